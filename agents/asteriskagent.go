@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -299,6 +300,27 @@ func (sma *AsteriskAgent) V1DisconnectSession(args utils.AttrDisconnectSession,
 	return nil
 }
 
+// Internal method to return the IDs of the channels active in asterisk, used by SessionS to sync
+func (sma *AsteriskAgent) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]string) error {
+	byts, err := sma.astConn.Call(aringo.HTTP_GET, fmt.Sprintf("http://%s/ari/channels",
+		sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address), nil)
+	if err != nil {
+		return err
+	}
+	var channels []map[string]interface{}
+	if err := json.Unmarshal(byts, &channels); err != nil {
+		return err
+	}
+	chanIDs := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if chanID, canCast := utils.CastFieldIfToString(channel["id"]); canCast && chanID != "" {
+			chanIDs = append(chanIDs, chanID)
+		}
+	}
+	*sessionIDs = chanIDs
+	return nil
+}
+
 // rpcclient.RpcClientConnection interface
 func (sma *AsteriskAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.RPCCall(sma, serviceMethod, args, reply)
@@ -39,7 +39,11 @@ import (
 func NewDiameterAgent(cgrCfg *config.CGRConfig, sessionS rpcclient.RpcClientConnection,
 	pubsubs rpcclient.RpcClientConnection) (*DiameterAgent, error) {
 	da := &DiameterAgent{cgrCfg: cgrCfg, sessionS: sessionS,
-		pubsubs: pubsubs, connMux: new(sync.Mutex)}
+		pubsubs: pubsubs, connMux: new(sync.Mutex),
+		originIDs: make(utils.StringMap), oIDsMux: new(sync.RWMutex)}
+	if biClnt, isBiRPC := sessionS.(*utils.BiRPCInternalClient); isBiRPC {
+		biClnt.SetClientConn(da) // so SessionS can sync the sessions with the agent
+	}
 	if reflect.ValueOf(da.pubsubs).IsNil() {
 		da.pubsubs = nil // Empty it so we can check it later
 	}
@@ -57,6 +61,9 @@ type DiameterAgent struct {
 	sessionS rpcclient.RpcClientConnection // Connection towards CGR-SMG component
 	pubsubs  rpcclient.RpcClientConnection // Connection towards CGR-PubSub component
 	connMux  *sync.Mutex                   // Protect connection for read/write
+	// OriginIDs of the sessions initiated over the agent, Diameter offers no way to list them out of the clients
+	originIDs utils.StringMap
+	oIDsMux   *sync.RWMutex
 }
 
 // Creates the message handlers
@@ -159,6 +166,9 @@ func (da DiameterAgent) processCCR(ccr *CCR, reqProcessor *config.DARequestProce
 			var initReply sessions.V1InitSessionReply
 			err = da.sessionS.Call(utils.SessionSv1InitiateSession,
 				procVars.asV1InitSessionArgs(cgrEv), &initReply)
+			if err == nil {
+				da.setActiveSession(smgEv, true)
+			}
 			if procVars[utils.MetaCGRReply], err = NewCGRReply(&initReply, err); err != nil {
 				return
 			}
@@ -172,6 +182,7 @@ func (da DiameterAgent) processCCR(ccr *CCR, reqProcessor *config.DARequestProce
 		case 3, 4: // Handle them together since we generate CDR for them
 			var rpl string
 			if ccr.CCRequestType == 3 {
+				da.setActiveSession(smgEv, false)
 				if err = da.sessionS.Call(utils.SessionSv1TerminateSession,
 					procVars.asV1TerminateSessionArgs(cgrEv), &rpl); err != nil {
 					procVars[utils.MetaCGRReply] = map[string]interface{}{utils.Error: err.Error()}
@@ -267,3 +278,32 @@ func (self *DiameterAgent) handleALL(c diam.Conn, m *diam.Message) {
 func (self *DiameterAgent) ListenAndServe() error {
 	return diam.ListenAndServe(self.cgrCfg.DiameterAgentCfg().Listen, self.handlers(), nil)
 }
+
+// setActiveSession records the OriginID of the event as active or not
+func (da *DiameterAgent) setActiveSession(ev map[string]interface{}, active bool) {
+	originID, _ := utils.CastFieldIfToString(ev[utils.OriginID])
+	if originID == "" {
+		return
+	}
+	da.oIDsMux.Lock()
+	if active {
+		da.originIDs[originID] = true
+	} else {
+		delete(da.originIDs, originID)
+	}
+	da.oIDsMux.Unlock()
+}
+
+// rpcclient.RpcClientConnection interface
+func (da *DiameterAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(da, serviceMethod, args, reply)
+}
+
+// V1GetActiveSessionIDs returns the OriginIDs of the sessions initiated over the agent and not yet terminated,
+// used by SessionS to sync
+func (da *DiameterAgent) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]string) (err error) {
+	da.oIDsMux.RLock()
+	*sessionIDs = da.originIDs.Slice()
+	da.oIDsMux.RUnlock()
+	return
+}
@@ -0,0 +1,41 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDAGetActiveSessionIDs(t *testing.T) {
+	da := &DiameterAgent{originIDs: make(utils.StringMap), oIDsMux: new(sync.RWMutex)}
+	da.setActiveSession(map[string]interface{}{utils.OriginID: "111"}, true)
+	da.setActiveSession(map[string]interface{}{utils.OriginID: "222"}, true)
+	da.setActiveSession(map[string]interface{}{utils.Account: "1001"}, true) // no OriginID
+	da.setActiveSession(map[string]interface{}{utils.OriginID: "111"}, false)
+	var sessionIDs []string
+	if err := da.Call(utils.SessionSv1GetActiveSessionIDs, "", &sessionIDs); err != nil {
+		t.Error(err)
+	} else if eIDs := []string{"222"}; !reflect.DeepEqual(eIDs, sessionIDs) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, sessionIDs)
+	}
+}
@@ -21,6 +21,7 @@ package agents
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -353,3 +354,33 @@ func (fsa *FSsessions) V1DisconnectSession(args utils.AttrDisconnectSession, rep
 	*reply = utils.OK
 	return
 }
+
+// fsChannelUUIDs extracts the channel UUIDs out of the CSV reply of "show channels" command
+func fsChannelUUIDs(showChannels string) (uuids []string) {
+	for _, line := range strings.Split(showChannels, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "uuid,") || // header
+			strings.HasSuffix(line, "total.") {
+			continue
+		}
+		uuids = append(uuids, strings.SplitN(line, ",", 2)[0])
+	}
+	return
+}
+
+// Internal method to return the UUIDs of the channels active on FreeSWITCH, used by SessionS to sync
+func (fsa *FSsessions) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]string) (err error) {
+	var uuids []string
+	for connId, fSock := range fsa.conns {
+		if !fSock.Connected() { // partial list would terminate the sessions on this connection
+			return fmt.Errorf("fsock not connected for connection id: %s", connId)
+		}
+		var chans string
+		if chans, err = fSock.SendApiCmd("show channels"); err != nil {
+			return
+		}
+		uuids = append(uuids, fsChannelUUIDs(chans)...)
+	}
+	*sessionIDs = uuids
+	return
+}
@@ -0,0 +1,40 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFsChannelUUIDs(t *testing.T) {
+	showChannels := `uuid,direction,created,created_epoch,name,state,cid_name,cid_num
+3dfa9d4b-7f1a-4ea4-8b1c-1e7c8b1bd9a1,inbound,2018-05-03 10:01:02,1525334462,sofia/cgrates/1001@127.0.0.1,CS_EXECUTE,1001,1001
+8f0c2f5e-3a9e-4c3b-9d1e-2a0c6f1e7b42,outbound,2018-05-03 10:01:03,1525334463,sofia/cgrates/1002@127.0.0.1,CS_EXCHANGE_MEDIA,1001,1001
+
+2 total.
+`
+	eUUIDs := []string{"3dfa9d4b-7f1a-4ea4-8b1c-1e7c8b1bd9a1", "8f0c2f5e-3a9e-4c3b-9d1e-2a0c6f1e7b42"}
+	if uuids := fsChannelUUIDs(showChannels); !reflect.DeepEqual(eUUIDs, uuids) {
+		t.Errorf("expecting: %+v, received: %+v", eUUIDs, uuids)
+	}
+	if uuids := fsChannelUUIDs("\n0 total.\n"); len(uuids) != 0 {
+		t.Errorf("received: %+v", uuids)
+	}
+}
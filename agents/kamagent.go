@@ -19,10 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/sessions"
@@ -34,16 +37,19 @@ func NewKamailioAgent(kaCfg *config.KamAgentCfg,
 	sessionS *utils.BiRPCInternalClient, timezone string) (ka *KamailioAgent) {
 	ka = &KamailioAgent{cfg: kaCfg, sessionS: sessionS,
 		timezone: timezone,
-		conns:    make(map[string]*kamevapi.KamEvapi)}
+		conns:    make(map[string]*kamevapi.KamEvapi),
+		dlgLists: make(map[string]chan []string)}
 	ka.sessionS.SetClientConn(ka) // pass the connection to KA back into smg so we can receive the disconnects
 	return
 }
 
 type KamailioAgent struct {
-	cfg      *config.KamAgentCfg
-	sessionS *utils.BiRPCInternalClient
-	timezone string
-	conns    map[string]*kamevapi.KamEvapi
+	cfg        *config.KamAgentCfg
+	sessionS   *utils.BiRPCInternalClient
+	timezone   string
+	conns      map[string]*kamevapi.KamEvapi
+	dlgLists   map[string]chan []string // OriginIDs of the dialogs listed by each connection
+	dlgListMux sync.Mutex               // one dialogs query at a time
 }
 
 func (self *KamailioAgent) Connect() error {
@@ -54,10 +60,13 @@ func (self *KamailioAgent) Connect() error {
 		regexp.MustCompile(CGR_CALL_START): []func([]byte, string){
 			self.onCallStart},
 		regexp.MustCompile(CGR_CALL_END): []func([]byte, string){self.onCallEnd},
+		regexp.MustCompile(CGR_DLG_LIST_REPLY): []func([]byte, string){
+			self.onDlgListReply},
 	}
 	errChan := make(chan error)
 	for _, connCfg := range self.cfg.EvapiConns {
 		connID := utils.GenUUID()
+		self.dlgLists[connID] = make(chan []string, 1)
 		logger := log.New(utils.Logger, "kamevapi:", 2)
 		if self.conns[connID], err = kamevapi.NewKamEvapi(connCfg.Address, connID, connCfg.Reconnects, eventHandlers, logger); err != nil {
 			return err
@@ -200,6 +209,20 @@ func (ka *KamailioAgent) onCallEnd(evData []byte, connID string) {
 	}
 }
 
+// onDlgListReply is called when Kamailio replies to CGR_DLG_LIST with its active dialogs
+func (ka *KamailioAgent) onDlgListReply(evData []byte, connID string) {
+	var dlgRply KamDlgListReply
+	if err := json.Unmarshal(evData, &dlgRply); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> unmarshalling dialogs list: %s, error: %s",
+			utils.KamailioAgent, evData, err.Error()))
+		return
+	}
+	select {
+	case ka.dlgLists[connID] <- dlgRply.OriginIDs():
+	default: // nobody waiting for it anymore, ie: timeout
+	}
+}
+
 func (self *KamailioAgent) disconnectSession(connID string, dscEv *KamSessionDisconnect) error {
 	if err := self.conns[connID].Send(dscEv.String()); err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> failed sending disconnect request: %s,  connection id: %s, error %s",
@@ -222,3 +245,28 @@ func (ka *KamailioAgent) V1DisconnectSession(args utils.AttrDisconnectSession, r
 	*reply = utils.OK
 	return
 }
+
+// V1GetActiveSessionIDs returns the OriginIDs of the dialogs active on Kamailio, used by SessionS to sync
+// the dialogs are listed by Kamailio script on CGR_DLG_LIST event, out of dlg.list RPC command
+func (ka *KamailioAgent) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]string) (err error) {
+	ka.dlgListMux.Lock()
+	defer ka.dlgListMux.Unlock()
+	var originIDs []string
+	for connID, evapi := range ka.conns {
+		select {
+		case <-ka.dlgLists[connID]: // stale reply, arrived after timeout
+		default:
+		}
+		if err = evapi.Send(fmt.Sprintf(`{"Event":"%s"}`, CGR_DLG_LIST)); err != nil {
+			return
+		}
+		select {
+		case dlgIDs := <-ka.dlgLists[connID]:
+			originIDs = append(originIDs, dlgIDs...)
+		case <-time.After(config.CgrConfig().ReplyTimeout): // partial list would terminate the sessions on this connection
+			return fmt.Errorf("timeout waiting for the dialogs list on connection id: %s", connID)
+		}
+	}
+	*sessionIDs = originIDs
+	return
+}
@@ -36,6 +36,9 @@ const (
 	CGR_SESSION_DISCONNECT = "CGR_SESSION_DISCONNECT"
 	CGR_CALL_START         = "CGR_CALL_START"
 	CGR_CALL_END           = "CGR_CALL_END"
+	CGR_DLG_LIST           = "CGR_DLG_LIST"
+	CGR_DLG_LIST_REPLY     = "CGR_DLG_LIST_REPLY"
+	KamDlgOriginIDVar      = "cgrOriginID" // dialog variable holding the OriginID
 	KamTRIndex             = "tr_index"
 	KamTRLabel             = "tr_label"
 	KamHashEntry           = "h_entry"
@@ -71,6 +74,29 @@ func (self *KamSessionDisconnect) String() string {
 	return string(mrsh)
 }
 
+// KamDlgListReply is sent by Kamailio as reply to CGR_DLG_LIST, with the response of dlg.list RPC command
+type KamDlgListReply struct {
+	Event   string
+	Dialogs struct {
+		Result []struct {
+			Variables []map[string]string `json:"variables"`
+		} `json:"result"`
+	}
+}
+
+// OriginIDs returns the OriginIDs out of the dialog variables
+func (dlr *KamDlgListReply) OriginIDs() (originIDs []string) {
+	originIDs = make([]string, 0)
+	for _, dlg := range dlr.Dialogs.Result {
+		for _, dlgVar := range dlg.Variables {
+			if originID, has := dlgVar[KamDlgOriginIDVar]; has {
+				originIDs = append(originIDs, originID)
+			}
+		}
+	}
+	return
+}
+
 // NewKamEvent parses bytes received over the wire from Kamailio into KamEvent
 func NewKamEvent(kamEvData []byte) (KamEvent, error) {
 	kev := make(map[string]string)
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -371,3 +372,17 @@ func TestKamEvV1TerminateSessionArgs(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected.TerminateSession, rcv.TerminateSession)
 	}
 }
+
+func TestKamDlgListReplyOriginIDs(t *testing.T) {
+	evData := []byte(`{"Event":"CGR_DLG_LIST_REPLY","Dialogs":{"jsonrpc":"2.0","result":[
+{"h_entry":1201,"h_id":2,"call-id":"ODVkMDI2Mzc2MDY5N2EzODhjNTAzNTdlODhiZjRlYWQ","variables":[{"cgrReqType":"*prepaid"},{"cgrOriginID":"ODVkMDI2Mzc2MDY5N2EzODhjNTAzNTdlODhiZjRlYWQ;eb082607"}]},
+{"h_entry":1202,"h_id":3,"call-id":"YTNkZGI0OGQ0YjQ4NjMwYmI1YjkxNDI1YzM4NjZiMDU","variables":[]}],"id":1}}`)
+	var dlgRply KamDlgListReply
+	if err := json.Unmarshal(evData, &dlgRply); err != nil {
+		t.Fatal(err)
+	}
+	eOriginIDs := []string{"ODVkMDI2Mzc2MDY5N2EzODhjNTAzNTdlODhiZjRlYWQ;eb082607"}
+	if originIDs := dlgRply.OriginIDs(); !reflect.DeepEqual(eOriginIDs, originIDs) {
+		t.Errorf("expecting: %+v, received: %+v", eOriginIDs, originIDs)
+	}
+}
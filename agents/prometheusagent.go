@@ -0,0 +1,286 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/scheduler"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+	"github.com/cgrates/rpcclient"
+)
+
+// Prometheus metric families exported by PrometheusAgent
+const (
+	PromStatMetric           = "cgrates_stats_metric"
+	PromResourceUsage        = "cgrates_resource_usage"
+	PromResourceLimit        = "cgrates_resource_limit"
+	PromResourceUsages       = "cgrates_resource_active_usages"
+	PromThresholdHits        = "cgrates_threshold_hits"
+	PromCacheItems           = "cgrates_cache_items"
+	PromCacheGroups          = "cgrates_cache_groups"
+	PromActiveSessions       = "cgrates_sessions_active"
+	PromSchedulerQueueLength = "cgrates_scheduler_queue_length"
+)
+
+// promLabelEscaper escapes label values as required by the text exposition format
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promSample is one line within a metric family
+type promSample struct {
+	labels []string // label name and value pairs
+	value  float64
+}
+
+// String returns the sample without the metric name, eg: {tenant="cgrates.org"} 10
+func (ps *promSample) String() string {
+	var lbls []string
+	for i := 0; i+1 < len(ps.labels); i += 2 {
+		lbls = append(lbls, fmt.Sprintf(`%s="%s"`,
+			ps.labels[i], promLabelEscaper.Replace(ps.labels[i+1])))
+	}
+	var lblStr string
+	if len(lbls) != 0 {
+		lblStr = "{" + strings.Join(lbls, utils.FIELDS_SEP) + "}"
+	}
+	return lblStr + " " + strconv.FormatFloat(ps.value, 'g', -1, 64)
+}
+
+// writePromFamily writes a metric family together with its HELP and TYPE headers
+func writePromFamily(w io.Writer, name, help, typ string, smpls []*promSample) {
+	if len(smpls) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	for _, smpl := range smpls {
+		fmt.Fprintf(w, "%s%s\n", name, smpl.String())
+	}
+}
+
+// NewPrometheusAgent will construct a PrometheusAgent
+func NewPrometheusAgent(tenants []string, statS, resS, thdS,
+	cacheS, sessionS, schedS rpcclient.RpcClientConnection) *PrometheusAgent {
+	if statS != nil && reflect.ValueOf(statS).IsNil() { // fix nil value in interface
+		statS = nil
+	}
+	if resS != nil && reflect.ValueOf(resS).IsNil() {
+		resS = nil
+	}
+	if thdS != nil && reflect.ValueOf(thdS).IsNil() {
+		thdS = nil
+	}
+	if cacheS != nil && reflect.ValueOf(cacheS).IsNil() {
+		cacheS = nil
+	}
+	if sessionS != nil && reflect.ValueOf(sessionS).IsNil() {
+		sessionS = nil
+	}
+	if schedS != nil && reflect.ValueOf(schedS).IsNil() {
+		schedS = nil
+	}
+	return &PrometheusAgent{tenants: tenants, statS: statS, resS: resS,
+		thdS: thdS, cacheS: cacheS, sessionS: sessionS, schedS: schedS}
+}
+
+// PrometheusAgent exports internal metrics in Prometheus text exposition format
+// only the subsystems with a connection configured are exported
+type PrometheusAgent struct {
+	tenants  []string
+	statS    rpcclient.RpcClientConnection
+	resS     rpcclient.RpcClientConnection
+	thdS     rpcclient.RpcClientConnection
+	cacheS   rpcclient.RpcClientConnection
+	sessionS rpcclient.RpcClientConnection
+	schedS   rpcclient.RpcClientConnection
+}
+
+// ServeHTTP implements http.Handler interface
+func (pa *PrometheusAgent) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var buf bytes.Buffer
+	if pa.statS != nil {
+		pa.exportStatS(&buf)
+	}
+	if pa.resS != nil {
+		pa.exportResourceS(&buf)
+	}
+	if pa.thdS != nil {
+		pa.exportThresholdS(&buf)
+	}
+	if pa.cacheS != nil {
+		pa.exportCacheS(&buf)
+	}
+	if pa.sessionS != nil {
+		pa.exportSessionS(&buf)
+	}
+	if pa.schedS != nil {
+		pa.exportScheduler(&buf)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// logError warns about a subsystem which could not be queried, the rest of the metrics are still served
+func (pa *PrometheusAgent) logError(subsys string, err error) {
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> error: %s querying %s",
+			utils.PrometheusAgent, err.Error(), subsys))
+}
+
+// exportStatS exports the float values of the StatQueue metrics
+func (pa *PrometheusAgent) exportStatS(w io.Writer) {
+	var smpls []*promSample
+	for _, tnt := range pa.tenants {
+		var qIDs []string
+		if err := pa.statS.Call(utils.StatSv1GetQueueIDs, tnt, &qIDs); err != nil {
+			pa.logError(utils.StatService, err)
+			continue
+		}
+		sort.Strings(qIDs)
+		for _, qID := range qIDs {
+			var metrics map[string]float64
+			if err := pa.statS.Call(utils.StatSv1GetQueueFloatMetrics,
+				&utils.TenantID{Tenant: tnt, ID: qID}, &metrics); err != nil {
+				pa.logError(utils.StatService, err)
+				continue
+			}
+			metricIDs := make([]string, 0, len(metrics))
+			for metricID := range metrics {
+				metricIDs = append(metricIDs, metricID)
+			}
+			sort.Strings(metricIDs)
+			for _, metricID := range metricIDs {
+				if metrics[metricID] == engine.STATS_NA { // not enough items, no value to report
+					continue
+				}
+				smpls = append(smpls, &promSample{
+					labels: []string{"tenant", tnt, "queue", qID, "metric", metricID},
+					value:  metrics[metricID]})
+			}
+		}
+	}
+	writePromFamily(w, PromStatMetric, "Value of a StatQueue metric.", "gauge", smpls)
+}
+
+// exportResourceS exports the usage versus limit of the Resources
+func (pa *PrometheusAgent) exportResourceS(w io.Writer) {
+	var usageSmpls, limitSmpls, usagesSmpls []*promSample
+	for _, tnt := range pa.tenants {
+		var rIDs []string
+		if err := pa.resS.Call(utils.ResourceSv1GetResourceIDs, tnt, &rIDs); err != nil {
+			pa.logError(utils.ResourceS, err)
+			continue
+		}
+		sort.Strings(rIDs)
+		for _, rID := range rIDs {
+			var rSum engine.ResourceSummary
+			if err := pa.resS.Call(utils.ResourceSv1GetResourceSummary,
+				&utils.TenantID{Tenant: tnt, ID: rID}, &rSum); err != nil {
+				pa.logError(utils.ResourceS, err)
+				continue
+			}
+			lbls := []string{"tenant", tnt, "resource", rID}
+			usageSmpls = append(usageSmpls, &promSample{labels: lbls, value: rSum.TotalUsage})
+			limitSmpls = append(limitSmpls, &promSample{labels: lbls, value: rSum.Limit})
+			usagesSmpls = append(usagesSmpls, &promSample{labels: lbls, value: float64(rSum.Usages)})
+		}
+	}
+	writePromFamily(w, PromResourceUsage, "Units used out of a Resource.", "gauge", usageSmpls)
+	writePromFamily(w, PromResourceLimit, "Limit of a Resource.", "gauge", limitSmpls)
+	writePromFamily(w, PromResourceUsages, "Number of active usages of a Resource.", "gauge", usagesSmpls)
+}
+
+// exportThresholdS exports the hit counters of the Thresholds
+func (pa *PrometheusAgent) exportThresholdS(w io.Writer) {
+	var smpls []*promSample
+	for _, tnt := range pa.tenants {
+		var tIDs []string
+		if err := pa.thdS.Call(utils.ThresholdSv1GetThresholdIDs, tnt, &tIDs); err != nil {
+			pa.logError(utils.ThresholdS, err)
+			continue
+		}
+		sort.Strings(tIDs)
+		for _, tID := range tIDs {
+			var thd engine.Threshold
+			if err := pa.thdS.Call(utils.ThresholdSv1GetThreshold,
+				&utils.TenantID{Tenant: tnt, ID: tID}, &thd); err != nil {
+				pa.logError(utils.ThresholdS, err)
+				continue
+			}
+			smpls = append(smpls, &promSample{
+				labels: []string{"tenant", tnt, "threshold", tID},
+				value:  float64(thd.Hits)})
+		}
+	}
+	writePromFamily(w, PromThresholdHits, "Number of hits of a Threshold since its last reset.", "gauge", smpls)
+}
+
+// exportCacheS exports the item and group counters of the cache partitions
+func (pa *PrometheusAgent) exportCacheS(w io.Writer) {
+	var cacheStats map[string]*ltcache.CacheStats
+	if err := pa.cacheS.Call(utils.CacheSv1GetCacheStats, []string{}, &cacheStats); err != nil {
+		pa.logError(utils.CacheS, err)
+		return
+	}
+	cacheIDs := make([]string, 0, len(cacheStats))
+	for cacheID := range cacheStats {
+		cacheIDs = append(cacheIDs, cacheID)
+	}
+	sort.Strings(cacheIDs)
+	var itmSmpls, grpSmpls []*promSample
+	for _, cacheID := range cacheIDs {
+		lbls := []string{"cache", cacheID}
+		itmSmpls = append(itmSmpls, &promSample{labels: lbls, value: float64(cacheStats[cacheID].Items)})
+		grpSmpls = append(grpSmpls, &promSample{labels: lbls, value: float64(cacheStats[cacheID].Groups)})
+	}
+	writePromFamily(w, PromCacheItems, "Number of items in a cache partition.", "gauge", itmSmpls)
+	writePromFamily(w, PromCacheGroups, "Number of groups in a cache partition.", "gauge", grpSmpls)
+}
+
+// exportSessionS exports the number of active sessions
+func (pa *PrometheusAgent) exportSessionS(w io.Writer) {
+	var count int
+	if err := pa.sessionS.Call(utils.SMGenericV1GetActiveSessionsCount,
+		map[string]string{}, &count); err != nil {
+		pa.logError(utils.SessionS, err)
+		return
+	}
+	writePromFamily(w, PromActiveSessions, "Number of active sessions.", "gauge",
+		[]*promSample{&promSample{value: float64(count)}})
+}
+
+// exportScheduler exports the number of action timings queued in the scheduler
+func (pa *PrometheusAgent) exportScheduler(w io.Writer) {
+	var schedActs []*scheduler.ScheduledAction
+	if err := pa.schedS.Call(utils.ApierV1GetScheduledActions,
+		scheduler.ArgsGetScheduledActions{}, &schedActs); err != nil {
+		pa.logError(utils.SchedulerS, err)
+		return
+	}
+	writePromFamily(w, PromSchedulerQueueLength, "Number of action timings queued in the scheduler.", "gauge",
+		[]*promSample{&promSample{value: float64(len(schedActs))}})
+}
@@ -0,0 +1,103 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+// testPromConn mocks the subsystems queried by PrometheusAgent
+type testPromConn struct{}
+
+func (tpc *testPromConn) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	switch serviceMethod {
+	case utils.StatSv1GetQueueIDs:
+		*reply.(*[]string) = []string{"Stats1"}
+	case utils.StatSv1GetQueueFloatMetrics:
+		*reply.(*map[string]float64) = map[string]float64{
+			utils.MetaASR: 50, utils.MetaACD: engine.STATS_NA}
+	case utils.ResourceSv1GetResourceIDs:
+		*reply.(*[]string) = []string{"Res1"}
+	case utils.ResourceSv1GetResourceSummary:
+		*reply.(*engine.ResourceSummary) = engine.ResourceSummary{
+			Tenant: "cgrates.org", ID: "Res1", TotalUsage: 3, Limit: 10, Usages: 2}
+	case utils.ThresholdSv1GetThresholdIDs:
+		*reply.(*[]string) = []string{"Thd1"}
+	case utils.ThresholdSv1GetThreshold:
+		*reply.(*engine.Threshold) = engine.Threshold{Tenant: "cgrates.org", ID: "Thd1", Hits: 4}
+	case utils.CacheSv1GetCacheStats:
+		*reply.(*map[string]*ltcache.CacheStats) = map[string]*ltcache.CacheStats{
+			utils.CacheDestinations: &ltcache.CacheStats{Items: 5, Groups: 1}}
+	case utils.SMGenericV1GetActiveSessionsCount:
+		*reply.(*int) = 7
+	default:
+		return utils.ErrNotImplemented
+	}
+	return nil
+}
+
+func TestPromSampleString(t *testing.T) {
+	smpl := &promSample{labels: []string{"tenant", "cgrates.org", "metric", `*sum:"Usage"`}, value: 1.5}
+	if rcv := smpl.String(); rcv != `{tenant="cgrates.org",metric="*sum:\"Usage\""} 1.5` {
+		t.Errorf("received: %s", rcv)
+	}
+	smpl = &promSample{value: 10}
+	if rcv := smpl.String(); rcv != " 10" {
+		t.Errorf("received: %s", rcv)
+	}
+}
+
+func TestPrometheusAgentServeHTTP(t *testing.T) {
+	conn := new(testPromConn)
+	pa := NewPrometheusAgent([]string{"cgrates.org"}, conn, conn, conn, conn, conn, nil)
+	rec := httptest.NewRecorder()
+	pa.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	eOut := `# HELP cgrates_stats_metric Value of a StatQueue metric.
+# TYPE cgrates_stats_metric gauge
+cgrates_stats_metric{tenant="cgrates.org",queue="Stats1",metric="*asr"} 50
+# HELP cgrates_resource_usage Units used out of a Resource.
+# TYPE cgrates_resource_usage gauge
+cgrates_resource_usage{tenant="cgrates.org",resource="Res1"} 3
+# HELP cgrates_resource_limit Limit of a Resource.
+# TYPE cgrates_resource_limit gauge
+cgrates_resource_limit{tenant="cgrates.org",resource="Res1"} 10
+# HELP cgrates_resource_active_usages Number of active usages of a Resource.
+# TYPE cgrates_resource_active_usages gauge
+cgrates_resource_active_usages{tenant="cgrates.org",resource="Res1"} 2
+# HELP cgrates_threshold_hits Number of hits of a Threshold since its last reset.
+# TYPE cgrates_threshold_hits gauge
+cgrates_threshold_hits{tenant="cgrates.org",threshold="Thd1"} 4
+# HELP cgrates_cache_items Number of items in a cache partition.
+# TYPE cgrates_cache_items gauge
+cgrates_cache_items{cache="destinations"} 5
+# HELP cgrates_cache_groups Number of groups in a cache partition.
+# TYPE cgrates_cache_groups gauge
+cgrates_cache_groups{cache="destinations"} 1
+# HELP cgrates_sessions_active Number of active sessions.
+# TYPE cgrates_sessions_active gauge
+cgrates_sessions_active 7
+`
+	if rcv := rec.Body.String(); rcv != eOut {
+		t.Errorf("expecting: %s, received: %s", eOut, rcv)
+	}
+}
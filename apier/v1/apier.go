@@ -54,6 +54,11 @@ type ApierV1 struct {
 	HTTPPoster  *utils.HTTPPoster
 }
 
+// Call implements rpcclient.RpcClientConnection interface for internal RPC
+func (self *ApierV1) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(self, serviceMethod, args, reply)
+}
+
 func (self *ApierV1) GetDestination(dstId string, reply *engine.Destination) error {
 	if dst, err := self.DataManager.DataDB().GetDestination(dstId, false, utils.NonTransactional); err != nil {
 		return utils.ErrNotFound
@@ -1015,6 +1020,19 @@ func (self *ApierV1) ReloadCache(attrs utils.AttrReloadCache, reply *string) (er
 	if err = self.DataManager.CacheDataFromDB(utils.AttributeProfilePrefix, dataIDs, true); err != nil {
 		return
 	}
+	// DispatcherProfile
+	dataIDs = make([]string, 0)
+	if attrs.DispatcherProfileIDs == nil {
+		dataIDs = nil // Reload all
+	} else if len(*attrs.DispatcherProfileIDs) > 0 {
+		dataIDs = make([]string, len(*attrs.DispatcherProfileIDs))
+		for idx, dId := range *attrs.DispatcherProfileIDs {
+			dataIDs[idx] = dId
+		}
+	}
+	if err = self.DataManager.CacheDataFromDB(utils.DispatcherProfilePrefix, dataIDs, true); err != nil {
+		return
+	}
 
 	*reply = utils.OK
 	return nil
@@ -1316,6 +1334,14 @@ func (self *ApierV1) FlushCache(args utils.AttrReloadCache, reply *string) (err
 				true, utils.NonTransactional)
 		}
 	}
+	if args.DispatcherProfileIDs == nil {
+		engine.Cache.Clear([]string{utils.CacheDispatcherProfiles})
+	} else if len(*args.DispatcherProfileIDs) != 0 {
+		for _, key := range *args.DispatcherProfileIDs {
+			engine.Cache.Remove(utils.CacheDispatcherProfiles, key,
+				true, utils.NonTransactional)
+		}
+	}
 
 	*reply = utils.OK
 	return
@@ -1344,6 +1370,7 @@ func (self *ApierV1) GetCacheStats(attrs utils.AttrCacheStats, reply *utils.Cach
 	cs.Filters = len(engine.Cache.GetItemIDs(utils.CacheFilters, ""))
 	cs.SupplierProfiles = len(engine.Cache.GetItemIDs(utils.CacheSupplierProfiles, ""))
 	cs.AttributeProfiles = len(engine.Cache.GetItemIDs(utils.CacheAttributeProfiles, ""))
+	cs.DispatcherProfiles = len(engine.Cache.GetItemIDs(utils.CacheDispatcherProfiles, ""))
 
 	if self.CdrStatsSrv != nil {
 		var queueIds []string
@@ -1772,6 +1799,25 @@ func (v1 *ApierV1) GetCacheKeys(args utils.ArgsCacheKeys, reply *utils.ArgsCache
 		}
 	}
 
+	if args.DispatcherProfileIDs != nil {
+		var ids []string
+		if len(*args.DispatcherProfileIDs) != 0 {
+			for _, id := range *args.DispatcherProfileIDs {
+				if _, hasIt := engine.Cache.Get(utils.CacheDispatcherProfiles, id); hasIt {
+					ids = append(ids, id)
+				}
+			}
+		} else {
+			for _, id := range engine.Cache.GetItemIDs(utils.CacheDispatcherProfiles, "") {
+				ids = append(ids, id)
+			}
+		}
+		ids = args.Paginator.PaginateStringSlice(ids)
+		if len(ids) != 0 {
+			reply.DispatcherProfileIDs = &ids
+		}
+	}
+
 	return
 }
 
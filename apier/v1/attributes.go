@@ -94,7 +94,7 @@ func (alSv1 *AttributeSv1) GetAttributeForEvent(ev *utils.CGREvent,
 }
 
 // ProcessEvent will replace event fields with the ones in maching AttributeProfile
-func (alSv1 *AttributeSv1) ProcessEvent(ev *utils.CGREvent,
+func (alSv1 *AttributeSv1) ProcessEvent(ev *engine.AttrArgsProcessEvent,
 	reply *engine.AttrSProcessEventReply) error {
 	return alSv1.attrS.V1ProcessEvent(ev, reply)
 }
@@ -294,8 +294,12 @@ func testAttributeSProcessEvent(t *testing.T) {
 		},
 	}
 	eRply := &engine.AttrSProcessEventReply{
-		MatchedProfile: "ATTR_1",
-		AlteredFields:  []string{utils.Subject, utils.Account},
+		MatchedProfile:  "ATTR_1",
+		AlteredFields:   []string{utils.Subject, utils.Account},
+		MatchedProfiles: []string{"ATTR_1"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "ATTR_1",
+				AlteredFields: []string{utils.Subject, utils.Account}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSProcessEvent",
@@ -308,8 +312,12 @@ func testAttributeSProcessEvent(t *testing.T) {
 		},
 	}
 	eRply2 := &engine.AttrSProcessEventReply{
-		MatchedProfile: "ATTR_1",
-		AlteredFields:  []string{utils.Account, utils.Subject},
+		MatchedProfile:  "ATTR_1",
+		AlteredFields:   []string{utils.Account, utils.Subject},
+		MatchedProfiles: []string{"ATTR_1"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "ATTR_1",
+				AlteredFields: []string{utils.Account, utils.Subject}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSProcessEvent",
@@ -374,8 +382,12 @@ func testAttributeSProcessEventWithNoneSubstitute(t *testing.T) {
 		t.Error("Unexpected reply returned", result)
 	}
 	eRply := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{utils.Account, utils.Subject},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{utils.Account, utils.Subject},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{utils.Account, utils.Subject}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
@@ -387,8 +399,12 @@ func testAttributeSProcessEventWithNoneSubstitute(t *testing.T) {
 		},
 	}
 	eRply2 := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{utils.Subject, utils.Account},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{utils.Subject, utils.Account},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{utils.Subject, utils.Account}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
@@ -453,8 +469,12 @@ func testAttributeSProcessEventWithNoneSubstitute2(t *testing.T) {
 		t.Error("Unexpected reply returned", result)
 	}
 	eRply := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{"Account", "Subject"},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{"Account", "Subject"},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{"Account", "Subject"}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
@@ -466,8 +486,12 @@ func testAttributeSProcessEventWithNoneSubstitute2(t *testing.T) {
 		},
 	}
 	eRply2 := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{utils.Subject, utils.Account},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{utils.Subject, utils.Account},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{utils.Subject, utils.Account}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
@@ -531,8 +555,12 @@ func testAttributeSProcessEventWithNoneSubstitute3(t *testing.T) {
 		t.Error("Unexpected reply returned", result)
 	}
 	eRply := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{"Account", "Subject"},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{"Account", "Subject"},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{"Account", "Subject"}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
@@ -544,8 +572,12 @@ func testAttributeSProcessEventWithNoneSubstitute3(t *testing.T) {
 		},
 	}
 	eRply2 := &engine.AttrSProcessEventReply{
-		MatchedProfile: "AttributeWithNonSubstitute",
-		AlteredFields:  []string{utils.Subject, utils.Account},
+		MatchedProfile:  "AttributeWithNonSubstitute",
+		AlteredFields:   []string{utils.Subject, utils.Account},
+		MatchedProfiles: []string{"AttributeWithNonSubstitute"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "AttributeWithNonSubstitute",
+				AlteredFields: []string{utils.Subject, utils.Account}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSWithNoneSubstitute",
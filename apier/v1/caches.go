@@ -35,6 +35,11 @@ type CacheSv1 struct {
 	cacheS *engine.CacheS
 }
 
+// Call implements rpcclient.RpcClientConnection interface for internal RPC
+func (chSv1 *CacheSv1) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(chSv1, serviceMethod, args, reply)
+}
+
 // GetItemExpiryTime returns the expiryTime for an item
 func (chSv1 *CacheSv1) GetItemIDs(args *engine.ArgsGetCacheItemIDs,
 	reply *[]string) error {
@@ -19,11 +19,85 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/dispatcher"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
 )
 
+// GetDispatcherProfile returns a Dispatcher Profile
+func (apierV1 *ApierV1) GetDispatcherProfile(arg utils.TenantID, reply *engine.DispatcherProfile) error {
+	if missing := utils.MissingStructFields(&arg, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if dpp, err := apierV1.DataManager.GetDispatcherProfile(arg.Tenant, arg.ID, false, utils.NonTransactional); err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	} else {
+		*reply = *dpp
+	}
+	return nil
+}
+
+// SetDispatcherProfile add/update a new Dispatcher Profile
+func (apierV1 *ApierV1) SetDispatcherProfile(dpp *engine.DispatcherProfile, reply *string) error {
+	if missing := utils.MissingStructFields(dpp, []string{"Tenant", "ID", "Strategy"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierV1.DataManager.SetDispatcherProfile(dpp, true); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type ArgRemoveDispatcherProfile struct {
+	Tenant     string
+	ID         string
+	Subsystems []string
+}
+
+// RemoveDispatcherProfile remove a specific Dispatcher Profile
+func (apierV1 *ApierV1) RemoveDispatcherProfile(arg *ArgRemoveDispatcherProfile, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{"Tenant", "ID", "Subsystems"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierV1.DataManager.RemoveDispatcherProfile(arg.Tenant, arg.ID, arg.Subsystems, utils.NonTransactional, true); err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
+func NewDispatcherSv1(dps *dispatcher.DispatcherService) *DispatcherSv1 {
+	return &DispatcherSv1{dS: dps}
+}
+
+// Exports RPC from DispatcherS
+type DispatcherSv1 struct {
+	dS *dispatcher.DispatcherService
+}
+
+// Ping used to determine if the DispatcherS is active
+func (dS *DispatcherSv1) Ping(ign string, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// GetHostStatus returns the health of the hosts DispatcherS routes to
+func (dS *DispatcherSv1) GetHostStatus(args *dispatcher.ArgsGetHostStatus,
+	reply *[]*dispatcher.HostStatus) error {
+	return dS.dS.DispatcherSv1GetHostStatus(args, reply)
+}
+
 func NewDispatcherThresholdSv1(dps *dispatcher.DispatcherService) *DispatcherThresholdSv1 {
 	return &DispatcherThresholdSv1{dS: dps}
 }
@@ -141,7 +215,7 @@ func (dA *DispatcherAttributeSv1) GetAttributeForEvent(ev *dispatcher.CGREvWithA
 }
 
 // ProcessEvent implements AttributeSv1ProcessEvent
-func (dA *DispatcherAttributeSv1) ProcessEvent(ev *dispatcher.CGREvWithApiKey,
+func (dA *DispatcherAttributeSv1) ProcessEvent(ev *dispatcher.ArgsAttrProcessEventWithApiKey,
 	reply *engine.AttrSProcessEventReply) error {
 	return dA.dA.AttributeSv1ProcessEvent(ev, reply)
 }
@@ -195,3 +269,158 @@ func (dS *DispatcherSessionSv1) UpdateSession(args *dispatcher.UpdateSessionWith
 	reply *sessions.V1UpdateSessionReply) (err error) {
 	return dS.dS.SessionSv1UpdateSession(args, reply)
 }
+
+func NewDispatcherResponder(dps *dispatcher.DispatcherService) *DispatcherResponder {
+	return &DispatcherResponder{dS: dps}
+}
+
+// Exports RPC from RALs
+type DispatcherResponder struct {
+	dS *dispatcher.DispatcherService
+}
+
+// GetCost implements ResponderGetCost
+func (dR *DispatcherResponder) GetCost(args *dispatcher.CallDescriptorWithApiKey,
+	reply *engine.CallCost) error {
+	return dR.dS.ResponderGetCost(args, reply)
+}
+
+// Debit implements ResponderDebit
+func (dR *DispatcherResponder) Debit(args *dispatcher.CallDescriptorWithApiKey,
+	reply *engine.CallCost) error {
+	return dR.dS.ResponderDebit(args, reply)
+}
+
+// MaxDebit implements ResponderMaxDebit
+func (dR *DispatcherResponder) MaxDebit(args *dispatcher.CallDescriptorWithApiKey,
+	reply *engine.CallCost) error {
+	return dR.dS.ResponderMaxDebit(args, reply)
+}
+
+// GetMaxSessionTime implements ResponderGetMaxSessionTime
+func (dR *DispatcherResponder) GetMaxSessionTime(args *dispatcher.CallDescriptorWithApiKey,
+	reply *float64) error {
+	return dR.dS.ResponderGetMaxSessionTime(args, reply)
+}
+
+func NewDispatcherCdrsV1(dps *dispatcher.DispatcherService) *DispatcherCdrsV1 {
+	return &DispatcherCdrsV1{dS: dps}
+}
+
+// Exports RPC from CDRs
+type DispatcherCdrsV1 struct {
+	dS *dispatcher.DispatcherService
+}
+
+// ProcessCDR implements CdrsV1ProcessCDR
+func (dC *DispatcherCdrsV1) ProcessCDR(args *dispatcher.CDRWithApiKey,
+	reply *string) error {
+	return dC.dS.CdrsV1ProcessCDR(args, reply)
+}
+
+// RateCDRs implements CdrsV1RateCDRs
+func (dC *DispatcherCdrsV1) RateCDRs(args *dispatcher.ArgsRateCDRsWithApiKey,
+	reply *string) error {
+	return dC.dS.CdrsV1RateCDRs(args, reply)
+}
+
+func NewDispatcherCacheSv1(dps *dispatcher.DispatcherService) *DispatcherCacheSv1 {
+	return &DispatcherCacheSv1{dS: dps}
+}
+
+// Exports RPC from CacheS, registered as CacheSv1 in place of the local CacheS
+type DispatcherCacheSv1 struct {
+	dS *dispatcher.DispatcherService
+}
+
+// Ping implements CacheSv1Ping
+func (dC *DispatcherCacheSv1) Ping(ign string, reply *string) error {
+	return dC.dS.CacheSv1Ping(ign, reply)
+}
+
+// GetItemIDs implements CacheSv1GetItemIDs
+func (dC *DispatcherCacheSv1) GetItemIDs(args *dispatcher.ArgsGetCacheItemIDsWithApiKey,
+	reply *[]string) error {
+	return dC.dS.CacheSv1GetItemIDs(args, reply)
+}
+
+// HasItem implements CacheSv1HasItem
+func (dC *DispatcherCacheSv1) HasItem(args *dispatcher.ArgsGetCacheItemWithApiKey,
+	reply *bool) error {
+	return dC.dS.CacheSv1HasItem(args, reply)
+}
+
+// GetItemExpiryTime implements CacheSv1GetItemExpiryTime
+func (dC *DispatcherCacheSv1) GetItemExpiryTime(args *dispatcher.ArgsGetCacheItemWithApiKey,
+	reply *time.Time) error {
+	return dC.dS.CacheSv1GetItemExpiryTime(args, reply)
+}
+
+// RemoveItem implements CacheSv1RemoveItem
+func (dC *DispatcherCacheSv1) RemoveItem(args *dispatcher.ArgsGetCacheItemWithApiKey,
+	reply *string) error {
+	return dC.dS.CacheSv1RemoveItem(args, reply)
+}
+
+// Clear implements CacheSv1Clear
+func (dC *DispatcherCacheSv1) Clear(args *dispatcher.ArgsCacheIDsWithApiKey,
+	reply *string) error {
+	return dC.dS.CacheSv1Clear(args, reply)
+}
+
+// GetCacheStats implements CacheSv1GetCacheStats
+func (dC *DispatcherCacheSv1) GetCacheStats(args *dispatcher.ArgsCacheIDsWithApiKey,
+	reply *map[string]*ltcache.CacheStats) error {
+	return dC.dS.CacheSv1GetCacheStats(args, reply)
+}
+
+// PrecacheStatus implements CacheSv1PrecacheStatus
+func (dC *DispatcherCacheSv1) PrecacheStatus(args *dispatcher.ArgsCacheIDsWithApiKey,
+	reply *map[string]string) error {
+	return dC.dS.CacheSv1PrecacheStatus(args, reply)
+}
+
+// HasGroup implements CacheSv1HasGroup
+func (dC *DispatcherCacheSv1) HasGroup(args *dispatcher.ArgsGetGroupWithApiKey,
+	reply *bool) error {
+	return dC.dS.CacheSv1HasGroup(args, reply)
+}
+
+// GetGroupItemIDs implements CacheSv1GetGroupItemIDs
+func (dC *DispatcherCacheSv1) GetGroupItemIDs(args *dispatcher.ArgsGetGroupWithApiKey,
+	reply *[]string) error {
+	return dC.dS.CacheSv1GetGroupItemIDs(args, reply)
+}
+
+// RemoveGroup implements CacheSv1RemoveGroup
+func (dC *DispatcherCacheSv1) RemoveGroup(args *dispatcher.ArgsGetGroupWithApiKey,
+	reply *string) error {
+	return dC.dS.CacheSv1RemoveGroup(args, reply)
+}
+
+func NewDispatcherApierV1(dps *dispatcher.DispatcherService) *DispatcherApierV1 {
+	return &DispatcherApierV1{dS: dps}
+}
+
+// Exports the read-only account and balance queries of ApierV1
+type DispatcherApierV1 struct {
+	dS *dispatcher.DispatcherService
+}
+
+// GetAccount implements ApierV1GetAccount
+func (dA *DispatcherApierV1) GetAccount(args *dispatcher.AttrGetAccountWithApiKey,
+	reply *interface{}) error {
+	return dA.dS.ApierV1GetAccount(args, reply)
+}
+
+// GetAccounts implements ApierV1GetAccounts
+func (dA *DispatcherApierV1) GetAccounts(args *dispatcher.AttrGetAccountsWithApiKey,
+	reply *[]interface{}) error {
+	return dA.dS.ApierV1GetAccounts(args, reply)
+}
+
+// GetMaxUsage implements ApierV1GetMaxUsage
+func (dA *DispatcherApierV1) GetMaxUsage(args *dispatcher.UsageRecordWithApiKey,
+	reply *float64) error {
+	return dA.dS.ApierV1GetMaxUsage(args, reply)
+}
@@ -29,6 +29,9 @@ func (self *ApierV1) SetFilter(attrs *engine.Filter, reply *string) error {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
 	if err := self.DataManager.SetFilter(attrs); err != nil {
+		if utils.ErrHasPrefix(err, utils.ErrFilterCycle.Error()) {
+			return err
+		}
 		return utils.APIErrorHandler(err)
 	}
 	*reply = utils.OK
@@ -65,3 +68,29 @@ func (self *ApierV1) RemoveFilter(arg utils.TenantID, reply *string) error {
 	*reply = utils.OK
 	return nil
 }
+
+func NewFilterSv1(fS *engine.FilterS) *FilterSv1 {
+	return &FilterSv1{fS: fS}
+}
+
+// Exports RPC from FilterS
+type FilterSv1 struct {
+	fS *engine.FilterS
+}
+
+// Call implements rpcclient.RpcClientConnection interface for internal RPC
+func (fSv1 *FilterSv1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(fSv1, serviceMethod, args, reply)
+}
+
+// ExplainFilters evaluates the filters for the event, returning the result of each filter rule
+func (fSv1 *FilterSv1) ExplainFilters(args *engine.ArgsExplainFilters,
+	reply *engine.FiltersExplanation) error {
+	return fSv1.fS.V1ExplainFilters(args, reply)
+}
+
+func (fSv1 *FilterSv1) Ping(ign string, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
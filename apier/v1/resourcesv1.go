@@ -57,6 +57,26 @@ func (rsv1 *ResourceSv1) ReleaseResources(args utils.ArgRSv1ResourceUsage, reply
 	return rsv1.rls.V1ReleaseResource(args, reply)
 }
 
+// GetResourceIDs returns list of resourceIDs registered for a tenant
+func (rsv1 *ResourceSv1) GetResourceIDs(tenant string, rIDs *[]string) error {
+	return rsv1.rls.V1GetResourceIDs(tenant, rIDs)
+}
+
+// GetResourceSummary returns the active usage of a Resource versus its limit
+func (rsv1 *ResourceSv1) GetResourceSummary(tntID *utils.TenantID, reply *engine.ResourceSummary) error {
+	return rsv1.rls.V1GetResourceSummary(tntID, reply)
+}
+
+// GetResourceUsages returns the usages holding a Resource
+func (rsv1 *ResourceSv1) GetResourceUsages(tntID *utils.TenantID, reply *[]*engine.ResourceUsageReport) error {
+	return rsv1.rls.V1GetResourceUsages(tntID, reply)
+}
+
+// ReleaseResourceUsages force-releases usages out of a Resource, by ID or by age
+func (rsv1 *ResourceSv1) ReleaseResourceUsages(args *engine.ArgsReleaseResourceUsages, reply *[]string) error {
+	return rsv1.rls.V1ReleaseResourceUsages(args, reply)
+}
+
 // GetResourceProfile returns a resource configuration
 func (apierV1 *ApierV1) GetResourceProfile(arg utils.TenantID, reply *engine.ResourceProfile) error {
 	if missing := utils.MissingStructFields(&arg, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
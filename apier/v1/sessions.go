@@ -96,6 +96,11 @@ func (ssv1 *SessionSv1) GetPassiveSessions(args map[string]string, rply *[]*sess
 	return ssv1.SMG.BiRPCV1GetPassiveSessions(nil, args, rply)
 }
 
+// SyncSessions terminates the active sessions which are not known anymore to the agents
+func (ssv1 *SessionSv1) SyncSessions(ignParam string, rply *string) error {
+	return ssv1.SMG.BiRPCv1SyncSessions(nil, ignParam, rply)
+}
+
 func (ssv1 *SessionSv1) BiRpcAuthorizeEvent(clnt *rpc2.Client, args *sessions.V1AuthorizeArgs,
 	rply *sessions.V1AuthorizeReply) error {
 	return ssv1.SMG.BiRPCv1AuthorizeEvent(clnt, args, rply)
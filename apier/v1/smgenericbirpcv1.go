@@ -42,6 +42,7 @@ func (self *SMGenericBiRpcV1) Handlers() map[string]interface{} {
 		"SMGenericV1.ProcessCDR":              self.ProcessCDR,
 		"SMGenericV1.GetActiveSessions":       self.GetActiveSessions,
 		"SMGenericV1.GetActiveSessionsCount":  self.GetActiveSessionsCount,
+		"SMGenericV1.GetActiveOriginIDs":      self.GetActiveOriginIDs,
 		"SMGenericV1.GetPassiveSessions":      self.GetPassiveSessions,
 		"SMGenericV1.GetPassiveSessionsCount": self.GetPassiveSessionsCount,
 		"SMGenericV1.ReplicateActiveSessions": self.ReplicateActiveSessions,
@@ -86,6 +87,10 @@ func (self *SMGenericBiRpcV1) GetActiveSessionsCount(clnt *rpc2.Client, attrs ma
 	return self.sm.BiRPCV1GetActiveSessionsCount(clnt, attrs, reply)
 }
 
+func (self *SMGenericBiRpcV1) GetActiveOriginIDs(clnt *rpc2.Client, originIDs []string, reply *[]string) error {
+	return self.sm.BiRPCV1GetActiveOriginIDs(clnt, originIDs, reply)
+}
+
 func (self *SMGenericBiRpcV1) GetPassiveSessions(clnt *rpc2.Client, attrs map[string]string, reply *[]*sessions.ActiveSession) error {
 	return self.sm.BiRPCV1GetPassiveSessions(clnt, attrs, reply)
 }
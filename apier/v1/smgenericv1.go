@@ -74,6 +74,10 @@ func (self *SMGenericV1) GetActiveSessionsCount(attrs map[string]string, reply *
 	return self.SMG.BiRPCV1GetActiveSessionsCount(nil, attrs, reply)
 }
 
+func (self *SMGenericV1) GetActiveOriginIDs(originIDs []string, reply *[]string) error {
+	return self.SMG.BiRPCV1GetActiveOriginIDs(nil, originIDs, reply)
+}
+
 func (self *SMGenericV1) GetPassiveSessions(attrs map[string]string, reply *[]*sessions.ActiveSession) error {
 	return self.SMG.BiRPCV1GetPassiveSessions(nil, attrs, reply)
 }
@@ -135,7 +135,8 @@ func startCdrc(internalCdrSChan, internalRaterChan chan rpcclient.RpcClientConne
 
 func startSessionS(internalSMGChan, internalRaterChan, internalResourceSChan, internalThresholdSChan,
 	internalStatSChan, internalSupplierSChan, internalAttrSChan,
-	internalCDRSChan chan rpcclient.RpcClientConnection, server *utils.Server, exitChan chan bool) {
+	internalCDRSChan chan rpcclient.RpcClientConnection, dm *engine.DataManager,
+	server *utils.Server, exitChan chan bool) {
 	utils.Logger.Info("Starting CGRateS Session service.")
 	var err error
 	var ralsConns, resSConns, threshSConns, statSConns, suplSConns, attrSConns, cdrsConn *rpcclient.RpcClientPool
@@ -215,7 +216,7 @@ func startSessionS(internalSMGChan, internalRaterChan, internalResourceSChan, in
 		exitChan <- true
 		return
 	}
-	sm := sessions.NewSMGeneric(cfg, ralsConns, resSConns, threshSConns, statSConns,
+	sm := sessions.NewSMGeneric(cfg, dm, ralsConns, resSConns, threshSConns, statSConns,
 		suplSConns, attrSConns, cdrsConn, smgReplConns, cfg.DefaultTimezone)
 	if err = sm.Connect(); err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> error: %s!", utils.SessionS, err))
@@ -264,11 +265,17 @@ func startAsteriskAgent(internalSMGChan chan rpcclient.RpcClientConnection, exit
 func startDiameterAgent(internalSMGChan, internalPubSubSChan chan rpcclient.RpcClientConnection, exitChan chan bool) {
 	var err error
 	utils.Logger.Info("Starting CGRateS DiameterAgent service")
-	var smgConn, pubsubConn *rpcclient.RpcClientPool
-	if len(cfg.DiameterAgentCfg().SessionSConns) != 0 {
+	var smgConn rpcclient.RpcClientConnection
+	var pubsubConn *rpcclient.RpcClientPool
+	if sSConns := cfg.DiameterAgentCfg().SessionSConns; len(sSConns) == 1 &&
+		sSConns[0].Address == utils.MetaInternal { // bidirectional so SessionS can sync the sessions with the agent
+		smgRpcConn := <-internalSMGChan
+		internalSMGChan <- smgRpcConn
+		smgConn = utils.NewBiRPCInternalClient(smgRpcConn.(*sessions.SMGeneric))
+	} else if len(sSConns) != 0 {
 		smgConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey, cfg.TLSClientCerificate,
 			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
-			cfg.DiameterAgentCfg().SessionSConns, internalSMGChan, cfg.InternalTtl)
+			sSConns, internalSMGChan, cfg.InternalTtl)
 		if err != nil {
 			utils.Logger.Crit(fmt.Sprintf("<DiameterAgent> Could not connect to SMG: %s", err.Error()))
 			exitChan <- true
@@ -378,6 +385,93 @@ func startHTTPAgent(internalSMGChan chan rpcclient.RpcClientConnection,
 	exitChan <- true
 }
 
+func startPrometheusAgent(internalStatSChan, internalRsChan, internalThresholdSChan,
+	internalCacheSChan, internalSMGChan, internalApierV1Chan chan rpcclient.RpcClientConnection,
+	server *utils.Server, exitChan chan bool) {
+	utils.Logger.Info("Starting Prometheus agent")
+	var err error
+	var statSConn, resSConn, thdSConn, cacheSConn, sSConn, schedSConn *rpcclient.RpcClientPool
+	if len(cfg.PrometheusAgentCfg().StatSConns) != 0 {
+		statSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().StatSConns, internalStatSChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.StatService, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.PrometheusAgentCfg().ResourceSConns) != 0 {
+		resSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().ResourceSConns, internalRsChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.ResourceS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.PrometheusAgentCfg().ThresholdSConns) != 0 {
+		thdSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().ThresholdSConns, internalThresholdSChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.ThresholdS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.PrometheusAgentCfg().CacheSConns) != 0 {
+		cacheSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().CacheSConns, internalCacheSChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.CacheS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.PrometheusAgentCfg().SessionSConns) != 0 {
+		sSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().SessionSConns, internalSMGChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.SessionS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.PrometheusAgentCfg().SchedulerConns) != 0 {
+		schedSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey,
+			cfg.TLSClientCerificate, cfg.ConnectAttempts, cfg.Reconnects,
+			cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.PrometheusAgentCfg().SchedulerConns, internalApierV1Chan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not connect to %s, error: %s",
+				utils.PrometheusAgent, utils.SchedulerS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	tenants := cfg.PrometheusAgentCfg().Tenants
+	if len(tenants) == 0 {
+		tenants = []string{cfg.DefaultTenant}
+	}
+	server.RegisterHttpHandler(cfg.PrometheusAgentCfg().Url,
+		agents.NewPrometheusAgent(tenants, statSConn, resSConn, thdSConn,
+			cacheSConn, sSConn, schedSConn))
+}
+
 func startCDRS(internalCdrSChan chan rpcclient.RpcClientConnection,
 	cdrDb engine.CdrStorage, dm *engine.DataManager,
 	internalRaterChan, internalPubSubSChan, internalAttributeSChan, internalUserSChan, internalAliaseSChan,
@@ -547,7 +641,8 @@ func startAttributeService(internalAttributeSChan chan rpcclient.RpcClientConnec
 	<-cacheS.GetPrecacheChannel(utils.CacheAttributeProfiles)
 
 	aS, err := engine.NewAttributeService(dm, filterS,
-		cfg.AttributeSCfg().StringIndexedFields, cfg.AttributeSCfg().PrefixIndexedFields)
+		cfg.AttributeSCfg().StringIndexedFields, cfg.AttributeSCfg().PrefixIndexedFields,
+		cfg.AttributeSCfg().SuffixIndexedFields, cfg.AttributeSCfg().ProcessRuns)
 	if err != nil {
 		utils.Logger.Crit(
 			fmt.Sprintf("<%s> Could not init, error: %s",
@@ -571,10 +666,10 @@ func startAttributeService(internalAttributeSChan chan rpcclient.RpcClientConnec
 }
 
 func startResourceService(internalRsChan chan rpcclient.RpcClientConnection, cacheS *engine.CacheS,
-	internalThresholdSChan chan rpcclient.RpcClientConnection, cfg *config.CGRConfig,
+	internalThresholdSChan, internalSMGChan chan rpcclient.RpcClientConnection, cfg *config.CGRConfig,
 	dm *engine.DataManager, server *utils.Server, exitChan chan bool, filterSChan chan *engine.FilterS) {
 	var err error
-	var thdSConn *rpcclient.RpcClientPool
+	var thdSConn, sSConn *rpcclient.RpcClientPool
 	filterS := <-filterSChan
 	filterSChan <- filterS
 	if len(cfg.ResourceSCfg().ThresholdSConns) != 0 { // Stats connection init
@@ -591,7 +686,8 @@ func startResourceService(internalRsChan chan rpcclient.RpcClientConnection, cac
 	<-cacheS.GetPrecacheChannel(utils.CacheResources)
 
 	rS, err := engine.NewResourceService(dm, cfg.ResourceSCfg().StoreInterval,
-		thdSConn, filterS, cfg.ResourceSCfg().StringIndexedFields, cfg.ResourceSCfg().PrefixIndexedFields)
+		thdSConn, filterS, cfg.ResourceSCfg().StringIndexedFields, cfg.ResourceSCfg().PrefixIndexedFields,
+		cfg.ResourceSCfg().SuffixIndexedFields)
 	if err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<ResourceS> Could not init, error: %s", err.Error()))
 		exitChan <- true
@@ -610,6 +706,17 @@ func startResourceService(internalRsChan chan rpcclient.RpcClientConnection, cac
 	rsV1 := v1.NewResourceSv1(rS)
 	server.RpcRegister(rsV1)
 	internalRsChan <- rsV1
+	if len(cfg.ResourceSCfg().SessionSConns) != 0 { // connect after publishing ourselves since SessionS can depend on us
+		sSConn, err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey, cfg.TLSClientCerificate,
+			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.ResourceSCfg().SessionSConns, internalSMGChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<ResourceS> Could not connect to SessionS: %s", err.Error()))
+			exitChan <- true
+			return
+		}
+		go rS.RunReconcile(sSConn, cfg.ResourceSCfg().ReconcileInterval)
+	}
 }
 
 // startStatService fires up the StatS
@@ -634,7 +741,8 @@ func startStatService(internalStatSChan chan rpcclient.RpcClientConnection, cach
 	<-cacheS.GetPrecacheChannel(utils.CacheStatQueues)
 
 	sS, err := engine.NewStatService(dm, cfg.StatSCfg().StoreInterval,
-		thdSConn, filterS, cfg.StatSCfg().StringIndexedFields, cfg.StatSCfg().PrefixIndexedFields)
+		thdSConn, filterS, cfg.StatSCfg().StringIndexedFields, cfg.StatSCfg().PrefixIndexedFields,
+		cfg.StatSCfg().SuffixIndexedFields)
 	if err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<StatS> Could not init, error: %s", err.Error()))
 		exitChan <- true
@@ -664,7 +772,8 @@ func startThresholdService(internalThresholdSChan chan rpcclient.RpcClientConnec
 	<-cacheS.GetPrecacheChannel(utils.CacheThresholds)
 
 	tS, err := engine.NewThresholdService(dm, cfg.ThresholdSCfg().StringIndexedFields,
-		cfg.ThresholdSCfg().PrefixIndexedFields, cfg.ThresholdSCfg().StoreInterval, filterS)
+		cfg.ThresholdSCfg().PrefixIndexedFields, cfg.ThresholdSCfg().SuffixIndexedFields,
+		cfg.ThresholdSCfg().StoreInterval, filterS)
 	if err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<ThresholdS> Could not init, error: %s", err.Error()))
 		exitChan <- true
@@ -718,7 +827,8 @@ func startSupplierService(internalSupplierSChan chan rpcclient.RpcClientConnecti
 	<-cacheS.GetPrecacheChannel(utils.CacheSupplierProfiles)
 
 	splS, err := engine.NewSupplierService(dm, cfg.DefaultTimezone, filterS, cfg.SupplierSCfg().StringIndexedFields,
-		cfg.SupplierSCfg().PrefixIndexedFields, resourceSConn, statSConn)
+		cfg.SupplierSCfg().PrefixIndexedFields, cfg.SupplierSCfg().SuffixIndexedFields,
+		resourceSConn, statSConn)
 	if err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s",
 			utils.SupplierS, err.Error()))
@@ -741,10 +851,14 @@ func startSupplierService(internalSupplierSChan chan rpcclient.RpcClientConnecti
 
 // startFilterService fires up the FilterS
 func startFilterService(filterSChan chan *engine.FilterS, cacheS *engine.CacheS,
-	internalStatSChan chan rpcclient.RpcClientConnection, cfg *config.CGRConfig,
-	dm *engine.DataManager, exitChan chan bool) {
+	internalStatSChan, internalRsChan chan rpcclient.RpcClientConnection, cfg *config.CGRConfig,
+	dm *engine.DataManager, server *utils.Server, exitChan chan bool) {
 	<-cacheS.GetPrecacheChannel(utils.CacheFilters)
-	filterSChan <- engine.NewFilterS(cfg, internalStatSChan, dm)
+	filterS := engine.NewFilterS(cfg, internalStatSChan, internalRsChan, dm)
+	if cfg.FilterSCfg().APIsEnabled {
+		server.RpcRegister(v1.NewFilterSv1(filterS))
+	}
+	filterSChan <- filterS
 }
 
 // loaderService will start and register APIs for LoaderService if enabled
@@ -759,12 +873,17 @@ func loaderService(cacheS *engine.CacheS, cfg *config.CGRConfig,
 }
 
 // startDispatcherService fires up the DispatcherS
-func startDispatcherService(internalDispatcherSChan, internalRaterChan chan rpcclient.RpcClientConnection,
+func startDispatcherService(internalDispatcherSChan, internalRaterChan,
+	internalCdrSChan, internalCacheSChan, internalApierV1Chan chan rpcclient.RpcClientConnection,
 	cacheS *engine.CacheS, dm *engine.DataManager,
-	server *utils.Server, exitChan chan bool) {
+	server *utils.Server, exitChan chan bool, filterSChan chan *engine.FilterS) {
 	utils.Logger.Info("Starting CGRateS Dispatcher service.")
 	var err error
-	var ralsConns, resSConns, threshSConns, statSConns, suplSConns, attrSConns, sessionsSConns *rpcclient.RpcClientPool
+	filterS := <-filterSChan
+	filterSChan <- filterS
+	<-cacheS.GetPrecacheChannel(utils.CacheDispatcherProfiles)
+	var ralsConns, resSConns, threshSConns, statSConns, suplSConns, attrSConns, sessionsSConns,
+		cdrSConns, cacheSConns, apierConns *rpcclient.RpcClientPool
 
 	cfg.DispatcherSCfg().DispatchingStrategy = strings.TrimPrefix(cfg.DispatcherSCfg().DispatchingStrategy,
 		utils.Meta) // remote * from DispatchingStrategy
@@ -838,8 +957,50 @@ func startDispatcherService(internalDispatcherSChan, internalRaterChan chan rpcc
 			return
 		}
 	}
-	dspS, err := dispatcher.NewDispatcherService(dm, ralsConns, resSConns, threshSConns, statSConns,
-		suplSConns, attrSConns, sessionsSConns)
+	if len(cfg.DispatcherSCfg().CDRsConns) != 0 {
+		cdrSConns, err = engine.NewRPCPool(cfg.DispatcherSCfg().DispatchingStrategy, cfg.TLSClientKey, cfg.TLSClientCerificate,
+			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.DispatcherSCfg().CDRsConns, internalCdrSChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> Could not connect to CDRs: %s", utils.DispatcherS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.DispatcherSCfg().CacheSConns) != 0 {
+		cacheSConns, err = engine.NewRPCPool(cfg.DispatcherSCfg().DispatchingStrategy, cfg.TLSClientKey, cfg.TLSClientCerificate,
+			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.DispatcherSCfg().CacheSConns, internalCacheSChan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> Could not connect to CacheS: %s", utils.DispatcherS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	if len(cfg.DispatcherSCfg().ApierConns) != 0 {
+		apierConns, err = engine.NewRPCPool(cfg.DispatcherSCfg().DispatchingStrategy, cfg.TLSClientKey, cfg.TLSClientCerificate,
+			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
+			cfg.DispatcherSCfg().ApierConns, internalApierV1Chan, cfg.InternalTtl)
+		if err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> Could not connect to ApierV1: %s", utils.DispatcherS, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	hostConns := make(map[string]rpcclient.RpcClientConnection)
+	for hostID, haCfgs := range cfg.DispatcherSCfg().Hosts {
+		if hostConns[hostID], err = engine.NewRPCPool(rpcclient.POOL_FIRST, cfg.TLSClientKey, cfg.TLSClientCerificate,
+			cfg.ConnectAttempts, cfg.Reconnects, cfg.ConnectTimeout, cfg.ReplyTimeout,
+			haCfgs, nil, cfg.InternalTtl); err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> Could not connect to host: %s, error: %s",
+				utils.DispatcherS, hostID, err.Error()))
+			exitChan <- true
+			return
+		}
+	}
+	dspS, err := dispatcher.NewDispatcherService(dm, cfg.DispatcherSCfg(),
+		filterS, hostConns, ralsConns, resSConns, threshSConns, statSConns,
+		suplSConns, attrSConns, sessionsSConns, cdrSConns, cacheSConns, apierConns)
 	if err != nil {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s", utils.DispatcherS, err.Error()))
 		exitChan <- true
@@ -853,31 +1014,63 @@ func startDispatcherService(internalDispatcherSChan, internalRaterChan chan rpcc
 		exitChan <- true
 		return
 	}()
-	if !cfg.ThresholdSCfg().Enabled && len(cfg.DispatcherSCfg().ThreshSConns) != 0 {
+	if !cfg.ThresholdSCfg().Enabled && (len(cfg.DispatcherSCfg().ThreshSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.ThresholdSv1,
 			v1.NewDispatcherThresholdSv1(dspS))
 	}
-	if !cfg.StatSCfg().Enabled && len(cfg.DispatcherSCfg().StatSConns) != 0 {
+	if !cfg.StatSCfg().Enabled && (len(cfg.DispatcherSCfg().StatSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.StatSv1,
 			v1.NewDispatcherStatSv1(dspS))
 	}
-	if !cfg.ResourceSCfg().Enabled && len(cfg.DispatcherSCfg().ResSConns) != 0 {
+	if !cfg.ResourceSCfg().Enabled && (len(cfg.DispatcherSCfg().ResSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.ResourceSv1,
 			v1.NewDispatcherResourceSv1(dspS))
 	}
-	if !cfg.SupplierSCfg().Enabled && len(cfg.DispatcherSCfg().SupplSConns) != 0 {
+	if !cfg.SupplierSCfg().Enabled && (len(cfg.DispatcherSCfg().SupplSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.SupplierSv1,
 			v1.NewDispatcherSupplierSv1(dspS))
 	}
-	if !cfg.AttributeSCfg().Enabled && len(cfg.DispatcherSCfg().AttrSConns) != 0 {
+	if !cfg.AttributeSCfg().Enabled && (len(cfg.DispatcherSCfg().AttrSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.AttributeSv1,
 			v1.NewDispatcherAttributeSv1(dspS))
 	}
-	if !cfg.SessionSCfg().Enabled && len(cfg.DispatcherSCfg().SessionSConns) != 0 {
+	if !cfg.SessionSCfg().Enabled && (len(cfg.DispatcherSCfg().SessionSConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
 		server.RpcRegisterName(utils.SessionSv1,
 			v1.NewDispatcherSessionSv1(dspS))
 	}
+	if !cfg.RALsEnabled && (len(cfg.DispatcherSCfg().RALsConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
+		server.RpcRegisterName(utils.Responder,
+			v1.NewDispatcherResponder(dspS))
+	}
+	if !cfg.RALsEnabled && (len(cfg.DispatcherSCfg().ApierConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
+		server.RpcRegisterName(utils.ApierV1,
+			v1.NewDispatcherApierV1(dspS))
+	}
+	if !cfg.CDRSEnabled && (len(cfg.DispatcherSCfg().CDRsConns) != 0 ||
+		len(cfg.DispatcherSCfg().Hosts) != 0) {
+		server.RpcRegisterName(utils.CdrsV1,
+			v1.NewDispatcherCdrsV1(dspS))
+	}
+	if dispatchingCacheS(cfg) {
+		server.RpcRegisterName(utils.CacheSv1,
+			v1.NewDispatcherCacheSv1(dspS))
+	}
+	server.RpcRegister(v1.NewDispatcherSv1(dspS))
+
+}
 
+// dispatchingCacheS returns true if the CacheSv1 requests are answered by the DispatcherS instead of the local CacheS
+func dispatchingCacheS(cfg *config.CGRConfig) bool {
+	return cfg.DispatcherSCfg().Enabled &&
+		(len(cfg.DispatcherSCfg().CacheSConns) != 0 || len(cfg.DispatcherSCfg().Hosts) != 0)
 }
 
 func startRpc(server *utils.Server, internalRaterChan,
@@ -1085,7 +1278,10 @@ func main() {
 
 	// init cache
 	cacheS := engine.NewCacheS(cfg, dm)
-	server.RpcRegister(v1.NewCacheSv1(cacheS)) // before pre-caching so we can check status via API
+	cacheSv1 := v1.NewCacheSv1(cacheS)
+	if !dispatchingCacheS(cfg) { // DispatcherS registers as CacheSv1 when fronting the CacheS
+		server.RpcRegister(cacheSv1) // before pre-caching so we can check status via API
+	}
 	go func() {
 		if err := cacheS.Precache(); err != nil {
 			errCGR := err.(*utils.CGRError)
@@ -1113,13 +1309,16 @@ func main() {
 	internalSupplierSChan := make(chan rpcclient.RpcClientConnection, 1)
 	filterSChan := make(chan *engine.FilterS, 1)
 	internalDispatcherSChan := make(chan rpcclient.RpcClientConnection, 1)
+	internalCacheSChan := make(chan rpcclient.RpcClientConnection, 1)
+	internalApierV1Chan := make(chan rpcclient.RpcClientConnection, 1)
+	internalCacheSChan <- cacheSv1
 
 	// Start ServiceManager
 	srvManager := servmanager.NewServiceManager(cfg, dm, exitChan, cacheS)
 
 	// Start rater service
 	if cfg.RALsEnabled {
-		go startRater(internalRaterChan, cacheS, internalThresholdSChan,
+		go startRater(internalRaterChan, internalApierV1Chan, cacheS, internalThresholdSChan,
 			internalCdrStatSChan, internalStatSChan,
 			internalPubSubSChan, internalAttributeSChan,
 			internalUserSChan, internalAliaseSChan,
@@ -1150,7 +1349,7 @@ func main() {
 	// Start SM-Generic
 	if cfg.SessionSCfg().Enabled {
 		go startSessionS(internalSMGChan, internalRaterChan, internalRsChan, internalThresholdSChan,
-			internalStatSChan, internalSupplierSChan, internalAttributeSChan, internalCdrSChan, server, exitChan)
+			internalStatSChan, internalSupplierSChan, internalAttributeSChan, internalCdrSChan, dm, server, exitChan)
 	}
 	// Start FreeSWITCHAgent
 	if cfg.FsAgentCfg().Enabled {
@@ -1180,6 +1379,11 @@ func main() {
 		go startHTTPAgent(internalSMGChan, exitChan, server, filterSChan)
 	}
 
+	if cfg.PrometheusAgentCfg().Enabled {
+		go startPrometheusAgent(internalStatSChan, internalRsChan, internalThresholdSChan,
+			internalCacheSChan, internalSMGChan, internalApierV1Chan, server, exitChan)
+	}
+
 	// Start PubSubS service
 	if cfg.PubSubServerEnabled {
 		go startPubSubServer(internalPubSubSChan, dm, server, exitChan)
@@ -1195,7 +1399,7 @@ func main() {
 		go startUsersServer(internalUserSChan, dm, server, exitChan)
 	}
 	// Start FilterS
-	go startFilterService(filterSChan, cacheS, internalStatSChan, cfg, dm, exitChan)
+	go startFilterService(filterSChan, cacheS, internalStatSChan, internalRsChan, cfg, dm, server, exitChan)
 
 	if cfg.AttributeSCfg().Enabled {
 		go startAttributeService(internalAttributeSChan, cacheS,
@@ -1205,7 +1409,7 @@ func main() {
 	// Start RL service
 	if cfg.ResourceSCfg().Enabled {
 		go startResourceService(internalRsChan, cacheS,
-			internalThresholdSChan, cfg, dm, server, exitChan, filterSChan)
+			internalThresholdSChan, internalSMGChan, cfg, dm, server, exitChan, filterSChan)
 	}
 
 	if cfg.StatSCfg().Enabled {
@@ -1226,7 +1430,8 @@ func main() {
 
 	if cfg.DispatcherSCfg().Enabled {
 		go startDispatcherService(internalDispatcherSChan,
-			internalRaterChan, cacheS, dm, server, exitChan)
+			internalRaterChan, internalCdrSChan, internalCacheSChan, internalApierV1Chan,
+			cacheS, dm, server, exitChan, filterSChan)
 	}
 
 	go loaderService(cacheS, cfg, dm, server, exitChan)
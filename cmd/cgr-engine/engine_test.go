@@ -19,7 +19,12 @@ package main
 
 import (
 	"net/rpc"
+	"reflect"
 	"testing"
+
+	"github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func BenchmarkRPCGet(b *testing.B) {
@@ -31,3 +36,42 @@ func BenchmarkRPCGet(b *testing.B) {
 		client.Call("Responder.Get", "test", &reply)
 	}
 }
+
+func TestDispatchingCacheS(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().CacheSConns = []*config.HaPoolConfig{
+		&config.HaPoolConfig{Address: "127.0.0.1:2012"}}
+	if dispatchingCacheS(cfg) {
+		t.Error("CacheS dispatched with DispatcherS disabled")
+	}
+	cfg.DispatcherSCfg().Enabled = true
+	if !dispatchingCacheS(cfg) {
+		t.Error("CacheS not dispatched")
+	}
+	cfg.DispatcherSCfg().CacheSConns = nil
+	if dispatchingCacheS(cfg) {
+		t.Error("CacheS dispatched without connections")
+	}
+}
+
+// TestDispatcherCacheSv1Methods makes sure the dispatcher registered as CacheSv1 answers the methods of the local CacheSv1
+func TestDispatcherCacheSv1Methods(t *testing.T) {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(utils.CacheSv1, v1.NewDispatcherCacheSv1(nil)); err != nil {
+		t.Fatal(err)
+	}
+	cacheSv1 := reflect.TypeOf(new(v1.CacheSv1))
+	dspCacheSv1 := reflect.TypeOf(new(v1.DispatcherCacheSv1))
+	for i := 0; i < cacheSv1.NumMethod(); i++ {
+		mName := cacheSv1.Method(i).Name
+		if mName == "Call" { // internal connection, not exported over RPC
+			continue
+		}
+		if _, has := dspCacheSv1.MethodByName(mName); !has {
+			t.Errorf("method: %s.%s not dispatched", utils.CacheSv1, mName)
+		}
+	}
+	if utils.CacheSv1Ping != utils.CacheSv1+".Ping" {
+		t.Errorf("unexpected method name: %s", utils.CacheSv1Ping)
+	}
+}
@@ -30,7 +30,7 @@ import (
 )
 
 // Starts rater and reports on chan
-func startRater(internalRaterChan chan rpcclient.RpcClientConnection, cacheS *engine.CacheS,
+func startRater(internalRaterChan, internalApierV1Chan chan rpcclient.RpcClientConnection, cacheS *engine.CacheS,
 	internalThdSChan, internalCdrStatSChan, internalStatSChan, internalPubSubSChan,
 	internalAttributeSChan, internalUserSChan, internalAliaseSChan chan rpcclient.RpcClientConnection,
 	serviceManager *servmanager.ServiceManager, server *utils.Server,
@@ -222,4 +222,5 @@ func startRater(internalRaterChan chan rpcclient.RpcClientConnection, cacheS *en
 	utils.RegisterRpcParams("", apierRpcV2)
 	utils.GetRpcParams("")
 	internalRaterChan <- responder // Rater done
+	internalApierV1Chan <- apierRpcV1
 }
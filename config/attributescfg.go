@@ -23,6 +23,8 @@ type AttributeSCfg struct {
 	Enabled             bool
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
+	SuffixIndexedFields *[]string
+	ProcessRuns         int // number of AttributeProfiles applied to one event, in weight order
 }
 
 func (alS *AttributeSCfg) loadFromJsonCfg(jsnCfg *AttributeSJsonCfg) (err error) {
@@ -46,5 +48,15 @@ func (alS *AttributeSCfg) loadFromJsonCfg(jsnCfg *AttributeSJsonCfg) (err error)
 		}
 		alS.PrefixIndexedFields = &pif
 	}
+	if jsnCfg.Suffix_indexed_fields != nil {
+		sfif := make([]string, len(*jsnCfg.Suffix_indexed_fields))
+		for i, fID := range *jsnCfg.Suffix_indexed_fields {
+			sfif[i] = fID
+		}
+		alS.SuffixIndexedFields = &sfif
+	}
+	if jsnCfg.Process_runs != nil {
+		alS.ProcessRuns = *jsnCfg.Process_runs
+	}
 	return
 }
@@ -143,6 +143,7 @@ func NewDefaultCGRConfig() (*CGRConfig, error) {
 	cfg.asteriskAgentCfg = new(AsteriskAgentCfg)
 	cfg.diameterAgentCfg = new(DiameterAgentCfg)
 	cfg.radiusAgentCfg = new(RadiusAgentCfg)
+	cfg.prometheusAgentCfg = new(PrometheusAgentCfg)
 	cfg.filterSCfg = new(FilterSCfg)
 	cfg.dispatcherSCfg = new(DispatcherSCfg)
 	cfg.ConfigReloads = make(map[string]chan struct{})
@@ -347,6 +348,7 @@ type CGRConfig struct {
 	diameterAgentCfg         *DiameterAgentCfg        // DiameterAgent configuration
 	radiusAgentCfg           *RadiusAgentCfg          // RadiusAgent configuration
 	httpAgentCfg             []*HttpAgentCfg          // HttpAgent configuration
+	prometheusAgentCfg       *PrometheusAgentCfg      // PrometheusAgent configuration
 	filterSCfg               *FilterSCfg              // FilterS configuration
 	PubSubServerEnabled      bool                     // Starts PubSub as server: <true|false>.
 	AliasesServerEnabled     bool                     // Starts PubSub as server: <true|false>.
@@ -645,6 +647,34 @@ func (self *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// PrometheusAgent checks
+	if self.prometheusAgentCfg.Enabled {
+		for _, connCfg := range self.prometheusAgentCfg.StatSConns {
+			if connCfg.Address == utils.MetaInternal && !self.statsCfg.Enabled {
+				return errors.New("StatS not enabled but requested by PrometheusAgent component.")
+			}
+		}
+		for _, connCfg := range self.prometheusAgentCfg.ResourceSConns {
+			if connCfg.Address == utils.MetaInternal && !self.resourceSCfg.Enabled {
+				return errors.New("ResourceS not enabled but requested by PrometheusAgent component.")
+			}
+		}
+		for _, connCfg := range self.prometheusAgentCfg.ThresholdSConns {
+			if connCfg.Address == utils.MetaInternal && !self.thresholdSCfg.Enabled {
+				return errors.New("ThresholdS not enabled but requested by PrometheusAgent component.")
+			}
+		}
+		for _, connCfg := range self.prometheusAgentCfg.SessionSConns {
+			if connCfg.Address == utils.MetaInternal && !self.sessionSCfg.Enabled {
+				return errors.New("SessionS not enabled but requested by PrometheusAgent component.")
+			}
+		}
+		for _, connCfg := range self.prometheusAgentCfg.SchedulerConns {
+			if connCfg.Address == utils.MetaInternal && !self.RALsEnabled {
+				return errors.New("RALs not enabled but requested by PrometheusAgent component.")
+			}
+		}
+	}
 	// ResourceLimiter checks
 	if self.resourceSCfg != nil && self.resourceSCfg.Enabled {
 		for _, connCfg := range self.resourceSCfg.ThresholdSConns {
@@ -652,6 +682,11 @@ func (self *CGRConfig) checkConfigSanity() error {
 				return errors.New("ThresholdS not enabled but requested by ResourceS component.")
 			}
 		}
+		for _, connCfg := range self.resourceSCfg.SessionSConns {
+			if connCfg.Address == utils.MetaInternal && !self.sessionSCfg.Enabled {
+				return errors.New("SessionS not enabled but requested by ResourceS component.")
+			}
+		}
 	}
 	// StatS checks
 	if self.statsCfg != nil && self.statsCfg.Enabled {
@@ -661,6 +696,11 @@ func (self *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// AttributeS checks
+	if self.attributeSCfg != nil && self.attributeSCfg.Enabled &&
+		self.attributeSCfg.ProcessRuns < 1 {
+		return fmt.Errorf("<%s> process_runs should be at least 1", utils.AttributeS)
+	}
 	// SupplierS checks
 	if self.supplierSCfg != nil && self.supplierSCfg.Enabled {
 		for _, connCfg := range self.supplierSCfg.RALsConns {
@@ -689,6 +729,25 @@ func (self *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> unsupported dispatching strategy %s",
 				utils.DispatcherS, self.dispatcherSCfg.DispatchingStrategy)
 		}
+		for hostID, connCfgs := range self.dispatcherSCfg.Hosts {
+			for _, connCfg := range connCfgs {
+				if connCfg.Address == utils.MetaInternal {
+					return fmt.Errorf("<%s> %s connection not supported for host %s",
+						utils.DispatcherS, utils.MetaInternal, hostID)
+				}
+			}
+		}
+		if self.dispatcherSCfg.HealthCheckInterval > 0 {
+			if len(self.dispatcherSCfg.HealthCheckMethods) == 0 {
+				return fmt.Errorf("<%s> health_check_methods needed when health_check_interval is set",
+					utils.DispatcherS)
+			}
+			if self.dispatcherSCfg.FailureThreshold < 1 ||
+				self.dispatcherSCfg.RecoveryThreshold < 1 {
+				return fmt.Errorf("<%s> failure_threshold and recovery_threshold should be at least 1",
+					utils.DispatcherS)
+			}
+		}
 	}
 	return nil
 }
@@ -797,6 +856,11 @@ func (self *CGRConfig) loadFromJsonCfg(jsnCfg *CgrJsonCfg) (err error) {
 		return err
 	}
 
+	jsnPrometheusAgntCfg, err := jsnCfg.PrometheusAgentJsonCfg()
+	if err != nil {
+		return err
+	}
+
 	jsnPubSubServCfg, err := jsnCfg.PubSubServJsonCfg()
 	if err != nil {
 		return err
@@ -1354,6 +1418,12 @@ func (self *CGRConfig) loadFromJsonCfg(jsnCfg *CgrJsonCfg) (err error) {
 		}
 	}
 
+	if jsnPrometheusAgntCfg != nil {
+		if err := self.prometheusAgentCfg.loadFromJsonCfg(jsnPrometheusAgntCfg); err != nil {
+			return err
+		}
+	}
+
 	if jsnPubSubServCfg != nil {
 		if jsnPubSubServCfg.Enabled != nil {
 			self.PubSubServerEnabled = *jsnPubSubServCfg.Enabled
@@ -1534,6 +1604,10 @@ func (self *CGRConfig) HttpAgentCfg() []*HttpAgentCfg {
 	return self.httpAgentCfg
 }
 
+func (self *CGRConfig) PrometheusAgentCfg() *PrometheusAgentCfg {
+	return self.prometheusAgentCfg
+}
+
 func (cfg *CGRConfig) FilterSCfg() *FilterSCfg {
 	return cfg.filterSCfg
 }
@@ -133,6 +133,7 @@ const CGRATES_CFG_JSON = `
 	"filters": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},				// control filters caching
 	"supplier_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},		// control supplier profile caching
 	"attribute_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},		// control attribute profile caching
+	"dispatcher_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},	// control dispatcher profile caching
 	"resource_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 					// control resource filter indexes caching
 	"resource_filter_revindexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 				// control resource filter reverse indexes caching
 	"stat_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 						// control stat filter indexes caching
@@ -143,11 +144,15 @@ const CGRATES_CFG_JSON = `
 	"supplier_filter_revindexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 				// control supplier filter reverse indexes caching
 	"attribute_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 				// control attribute filter indexes caching
 	"attribute_filter_revindexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 				// control attribute filter reverse indexes caching
+	"dispatcher_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 				// control dispatcher filter indexes caching
+	"dispatcher_filter_revindexes" : {"limit": -1, "ttl": "", "static_ttl": false}, 			// control dispatcher filter reverse indexes caching
 },
 
 
 "filters": {								// Filters configuration (*new)
 	"stats_conns": [],						// address where to reach the stat service, empty to disable stats functionality: <""|*internal|x.y.z.y:1234>
+	"resources_conns": [],					// address where to reach the resource service, empty to disable resources functionality: <""|*internal|x.y.z.y:1234>
+	"apis_enabled": false,					// register the FilterSv1 APIs (ie: ExplainFilters): <true|false>
 },
 
 
@@ -312,6 +317,8 @@ const CGRATES_CFG_JSON = `
 	//"session_ttl_usage": "",				// tweak Usage for sessions timing-out, not defined by default
 	"session_indexes": [],					// index sessions based on these fields for GetActiveSessions API
 	"client_protocol": 1.0,					// version of protocol to use when acting as JSON-PRC client <"0","1.0">
+	"store_interval": "",					// snapshot active sessions into dataDB so they survive a restart, empty to disable: <""|$dur>
+	"channel_sync_interval": "0s",			// terminate regularly the sessions unknown to the agents connected over BiRPC, 0 to disable
 },
 
 
@@ -405,6 +412,19 @@ const CGRATES_CFG_JSON = `
 ],
 
 
+"prometheus_agent": {
+	"enabled": false,				// exports internal metrics in Prometheus text format: <true|false>
+	"url": "/metrics",				// HTTP path the metrics are served on
+	"tenants": [],					// tenants to export metrics for, empty for default_tenant
+	"stats_conns": [],				// export StatQueue metrics out of StatS <""|*internal|127.0.0.1:2013>
+	"resources_conns": [],			// export resource usage out of ResourceS <""|*internal|127.0.0.1:2013>
+	"thresholds_conns": [],			// export threshold hits out of ThresholdS <""|*internal|127.0.0.1:2013>
+	"caches_conns": [],				// export cache statistics out of CacheS <""|*internal|127.0.0.1:2012>
+	"sessions_conns": [],			// export active sessions out of SessionS <""|*internal|127.0.0.1:2012>
+	"scheduler_conns": [],			// export scheduler queue length out of RALs <""|*internal|127.0.0.1:2012>
+},
+
+
 "pubsubs": {
 	"enabled": false,				// starts PubSub service: <true|false>.
 },
@@ -425,6 +445,8 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,						// starts attribute service: <true|false>.
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	//"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	"process_runs": 1,						// number of matching AttributeProfiles applied on one event, in weight order
 },
 
 
@@ -434,6 +456,9 @@ const CGRATES_CFG_JSON = `
 	"thresholds_conns": [],					// address where to reach the thresholds service, empty to disable thresholds functionality: <""|*internal|x.y.z.y:1234>
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	//"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	"sessions_conns": [],					// address where to reach SessionS for reconciling the usages: <""|*internal|x.y.z.y:1234>
+	"reconcile_interval": "",				// release regularly the usages without active session in SessionS, empty to disable: <""|$dur>
 },
 
 
@@ -443,6 +468,7 @@ const CGRATES_CFG_JSON = `
 	"thresholds_conns": [],					// address where to reach the thresholds service, empty to disable thresholds functionality: <""|*internal|x.y.z.y:1234>
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	//"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 },
 
 
@@ -451,6 +477,7 @@ const CGRATES_CFG_JSON = `
 	"store_interval": "",					// dump cache regularly to dataDB, 0 - dump at start/shutdown: <""|$dur>
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	//"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 },
 
 
@@ -458,6 +485,7 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,						// starts SupplierS service: <true|false>.
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	//"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"rals_conns": [
 		{"address": "*internal"},			// address where to reach the RALs for cost/accounting  <*internal>
 	],
@@ -494,6 +522,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Substitute", "field_id": "Substitute", "type": "*composed", "value": "7"},
 					{"tag": "Append", "field_id": "Append", "type": "*composed", "value": "8"},
 					{"tag": "Weight", "field_id": "Weight", "type": "*composed", "value": "9"},
+					{"tag": "Type", "field_id": "Type", "type": "*composed", "value": "10"},
 				],
 			},
 			{
@@ -523,6 +552,11 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "field_id": "Stored", "type": "*composed", "value": "8"},
 					{"tag": "Weight", "field_id": "Weight", "type": "*composed", "value": "9"},
 					{"tag": "ThresholdIDs", "field_id": "ThresholdIDs", "type": "*composed", "value": "10"},
+					{"tag": "AllocationStrategy", "field_id": "AllocationStrategy", "type": "*composed", "value": "11"},
+					{"tag": "MaxUsageUnits", "field_id": "MaxUsageUnits", "type": "*composed", "value": "12"},
+					{"tag": "QueueTimeout", "field_id": "QueueTimeout", "type": "*composed", "value": "13"},
+					{"tag": "QueueLength", "field_id": "QueueLength", "type": "*composed", "value": "14"},
+					{"tag": "RateInterval", "field_id": "RateInterval", "type": "*composed", "value": "15"},
 				],
 			},
 			{
@@ -542,6 +576,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "field_id": "Weight", "type": "*composed", "value": "10"},
 					{"tag": "MinItems", "field_id": "MinItems", "type": "*composed", "value": "11"},
 					{"tag": "ThresholdIDs", "field_id": "ThresholdIDs", "type": "*composed", "value": "12"},
+					{"tag": "BucketInterval", "field_id": "BucketInterval", "type": "*composed", "value": "13"},
 				],
 			},
 			{
@@ -559,6 +594,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "field_id": "Weight", "type": "*composed", "value": "8"},
 					{"tag": "ActionIDs", "field_id": "ActionIDs", "type": "*composed", "value": "9"},
 					{"tag": "Async", "field_id": "Async", "type": "*composed", "value": "10"},
+					{"tag": "ResetInterval", "field_id": "ResetInterval", "type": "*composed", "value": "11"},
+					{"tag": "RecoveryFilterIDs", "field_id": "RecoveryFilterIDs", "type": "*composed", "value": "12"},
 				],
 			},
 			{
@@ -665,7 +702,15 @@ const CGRATES_CFG_JSON = `
 	"suppliers_conns": [],					// address where to reach the SupplierS <""|*internal|127.0.0.1:2013>
 	"attributes_conns": [],					// address where to reach the AttributeS <""|*internal|127.0.0.1:2013>
 	"sessions_conns": [],					// connection towards SessionService
+	"cdrs_conns": [],						// address where to reach the CDRs <""|*internal|127.0.0.1:2012>
+	"caches_conns": [],						// address where to reach the CacheS <""|*internal|127.0.0.1:2012>
+	"apier_conns": [],						// address where to reach the ApierV1 for account queries <""|*internal|127.0.0.1:2012>
 	"dispatching_strategy":"*first",		// strategy for dispatching <*first|*random|*next|*broadcast>
+	"hosts": {},							// hosts referenced by DispatcherProfiles, ie: {"HOST1": [{"address": "127.0.0.1:2012"}]}
+	"health_check_interval": "0s",			// interval between health checks of the hosts, 0 to disable
+	"health_check_methods": ["AttributeSv1.Ping"],	// *ping methods called on each host, the host is healthy if one of them replies
+	"failure_threshold": 3,					// consecutive failed checks after which a host is ejected from dispatching
+	"recovery_threshold": 2,				// consecutive successful checks after which an ejected host is dispatched to again
 },
 
 
@@ -27,46 +27,47 @@ import (
 )
 
 const (
-	GENERAL_JSN        = "general"
-	CACHE_JSN          = "cache"
-	LISTEN_JSN         = "listen"
-	HTTP_JSN           = "http"
-	DATADB_JSN         = "data_db"
-	STORDB_JSN         = "stor_db"
-	FilterSjsn         = "filters"
-	RALS_JSN           = "rals"
-	SCHEDULER_JSN      = "scheduler"
-	CDRS_JSN           = "cdrs"
-	MEDIATOR_JSN       = "mediator"
-	CDRSTATS_JSN       = "cdrstats"
-	CDRE_JSN           = "cdre"
-	CDRC_JSN           = "cdrc"
-	SessionSJson       = "sessions"
-	FreeSWITCHAgentJSN = "freeswitch_agent"
-	KamailioAgentJSN   = "kamailio_agent"
-	AsteriskAgentJSN   = "asterisk_agent"
-	SM_JSN             = "session_manager"
-	FS_JSN             = "freeswitch"
-	OSIPS_JSN          = "opensips"
-	DA_JSN             = "diameter_agent"
-	RA_JSN             = "radius_agent"
-	HttpAgentJson      = "http_agent"
-	HISTSERV_JSN       = "historys"
-	PUBSUBSERV_JSN     = "pubsubs"
-	ALIASESSERV_JSN    = "aliases"
-	USERSERV_JSN       = "users"
-	ATTRIBUTE_JSN      = "attributes"
-	RESOURCES_JSON     = "resources"
-	STATS_JSON         = "stats"
-	THRESHOLDS_JSON    = "thresholds"
-	SupplierSJson      = "suppliers"
-	FILTERS_JSON       = "filters"
-	LoaderJson         = "loaders"
-	MAILER_JSN         = "mailer"
-	SURETAX_JSON       = "suretax"
-	DispatcherSJson    = "dispatcher"
-	CgrLoaderCfgJson   = "loader"
-	CgrMigratorCfgJson = "migrator"
+	GENERAL_JSN         = "general"
+	CACHE_JSN           = "cache"
+	LISTEN_JSN          = "listen"
+	HTTP_JSN            = "http"
+	DATADB_JSN          = "data_db"
+	STORDB_JSN          = "stor_db"
+	FilterSjsn          = "filters"
+	RALS_JSN            = "rals"
+	SCHEDULER_JSN       = "scheduler"
+	CDRS_JSN            = "cdrs"
+	MEDIATOR_JSN        = "mediator"
+	CDRSTATS_JSN        = "cdrstats"
+	CDRE_JSN            = "cdre"
+	CDRC_JSN            = "cdrc"
+	SessionSJson        = "sessions"
+	FreeSWITCHAgentJSN  = "freeswitch_agent"
+	KamailioAgentJSN    = "kamailio_agent"
+	AsteriskAgentJSN    = "asterisk_agent"
+	SM_JSN              = "session_manager"
+	FS_JSN              = "freeswitch"
+	OSIPS_JSN           = "opensips"
+	DA_JSN              = "diameter_agent"
+	RA_JSN              = "radius_agent"
+	HttpAgentJson       = "http_agent"
+	PrometheusAgentJson = "prometheus_agent"
+	HISTSERV_JSN        = "historys"
+	PUBSUBSERV_JSN      = "pubsubs"
+	ALIASESSERV_JSN     = "aliases"
+	USERSERV_JSN        = "users"
+	ATTRIBUTE_JSN       = "attributes"
+	RESOURCES_JSON      = "resources"
+	STATS_JSON          = "stats"
+	THRESHOLDS_JSON     = "thresholds"
+	SupplierSJson       = "suppliers"
+	FILTERS_JSON        = "filters"
+	LoaderJson          = "loaders"
+	MAILER_JSN          = "mailer"
+	SURETAX_JSON        = "suretax"
+	DispatcherSJson     = "dispatcher"
+	CgrLoaderCfgJson    = "loader"
+	CgrMigratorCfgJson  = "migrator"
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -320,6 +321,18 @@ func (self CgrJsonCfg) HttpAgentJsonCfg() (*[]*HttpAgentJsonCfg, error) {
 	return &httpAgnt, nil
 }
 
+func (self CgrJsonCfg) PrometheusAgentJsonCfg() (*PrometheusAgentJsonCfg, error) {
+	rawCfg, hasKey := self[PrometheusAgentJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(PrometheusAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (self CgrJsonCfg) PubSubServJsonCfg() (*PubSubServJsonCfg, error) {
 	rawCfg, hasKey := self[PUBSUBSERV_JSN]
 	if !hasKey {
@@ -144,6 +144,9 @@ func TestCacheJsonCfg(t *testing.T) {
 		utils.CacheAttributeProfiles: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 			Precache: utils.BoolPointer(false)},
+		utils.CacheDispatcherProfiles: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
+			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+			Precache: utils.BoolPointer(false)},
 		utils.CacheResourceFilterIndexes: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false)},
 		utils.CacheResourceFilterRevIndexes: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
@@ -164,6 +167,10 @@ func TestCacheJsonCfg(t *testing.T) {
 			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false)},
 		utils.CacheAttributeFilterRevIndexes: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
 			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false)},
+		utils.CacheDispatcherFilterIndexes: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
+			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false)},
+		utils.CacheDispatcherFilterRevIndexes: &CacheParamJsonCfg{Limit: utils.IntPointer(-1),
+			Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false)},
 	}
 
 	if gCfg, err := dfCgrJsonCfg.CacheJsonCfg(); err != nil {
@@ -507,6 +514,8 @@ func TestSmgJsonCfg(t *testing.T) {
 		Session_ttl:               utils.StringPointer("0s"),
 		Session_indexes:           &[]string{},
 		Client_protocol:           utils.Float64Pointer(1.0),
+		Store_interval:            utils.StringPointer(""),
+		Channel_sync_interval:     utils.StringPointer("0s"),
 	}
 	if cfg, err := dfCgrJsonCfg.SessionSJsonCfg(); err != nil {
 		t.Error(err)
@@ -696,6 +705,8 @@ func TestDfAttributeServJsonCfg(t *testing.T) {
 		Enabled:               utils.BoolPointer(false),
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: nil,
+		Process_runs:          utils.IntPointer(1),
 	}
 	if cfg, err := dfCgrJsonCfg.AttributeServJsonCfg(); err != nil {
 		t.Error(err)
@@ -706,7 +717,9 @@ func TestDfAttributeServJsonCfg(t *testing.T) {
 
 func TestDfFilterSJsonCfg(t *testing.T) {
 	eCfg := &FilterSJsonCfg{
-		Stats_conns: &[]*HaPoolJsonCfg{},
+		Stats_conns:     &[]*HaPoolJsonCfg{},
+		Resources_conns: &[]*HaPoolJsonCfg{},
+		Apis_enabled:    utils.BoolPointer(false),
 	}
 	if cfg, err := dfCgrJsonCfg.FilterSJsonCfg(); err != nil {
 		t.Error(err)
@@ -722,6 +735,9 @@ func TestDfResourceLimiterSJsonCfg(t *testing.T) {
 		Store_interval:        utils.StringPointer(""),
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: nil,
+		Sessions_conns:        &[]*HaPoolJsonCfg{},
+		Reconcile_interval:    utils.StringPointer(""),
 	}
 	if cfg, err := dfCgrJsonCfg.ResourceSJsonCfg(); err != nil {
 		t.Error(err)
@@ -737,6 +753,7 @@ func TestDfStatServiceJsonCfg(t *testing.T) {
 		Thresholds_conns:      &[]*HaPoolJsonCfg{},
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: nil,
 	}
 	if cfg, err := dfCgrJsonCfg.StatSJsonCfg(); err != nil {
 		t.Error(err)
@@ -751,6 +768,7 @@ func TestDfThresholdSJsonCfg(t *testing.T) {
 		Store_interval:        utils.StringPointer(""),
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: nil,
 	}
 	if cfg, err := dfCgrJsonCfg.ThresholdSJsonCfg(); err != nil {
 		t.Error(err)
@@ -764,6 +782,7 @@ func TestDfSupplierSJsonCfg(t *testing.T) {
 		Enabled:               utils.BoolPointer(false),
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: nil,
 		Rals_conns: &[]*HaPoolJsonCfg{
 			&HaPoolJsonCfg{
 				Address: utils.StringPointer("*internal"),
@@ -840,6 +859,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Field_id: utils.StringPointer(utils.Weight),
 							Type:     utils.StringPointer(utils.META_COMPOSED),
 							Value:    utils.StringPointer("9")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("Type"),
+							Field_id: utils.StringPointer(utils.Type),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("10")},
 					},
 				},
 				&LoaderJsonDataType{
@@ -924,6 +947,26 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Field_id: utils.StringPointer("ThresholdIDs"),
 							Type:     utils.StringPointer(utils.META_COMPOSED),
 							Value:    utils.StringPointer("10")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("AllocationStrategy"),
+							Field_id: utils.StringPointer("AllocationStrategy"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("11")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("MaxUsageUnits"),
+							Field_id: utils.StringPointer("MaxUsageUnits"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("12")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("QueueTimeout"),
+							Field_id: utils.StringPointer("QueueTimeout"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("13")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("QueueLength"),
+							Field_id: utils.StringPointer("QueueLength"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("14")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("RateInterval"),
+							Field_id: utils.StringPointer("RateInterval"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("15")},
 					},
 				},
 				&LoaderJsonDataType{
@@ -984,6 +1027,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Field_id: utils.StringPointer("ThresholdIDs"),
 							Type:     utils.StringPointer(utils.META_COMPOSED),
 							Value:    utils.StringPointer("12")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("BucketInterval"),
+							Field_id: utils.StringPointer("BucketInterval"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("13")},
 					},
 				},
 				&LoaderJsonDataType{
@@ -1036,6 +1083,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Field_id: utils.StringPointer("Async"),
 							Type:     utils.StringPointer(utils.META_COMPOSED),
 							Value:    utils.StringPointer("10")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("ResetInterval"),
+							Field_id: utils.StringPointer("ResetInterval"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("11")},
+						&CdrFieldJsonCfg{Tag: utils.StringPointer("RecoveryFilterIDs"),
+							Field_id: utils.StringPointer("RecoveryFilterIDs"),
+							Type:     utils.StringPointer(utils.META_COMPOSED),
+							Value:    utils.StringPointer("12")},
 					},
 				},
 				&LoaderJsonDataType{
@@ -1249,17 +1304,44 @@ func TestDfHttpJsonCfg(t *testing.T) {
 	}
 }
 
+func TestDfPrometheusAgentJsonCfg(t *testing.T) {
+	eCfg := &PrometheusAgentJsonCfg{
+		Enabled:          utils.BoolPointer(false),
+		Url:              utils.StringPointer("/metrics"),
+		Tenants:          &[]string{},
+		Stats_conns:      &[]*HaPoolJsonCfg{},
+		Resources_conns:  &[]*HaPoolJsonCfg{},
+		Thresholds_conns: &[]*HaPoolJsonCfg{},
+		Caches_conns:     &[]*HaPoolJsonCfg{},
+		Sessions_conns:   &[]*HaPoolJsonCfg{},
+		Scheduler_conns:  &[]*HaPoolJsonCfg{},
+	}
+	if cfg, err := dfCgrJsonCfg.PrometheusAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
 func TestDfDispatcherSJsonCfg(t *testing.T) {
 	eCfg := &DispatcherSJsonCfg{
-		Enabled:              utils.BoolPointer(false),
-		Rals_conns:           &[]*HaPoolJsonCfg{},
-		Resources_conns:      &[]*HaPoolJsonCfg{},
-		Thresholds_conns:     &[]*HaPoolJsonCfg{},
-		Stats_conns:          &[]*HaPoolJsonCfg{},
-		Suppliers_conns:      &[]*HaPoolJsonCfg{},
-		Attributes_conns:     &[]*HaPoolJsonCfg{},
-		Sessions_conns:       &[]*HaPoolJsonCfg{},
-		Dispatching_strategy: utils.StringPointer(utils.MetaFirst),
+		Enabled:               utils.BoolPointer(false),
+		Rals_conns:            &[]*HaPoolJsonCfg{},
+		Resources_conns:       &[]*HaPoolJsonCfg{},
+		Thresholds_conns:      &[]*HaPoolJsonCfg{},
+		Stats_conns:           &[]*HaPoolJsonCfg{},
+		Suppliers_conns:       &[]*HaPoolJsonCfg{},
+		Attributes_conns:      &[]*HaPoolJsonCfg{},
+		Sessions_conns:        &[]*HaPoolJsonCfg{},
+		Cdrs_conns:            &[]*HaPoolJsonCfg{},
+		Caches_conns:          &[]*HaPoolJsonCfg{},
+		Apier_conns:           &[]*HaPoolJsonCfg{},
+		Dispatching_strategy:  utils.StringPointer(utils.MetaFirst),
+		Hosts:                 &map[string][]*HaPoolJsonCfg{},
+		Health_check_interval: utils.StringPointer("0s"),
+		Health_check_methods:  &[]string{utils.AttributeSv1Ping},
+		Failure_threshold:     utils.IntPointer(3),
+		Recovery_threshold:    utils.IntPointer(2),
 	}
 	if cfg, err := dfCgrJsonCfg.DispatcherSJsonCfg(); err != nil {
 		t.Error(err)
@@ -669,6 +669,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 			TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		utils.CacheAttributeProfiles: &CacheParamConfig{Limit: -1,
 			TTL: time.Duration(0), StaticTTL: false, Precache: false},
+		utils.CacheDispatcherProfiles: &CacheParamConfig{Limit: -1,
+			TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		utils.CacheResourceFilterIndexes: &CacheParamConfig{Limit: -1,
 			TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		utils.CacheResourceFilterRevIndexes: &CacheParamConfig{Limit: -1,
@@ -689,6 +691,10 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 			TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		utils.CacheAttributeFilterRevIndexes: &CacheParamConfig{Limit: -1,
 			TTL: time.Duration(0), StaticTTL: false, Precache: false},
+		utils.CacheDispatcherFilterIndexes: &CacheParamConfig{Limit: -1,
+			TTL: time.Duration(0), StaticTTL: false, Precache: false},
+		utils.CacheDispatcherFilterRevIndexes: &CacheParamConfig{Limit: -1,
+			TTL: time.Duration(0), StaticTTL: false, Precache: false},
 	}
 
 	if !reflect.DeepEqual(eCacheCfg, cgrCfg.CacheCfg()) {
@@ -777,7 +783,9 @@ func TestCgrCfgJSONDefaultsUserS(t *testing.T) {
 
 func TestCgrCfgJSONDefaultFiltersCfg(t *testing.T) {
 	eFiltersCfg := &FilterSCfg{
-		StatSConns: []*HaPoolConfig{},
+		StatSConns:     []*HaPoolConfig{},
+		ResourceSConns: []*HaPoolConfig{},
+		APIsEnabled:    false,
 	}
 	if !reflect.DeepEqual(cgrCfg.filterSCfg, eFiltersCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.filterSCfg, eFiltersCfg)
@@ -789,6 +797,8 @@ func TestCgrCfgJSONDefaultSAttributeSCfg(t *testing.T) {
 		Enabled:             false,
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		SuffixIndexedFields: nil,
+		ProcessRuns:         1,
 	}
 	if !reflect.DeepEqual(eAliasSCfg, cgrCfg.attributeSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", eAliasSCfg, cgrCfg.attributeSCfg)
@@ -802,6 +812,8 @@ func TestCgrCfgJSONDefaultsResLimCfg(t *testing.T) {
 		StoreInterval:       0,
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		SuffixIndexedFields: nil,
+		SessionSConns:       []*HaPoolConfig{},
 	}
 	if !reflect.DeepEqual(cgrCfg.resourceSCfg, eResLiCfg) {
 		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eResLiCfg), utils.ToJSON(cgrCfg.resourceSCfg))
@@ -816,6 +828,7 @@ func TestCgrCfgJSONDefaultStatsCfg(t *testing.T) {
 		ThresholdSConns:     []*HaPoolConfig{},
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		SuffixIndexedFields: nil,
 	}
 	if !reflect.DeepEqual(cgrCfg.statsCfg, eStatsCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.statsCfg, eStatsCfg)
@@ -828,6 +841,7 @@ func TestCgrCfgJSONDefaultThresholdSCfg(t *testing.T) {
 		StoreInterval:       0,
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		SuffixIndexedFields: nil,
 	}
 	if !reflect.DeepEqual(eThresholdSCfg, cgrCfg.thresholdSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", eThresholdSCfg, cgrCfg.thresholdSCfg)
@@ -839,6 +853,7 @@ func TestCgrCfgJSONDefaultSupplierSCfg(t *testing.T) {
 		Enabled:             false,
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		SuffixIndexedFields: nil,
 
 		RALsConns: []*HaPoolConfig{
 			&HaPoolConfig{Address: "*internal"},
@@ -1124,6 +1139,10 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							FieldId: "Weight",
 							Type:    utils.META_COMPOSED,
 							Value:   utils.ParseRSRFieldsMustCompile("9", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "Type",
+							FieldId: "Type",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("10", utils.INFIELD_SEP)},
 					},
 				},
 				&LoaderDataType{
@@ -1208,6 +1227,26 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							FieldId: "ThresholdIDs",
 							Type:    utils.META_COMPOSED,
 							Value:   utils.ParseRSRFieldsMustCompile("10", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "AllocationStrategy",
+							FieldId: "AllocationStrategy",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("11", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "MaxUsageUnits",
+							FieldId: "MaxUsageUnits",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("12", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "QueueTimeout",
+							FieldId: "QueueTimeout",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("13", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "QueueLength",
+							FieldId: "QueueLength",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("14", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "RateInterval",
+							FieldId: "RateInterval",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("15", utils.INFIELD_SEP)},
 					},
 				},
 				&LoaderDataType{
@@ -1268,6 +1307,10 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							FieldId: "ThresholdIDs",
 							Type:    utils.META_COMPOSED,
 							Value:   utils.ParseRSRFieldsMustCompile("12", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "BucketInterval",
+							FieldId: "BucketInterval",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("13", utils.INFIELD_SEP)},
 					},
 				},
 				&LoaderDataType{
@@ -1320,6 +1363,14 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							FieldId: "Async",
 							Type:    utils.META_COMPOSED,
 							Value:   utils.ParseRSRFieldsMustCompile("10", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "ResetInterval",
+							FieldId: "ResetInterval",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("11", utils.INFIELD_SEP)},
+						&CfgCdrField{Tag: "RecoveryFilterIDs",
+							FieldId: "RecoveryFilterIDs",
+							Type:    utils.META_COMPOSED,
+							Value:   utils.ParseRSRFieldsMustCompile("12", utils.INFIELD_SEP)},
 					},
 				},
 				&LoaderDataType{
@@ -1403,6 +1454,23 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 	}
 }
 
+func TestCgrCfgJSONDefaultPrometheusAgentCfg(t *testing.T) {
+	ePaCfg := &PrometheusAgentCfg{
+		Enabled:         false,
+		Url:             "/metrics",
+		Tenants:         []string{},
+		StatSConns:      []*HaPoolConfig{},
+		ResourceSConns:  []*HaPoolConfig{},
+		ThresholdSConns: []*HaPoolConfig{},
+		CacheSConns:     []*HaPoolConfig{},
+		SessionSConns:   []*HaPoolConfig{},
+		SchedulerConns:  []*HaPoolConfig{},
+	}
+	if !reflect.DeepEqual(cgrCfg.PrometheusAgentCfg(), ePaCfg) {
+		t.Errorf("received: %+v, expecting: %+v", cgrCfg.PrometheusAgentCfg(), ePaCfg)
+	}
+}
+
 func TestCgrCfgJSONDefaultDispatcherSCfg(t *testing.T) {
 	eDspSCfg := &DispatcherSCfg{
 		Enabled:             false,
@@ -1413,7 +1481,15 @@ func TestCgrCfgJSONDefaultDispatcherSCfg(t *testing.T) {
 		SupplSConns:         []*HaPoolConfig{},
 		AttrSConns:          []*HaPoolConfig{},
 		SessionSConns:       []*HaPoolConfig{},
+		CDRsConns:           []*HaPoolConfig{},
+		CacheSConns:         []*HaPoolConfig{},
+		ApierConns:          []*HaPoolConfig{},
 		DispatchingStrategy: utils.MetaFirst,
+		Hosts:               map[string][]*HaPoolConfig{},
+		HealthCheckInterval: 0,
+		HealthCheckMethods:  []string{utils.AttributeSv1Ping},
+		FailureThreshold:    3,
+		RecoveryThreshold:   2,
 	}
 	if !reflect.DeepEqual(cgrCfg.dispatcherSCfg, eDspSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.dispatcherSCfg, eDspSCfg)
@@ -18,6 +18,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
 // DispatcherSCfg is the configuration of dispatcher service
 type DispatcherSCfg struct {
 	Enabled             bool
@@ -28,7 +34,15 @@ type DispatcherSCfg struct {
 	SupplSConns         []*HaPoolConfig
 	AttrSConns          []*HaPoolConfig
 	SessionSConns       []*HaPoolConfig
+	CDRsConns           []*HaPoolConfig
+	CacheSConns         []*HaPoolConfig
+	ApierConns          []*HaPoolConfig
 	DispatchingStrategy string
+	Hosts               map[string][]*HaPoolConfig // connections towards hosts used in DispatcherProfiles
+	HealthCheckInterval time.Duration              // 0 disables health checks
+	HealthCheckMethods  []string                   // the host is healthy if one of these replies
+	FailureThreshold    int                        // consecutive failed checks before ejecting a host
+	RecoveryThreshold   int                        // consecutive successful checks before using an ejected host again
 }
 
 func (dps *DispatcherSCfg) loadFromJsonCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -87,8 +101,56 @@ func (dps *DispatcherSCfg) loadFromJsonCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 			dps.SessionSConns[idx].loadFromJsonCfg(jsnHaCfg)
 		}
 	}
+	if jsnCfg.Cdrs_conns != nil {
+		dps.CDRsConns = make([]*HaPoolConfig, len(*jsnCfg.Cdrs_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Cdrs_conns {
+			dps.CDRsConns[idx] = NewDfltHaPoolConfig()
+			dps.CDRsConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Caches_conns != nil {
+		dps.CacheSConns = make([]*HaPoolConfig, len(*jsnCfg.Caches_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Caches_conns {
+			dps.CacheSConns[idx] = NewDfltHaPoolConfig()
+			dps.CacheSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Apier_conns != nil {
+		dps.ApierConns = make([]*HaPoolConfig, len(*jsnCfg.Apier_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Apier_conns {
+			dps.ApierConns[idx] = NewDfltHaPoolConfig()
+			dps.ApierConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
 	if jsnCfg.Dispatching_strategy != nil {
 		dps.DispatchingStrategy = *jsnCfg.Dispatching_strategy
 	}
+	if jsnCfg.Hosts != nil {
+		dps.Hosts = make(map[string][]*HaPoolConfig, len(*jsnCfg.Hosts))
+		for hostID, jsnHaCfgs := range *jsnCfg.Hosts {
+			dps.Hosts[hostID] = make([]*HaPoolConfig, len(jsnHaCfgs))
+			for idx, jsnHaCfg := range jsnHaCfgs {
+				dps.Hosts[hostID][idx] = NewDfltHaPoolConfig()
+				dps.Hosts[hostID][idx].loadFromJsonCfg(jsnHaCfg)
+			}
+		}
+	}
+	if jsnCfg.Health_check_interval != nil {
+		if dps.HealthCheckInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Health_check_interval); err != nil {
+			return err
+		}
+	}
+	if jsnCfg.Health_check_methods != nil {
+		dps.HealthCheckMethods = make([]string, len(*jsnCfg.Health_check_methods))
+		for i, method := range *jsnCfg.Health_check_methods {
+			dps.HealthCheckMethods[i] = method
+		}
+	}
+	if jsnCfg.Failure_threshold != nil {
+		dps.FailureThreshold = *jsnCfg.Failure_threshold
+	}
+	if jsnCfg.Recovery_threshold != nil {
+		dps.RecoveryThreshold = *jsnCfg.Recovery_threshold
+	}
 	return nil
 }
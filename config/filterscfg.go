@@ -19,7 +19,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 type FilterSCfg struct {
-	StatSConns []*HaPoolConfig
+	StatSConns     []*HaPoolConfig
+	ResourceSConns []*HaPoolConfig
+	APIsEnabled    bool // register the FilterSv1 APIs
 }
 
 func (fSCfg *FilterSCfg) loadFromJsonCfg(jsnCfg *FilterSJsonCfg) (err error) {
@@ -33,5 +35,15 @@ func (fSCfg *FilterSCfg) loadFromJsonCfg(jsnCfg *FilterSJsonCfg) (err error) {
 			fSCfg.StatSConns[idx].loadFromJsonCfg(jsnHaCfg)
 		}
 	}
+	if jsnCfg.Resources_conns != nil {
+		fSCfg.ResourceSConns = make([]*HaPoolConfig, len(*jsnCfg.Resources_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Resources_conns {
+			fSCfg.ResourceSConns[idx] = NewDfltHaPoolConfig()
+			fSCfg.ResourceSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Apis_enabled != nil {
+		fSCfg.APIsEnabled = *jsnCfg.Apis_enabled
+	}
 	return
 }
@@ -85,7 +85,9 @@ type DbJsonCfg struct {
 
 // Filters config
 type FilterSJsonCfg struct {
-	Stats_conns *[]*HaPoolJsonCfg
+	Stats_conns     *[]*HaPoolJsonCfg
+	Resources_conns *[]*HaPoolJsonCfg
+	Apis_enabled    *bool
 }
 
 // Rater config section
@@ -225,6 +227,8 @@ type SessionSJsonCfg struct {
 	Session_ttl_usage         *string
 	Session_indexes           *[]string
 	Client_protocol           *float64
+	Store_interval            *string
+	Channel_sync_interval     *string
 }
 
 // FreeSWITCHAgent config section
@@ -382,6 +386,19 @@ type HttpAgentJsonCfg struct {
 	Request_processors *[]*HttpAgentProcessorJsnCfg
 }
 
+// Prometheus exporter config section
+type PrometheusAgentJsonCfg struct {
+	Enabled          *bool
+	Url              *string
+	Tenants          *[]string
+	Stats_conns      *[]*HaPoolJsonCfg
+	Resources_conns  *[]*HaPoolJsonCfg
+	Thresholds_conns *[]*HaPoolJsonCfg
+	Caches_conns     *[]*HaPoolJsonCfg
+	Sessions_conns   *[]*HaPoolJsonCfg
+	Scheduler_conns  *[]*HaPoolJsonCfg
+}
+
 type HttpAgentProcessorJsnCfg struct {
 	Id                  *string
 	Dry_run             *bool
@@ -420,6 +437,8 @@ type AttributeSJsonCfg struct {
 	Enabled               *bool
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
+	Suffix_indexed_fields *[]string
+	Process_runs          *int
 }
 
 // ResourceLimiter service config section
@@ -429,6 +448,9 @@ type ResourceSJsonCfg struct {
 	Store_interval        *string
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
+	Suffix_indexed_fields *[]string
+	Sessions_conns        *[]*HaPoolJsonCfg
+	Reconcile_interval    *string
 }
 
 // Stat service config section
@@ -438,6 +460,7 @@ type StatServJsonCfg struct {
 	Thresholds_conns      *[]*HaPoolJsonCfg
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
+	Suffix_indexed_fields *[]string
 }
 
 // Threshold service config section
@@ -446,6 +469,7 @@ type ThresholdSJsonCfg struct {
 	Store_interval        *string
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
+	Suffix_indexed_fields *[]string
 }
 
 // Supplier service config section
@@ -453,6 +477,7 @@ type SupplierSJsonCfg struct {
 	Enabled               *bool
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
+	Suffix_indexed_fields *[]string
 	Rals_conns            *[]*HaPoolJsonCfg
 	Resources_conns       *[]*HaPoolJsonCfg
 	Stats_conns           *[]*HaPoolJsonCfg
@@ -517,15 +542,23 @@ type SureTaxJsonCfg struct {
 
 // Dispatcher service config section
 type DispatcherSJsonCfg struct {
-	Enabled              *bool
-	Rals_conns           *[]*HaPoolJsonCfg
-	Resources_conns      *[]*HaPoolJsonCfg
-	Thresholds_conns     *[]*HaPoolJsonCfg
-	Stats_conns          *[]*HaPoolJsonCfg
-	Suppliers_conns      *[]*HaPoolJsonCfg
-	Attributes_conns     *[]*HaPoolJsonCfg
-	Sessions_conns       *[]*HaPoolJsonCfg
-	Dispatching_strategy *string
+	Enabled               *bool
+	Rals_conns            *[]*HaPoolJsonCfg
+	Resources_conns       *[]*HaPoolJsonCfg
+	Thresholds_conns      *[]*HaPoolJsonCfg
+	Stats_conns           *[]*HaPoolJsonCfg
+	Suppliers_conns       *[]*HaPoolJsonCfg
+	Attributes_conns      *[]*HaPoolJsonCfg
+	Sessions_conns        *[]*HaPoolJsonCfg
+	Cdrs_conns            *[]*HaPoolJsonCfg
+	Caches_conns          *[]*HaPoolJsonCfg
+	Apier_conns           *[]*HaPoolJsonCfg
+	Dispatching_strategy  *string
+	Hosts                 *map[string][]*HaPoolJsonCfg
+	Health_check_interval *string
+	Health_check_methods  *[]string
+	Failure_threshold     *int
+	Recovery_threshold    *int
 }
 
 type LoaderCfgJson struct {
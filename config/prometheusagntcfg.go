@@ -0,0 +1,93 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+// PrometheusAgentCfg is the configuration of the Prometheus metrics exporter
+type PrometheusAgentCfg struct {
+	Enabled         bool
+	Url             string
+	Tenants         []string // tenants to export metrics for, empty for default_tenant
+	StatSConns      []*HaPoolConfig
+	ResourceSConns  []*HaPoolConfig
+	ThresholdSConns []*HaPoolConfig
+	CacheSConns     []*HaPoolConfig
+	SessionSConns   []*HaPoolConfig
+	SchedulerConns  []*HaPoolConfig
+}
+
+func (pa *PrometheusAgentCfg) loadFromJsonCfg(jsnCfg *PrometheusAgentJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		pa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Url != nil {
+		pa.Url = *jsnCfg.Url
+	}
+	if jsnCfg.Tenants != nil {
+		pa.Tenants = make([]string, len(*jsnCfg.Tenants))
+		for i, tnt := range *jsnCfg.Tenants {
+			pa.Tenants[i] = tnt
+		}
+	}
+	if jsnCfg.Stats_conns != nil {
+		pa.StatSConns = make([]*HaPoolConfig, len(*jsnCfg.Stats_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Stats_conns {
+			pa.StatSConns[idx] = NewDfltHaPoolConfig()
+			pa.StatSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Resources_conns != nil {
+		pa.ResourceSConns = make([]*HaPoolConfig, len(*jsnCfg.Resources_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Resources_conns {
+			pa.ResourceSConns[idx] = NewDfltHaPoolConfig()
+			pa.ResourceSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Thresholds_conns != nil {
+		pa.ThresholdSConns = make([]*HaPoolConfig, len(*jsnCfg.Thresholds_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Thresholds_conns {
+			pa.ThresholdSConns[idx] = NewDfltHaPoolConfig()
+			pa.ThresholdSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Caches_conns != nil {
+		pa.CacheSConns = make([]*HaPoolConfig, len(*jsnCfg.Caches_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Caches_conns {
+			pa.CacheSConns[idx] = NewDfltHaPoolConfig()
+			pa.CacheSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Sessions_conns != nil {
+		pa.SessionSConns = make([]*HaPoolConfig, len(*jsnCfg.Sessions_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Sessions_conns {
+			pa.SessionSConns[idx] = NewDfltHaPoolConfig()
+			pa.SessionSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Scheduler_conns != nil {
+		pa.SchedulerConns = make([]*HaPoolConfig, len(*jsnCfg.Scheduler_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Scheduler_conns {
+			pa.SchedulerConns[idx] = NewDfltHaPoolConfig()
+			pa.SchedulerConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	return nil
+}
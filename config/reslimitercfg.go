@@ -30,6 +30,9 @@ type ResourceSConfig struct {
 	StoreInterval       time.Duration   // Dump regularly from cache into dataDB
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
+	SuffixIndexedFields *[]string
+	SessionSConns       []*HaPoolConfig // Connections towards SessionS, used to reconcile usages
+	ReconcileInterval   time.Duration   // Release regularly the usages without active session, 0 to disable
 }
 
 func (rlcfg *ResourceSConfig) loadFromJsonCfg(jsnCfg *ResourceSJsonCfg) (err error) {
@@ -65,5 +68,24 @@ func (rlcfg *ResourceSConfig) loadFromJsonCfg(jsnCfg *ResourceSJsonCfg) (err err
 		}
 		rlcfg.PrefixIndexedFields = &pif
 	}
+	if jsnCfg.Suffix_indexed_fields != nil {
+		sfif := make([]string, len(*jsnCfg.Suffix_indexed_fields))
+		for i, fID := range *jsnCfg.Suffix_indexed_fields {
+			sfif[i] = fID
+		}
+		rlcfg.SuffixIndexedFields = &sfif
+	}
+	if jsnCfg.Sessions_conns != nil {
+		rlcfg.SessionSConns = make([]*HaPoolConfig, len(*jsnCfg.Sessions_conns))
+		for idx, jsnHaCfg := range *jsnCfg.Sessions_conns {
+			rlcfg.SessionSConns[idx] = NewDfltHaPoolConfig()
+			rlcfg.SessionSConns[idx].loadFromJsonCfg(jsnHaCfg)
+		}
+	}
+	if jsnCfg.Reconcile_interval != nil {
+		if rlcfg.ReconcileInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconcile_interval); err != nil {
+			return
+		}
+	}
 	return nil
 }
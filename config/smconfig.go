@@ -108,6 +108,8 @@ type SessionSCfg struct {
 	SessionTTLUsage         *time.Duration
 	SessionIndexes          utils.StringMap
 	ClientProtocol          float64
+	StoreInterval           time.Duration // snapshot active sessions into DataDB, 0 to disable
+	ChannelSyncInterval     time.Duration // sync active sessions with the agents, 0 to disable
 }
 
 func (self *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) error {
@@ -217,6 +219,16 @@ func (self *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) error {
 	if jsnCfg.Client_protocol != nil {
 		self.ClientProtocol = *jsnCfg.Client_protocol
 	}
+	if jsnCfg.Store_interval != nil {
+		if self.StoreInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Store_interval); err != nil {
+			return err
+		}
+	}
+	if jsnCfg.Channel_sync_interval != nil {
+		if self.ChannelSyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Channel_sync_interval); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
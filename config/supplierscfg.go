@@ -24,6 +24,7 @@ type SupplierSCfg struct {
 	Enabled             bool
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
+	SuffixIndexedFields *[]string
 	RALsConns           []*HaPoolConfig
 	ResourceSConns      []*HaPoolConfig
 	StatSConns          []*HaPoolConfig
@@ -50,6 +51,13 @@ func (spl *SupplierSCfg) loadFromJsonCfg(jsnCfg *SupplierSJsonCfg) (err error) {
 		}
 		spl.PrefixIndexedFields = &pif
 	}
+	if jsnCfg.Suffix_indexed_fields != nil {
+		sfif := make([]string, len(*jsnCfg.Suffix_indexed_fields))
+		for i, fID := range *jsnCfg.Suffix_indexed_fields {
+			sfif[i] = fID
+		}
+		spl.SuffixIndexedFields = &sfif
+	}
 	if jsnCfg.Rals_conns != nil {
 		spl.RALsConns = make([]*HaPoolConfig, len(*jsnCfg.Rals_conns))
 		for idx, jsnHaCfg := range *jsnCfg.Rals_conns {
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdFilterExplain{
+		name:      "filter_explain",
+		rpcMethod: utils.FilterSv1ExplainFilters,
+		rpcParams: &engine.ArgsExplainFilters{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdFilterExplain evaluates filters for an event, explaining the result of each rule
+type CmdFilterExplain struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.ArgsExplainFilters
+	*CommandExecuter
+}
+
+func (self *CmdFilterExplain) Name() string {
+	return self.name
+}
+
+func (self *CmdFilterExplain) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdFilterExplain) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &engine.ArgsExplainFilters{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdFilterExplain) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdFilterExplain) RpcResult() interface{} {
+	var fExp engine.FiltersExplanation
+	return &fExp
+}
@@ -0,0 +1,4 @@
+USE `cgrates`;
+
+ALTER TABLE `tp_attributes`
+	ADD COLUMN `type` varchar(32) NOT NULL DEFAULT '' after `weight` ;
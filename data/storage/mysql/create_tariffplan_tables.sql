@@ -410,6 +410,11 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `allocation_strategy` varchar(16) NOT NULL,
+  `max_usage_units` varchar(64) NOT NULL,
+  `queue_timeout` varchar(32) NOT NULL,
+  `queue_length` int(11) NOT NULL,
+  `rate_interval` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -437,6 +442,7 @@ CREATE TABLE tp_stats (
   `weight` decimal(8,2) NOT NULL,
   `min_items` int(11) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `bucket_interval` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -462,6 +468,8 @@ CREATE TABLE tp_thresholds (
   `weight` decimal(8,2) NOT NULL,
   `action_ids` varchar(64) NOT NULL,
   `async` BOOLEAN NOT NULL,
+  `reset_interval` varchar(16) NOT NULL,
+  `recovery_filter_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -540,6 +548,7 @@ CREATE TABLE tp_attributes (
   `substitute` varchar(64) NOT NULL,
   `append` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `type` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -0,0 +1,2 @@
+ALTER TABLE tp_attributes
+	ADD COLUMN "type" varchar(32) NOT NULL DEFAULT '';
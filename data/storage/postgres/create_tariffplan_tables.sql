@@ -406,6 +406,11 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "allocation_strategy" varchar(16) NOT NULL,
+  "max_usage_units" varchar(64) NOT NULL,
+  "queue_timeout" varchar(32) NOT NULL,
+  "queue_length" INTEGER NOT NULL,
+  "rate_interval" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -433,6 +438,7 @@ CREATE TABLE tp_stats (
   "weight" decimal(8,2) NOT NULL,
   "min_items" INTEGER NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "bucket_interval" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -457,6 +463,8 @@ CREATE TABLE tp_thresholds (
   "weight" decimal(8,2) NOT NULL,
   "action_ids" varchar(64) NOT NULL,
   "async" BOOLEAN NOT NULL,
+  "reset_interval" varchar(16) NOT NULL,
+  "recovery_filter_ids" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_thresholds_idx ON tp_thresholds (tpid);
@@ -530,6 +538,7 @@ CREATE INDEX tp_suppliers_unique ON tp_suppliers  ("tpid",  "tenant", "id",
     "substitute" varchar(64) NOT NULL,
     "append" BOOLEAN NOT NULL,
     "weight" decimal(8,2) NOT NULL,
+    "type" varchar(32) NOT NULL,
     "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_attributes_ids ON tp_attributes (tpid);
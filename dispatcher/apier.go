@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func (dS *DispatcherService) ApierV1GetAccount(args *AttrGetAccountWithApiKey,
+	reply *interface{}) (err error) {
+	if err = dS.authorize(utils.ApierV1GetAccount, args.AttrGetAccount.Tenant,
+		args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.AttrGetAccount.Tenant,
+		ID:     utils.UUIDSha1Prefix(),
+		Event: map[string]interface{}{
+			utils.Account: args.AttrGetAccount.Account,
+		},
+	}, utils.MetaApier, dS.apierS, utils.ApierV1GetAccount, &args.AttrGetAccount, reply)
+}
+
+func (dS *DispatcherService) ApierV1GetAccounts(args *AttrGetAccountsWithApiKey,
+	reply *[]interface{}) (err error) {
+	if err = dS.authorize(utils.ApierV1GetAccounts, args.AttrGetAccounts.Tenant,
+		args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.AttrGetAccounts.Tenant, ID: utils.UUIDSha1Prefix()},
+		utils.MetaApier, dS.apierS, utils.ApierV1GetAccounts, args.AttrGetAccounts, reply)
+}
+
+func (dS *DispatcherService) ApierV1GetMaxUsage(args *UsageRecordWithApiKey,
+	reply *float64) (err error) {
+	tnt := tenantOrDefault(args.UsageRecord.Tenant)
+	if err = dS.authorize(utils.ApierV1GetMaxUsage, tnt,
+		args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     utils.UUIDSha1Prefix(),
+		Event: map[string]interface{}{
+			utils.Account:     args.UsageRecord.Account,
+			utils.Subject:     args.UsageRecord.Subject,
+			utils.Destination: args.UsageRecord.Destination,
+		},
+	}, utils.MetaApier, dS.apierS, utils.ApierV1GetMaxUsage, args.UsageRecord, reply)
+}
@@ -0,0 +1,64 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDspApierV1(t *testing.T) {
+	eCalls := []string{utils.ApierV1GetAccount, utils.ApierV1GetAccounts, utils.ApierV1GetMaxUsage}
+	conn := new(mockDspConn)
+	dS := newTestDispatcherService(strings.Join(eCalls, utils.INFIELD_SEP), conn)
+	var rplyAcnt interface{}
+	if err := dS.ApierV1GetAccount(&AttrGetAccountWithApiKey{
+		APIKey:         "12345",
+		AttrGetAccount: utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001"},
+	}, &rplyAcnt); err != nil {
+		t.Error(err)
+	}
+	var rplyAcnts []interface{}
+	if err := dS.ApierV1GetAccounts(&AttrGetAccountsWithApiKey{
+		APIKey:          "12345",
+		AttrGetAccounts: utils.AttrGetAccounts{Tenant: "cgrates.org"},
+	}, &rplyAcnts); err != nil {
+		t.Error(err)
+	}
+	var maxUsage float64
+	if err := dS.ApierV1GetMaxUsage(&UsageRecordWithApiKey{
+		APIKey:      "12345",
+		UsageRecord: engine.UsageRecord{Account: "1001", Destination: "1002"},
+	}, &maxUsage); err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(eCalls, conn.calls) {
+		t.Errorf("expecting: %+v, received: %+v", eCalls, conn.calls)
+	}
+	// no ApierV1 connection and no DispatcherProfile matching
+	dS = newTestDispatcherService(strings.Join(eCalls, utils.INFIELD_SEP), nil)
+	if err := dS.ApierV1GetMaxUsage(&UsageRecordWithApiKey{APIKey: "12345"},
+		&maxUsage); err == nil || err.Error() != utils.NewErrNotConnected(utils.MetaApier).Error() {
+		t.Errorf("expecting: %v, received: %v", utils.NewErrNotConnected(utils.MetaApier), err)
+	}
+}
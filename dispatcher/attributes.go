@@ -19,22 +19,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatcher
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) AttributeSv1Ping(ign string, reply *string) error {
-	if dS.attrS == nil {
-		return utils.NewErrNotConnected(utils.ResourceS)
-	}
-	return dS.attrS.Call(utils.AttributeSv1Ping, ign, reply)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaAttributes, dS.attrS, utils.AttributeSv1Ping, ign, reply)
 }
 
 func (dS *DispatcherService) AttributeSv1GetAttributeForEvent(args *CGREvWithApiKey,
 	reply *engine.AttributeProfile) (err error) {
-	if dS.attrS == nil {
-		return utils.NewErrNotConnected(utils.ResourceS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -55,15 +51,13 @@ func (dS *DispatcherService) AttributeSv1GetAttributeForEvent(args *CGREvWithApi
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.AttributeSv1GetAttributeForEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.attrS.Call(utils.AttributeSv1GetAttributeForEvent, args.CGREvent, reply)
+	return dS.Dispatch(&args.CGREvent, utils.MetaAttributes, dS.attrS,
+		utils.AttributeSv1GetAttributeForEvent, args.CGREvent, reply)
 
 }
 
-func (dS *DispatcherService) AttributeSv1ProcessEvent(args *CGREvWithApiKey,
+func (dS *DispatcherService) AttributeSv1ProcessEvent(args *ArgsAttrProcessEventWithApiKey,
 	reply *engine.AttrSProcessEventReply) (err error) {
-	if dS.attrS == nil {
-		return utils.NewErrNotConnected(utils.ResourceS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -84,6 +78,7 @@ func (dS *DispatcherService) AttributeSv1ProcessEvent(args *CGREvWithApiKey,
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.AttributeSv1ProcessEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.attrS.Call(utils.AttributeSv1ProcessEvent, args.CGREvent, reply)
+	return dS.Dispatch(&args.CGREvent, utils.MetaAttributes, dS.attrS,
+		utils.AttributeSv1ProcessEvent, args.AttrArgsProcessEvent, reply)
 
 }
@@ -268,8 +268,12 @@ func testDspAttrTestAuthKey2(t *testing.T) {
 	}
 
 	eRply := &engine.AttrSProcessEventReply{
-		MatchedProfile: "ATTR_1001_SIMPLEAUTH",
-		AlteredFields:  []string{"Password"},
+		MatchedProfile:  "ATTR_1001_SIMPLEAUTH",
+		AlteredFields:   []string{"Password"},
+		MatchedProfiles: []string{"ATTR_1001_SIMPLEAUTH"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "ATTR_1001_SIMPLEAUTH",
+				AlteredFields: []string{"Password"}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "testAttributeSGetAttributeForEvent",
@@ -0,0 +1,132 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+func (dS *DispatcherService) CacheSv1Ping(ign string, reply *string) error {
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1Ping, ign, reply)
+}
+
+func (dS *DispatcherService) CacheSv1GetItemIDs(args *ArgsGetCacheItemIDsWithApiKey,
+	reply *[]string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1GetItemIDs, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1GetItemIDs, &args.ArgsGetCacheItemIDs, reply)
+}
+
+func (dS *DispatcherService) CacheSv1HasItem(args *ArgsGetCacheItemWithApiKey,
+	reply *bool) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1HasItem, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1HasItem, &args.ArgsGetCacheItem, reply)
+}
+
+func (dS *DispatcherService) CacheSv1GetItemExpiryTime(args *ArgsGetCacheItemWithApiKey,
+	reply *time.Time) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1GetItemExpiryTime, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1GetItemExpiryTime, &args.ArgsGetCacheItem, reply)
+}
+
+func (dS *DispatcherService) CacheSv1RemoveItem(args *ArgsGetCacheItemWithApiKey,
+	reply *string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1RemoveItem, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1RemoveItem, &args.ArgsGetCacheItem, reply)
+}
+
+func (dS *DispatcherService) CacheSv1Clear(args *ArgsCacheIDsWithApiKey,
+	reply *string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1Clear, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1Clear, args.CacheIDs, reply)
+}
+
+func (dS *DispatcherService) CacheSv1GetCacheStats(args *ArgsCacheIDsWithApiKey,
+	reply *map[string]*ltcache.CacheStats) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1GetCacheStats, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1GetCacheStats, args.CacheIDs, reply)
+}
+
+func (dS *DispatcherService) CacheSv1PrecacheStatus(args *ArgsCacheIDsWithApiKey,
+	reply *map[string]string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1PrecacheStatus, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1PrecacheStatus, args.CacheIDs, reply)
+}
+
+func (dS *DispatcherService) CacheSv1HasGroup(args *ArgsGetGroupWithApiKey,
+	reply *bool) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1HasGroup, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1HasGroup, &args.ArgsGetGroup, reply)
+}
+
+func (dS *DispatcherService) CacheSv1GetGroupItemIDs(args *ArgsGetGroupWithApiKey,
+	reply *[]string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1GetGroupItemIDs, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1GetGroupItemIDs, &args.ArgsGetGroup, reply)
+}
+
+func (dS *DispatcherService) CacheSv1RemoveGroup(args *ArgsGetGroupWithApiKey,
+	reply *string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CacheSv1RemoveGroup, tnt, args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCaches, dS.cacheS, utils.CacheSv1RemoveGroup, &args.ArgsGetGroup, reply)
+}
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+func TestDspCacheSv1(t *testing.T) {
+	eCalls := []string{utils.CacheSv1Ping, utils.CacheSv1GetItemIDs, utils.CacheSv1HasItem,
+		utils.CacheSv1GetItemExpiryTime, utils.CacheSv1RemoveItem, utils.CacheSv1Clear,
+		utils.CacheSv1GetCacheStats, utils.CacheSv1PrecacheStatus, utils.CacheSv1HasGroup,
+		utils.CacheSv1GetGroupItemIDs, utils.CacheSv1RemoveGroup}
+	conn := new(mockDspConn)
+	dS := newTestDispatcherService(strings.Join(eCalls[1:], utils.INFIELD_SEP), conn)
+	var rplyStr string
+	if err := dS.CacheSv1Ping("", &rplyStr); err != nil {
+		t.Error(err)
+	}
+	var rplyIDs []string
+	if err := dS.CacheSv1GetItemIDs(&ArgsGetCacheItemIDsWithApiKey{APIKey: "12345"},
+		&rplyIDs); err != nil {
+		t.Error(err)
+	}
+	itmArgs := &ArgsGetCacheItemWithApiKey{APIKey: "12345", Tenant: "cgrates.org"}
+	var rplyBool bool
+	if err := dS.CacheSv1HasItem(itmArgs, &rplyBool); err != nil {
+		t.Error(err)
+	}
+	var rplyTime time.Time
+	if err := dS.CacheSv1GetItemExpiryTime(itmArgs, &rplyTime); err != nil {
+		t.Error(err)
+	}
+	if err := dS.CacheSv1RemoveItem(itmArgs, &rplyStr); err != nil {
+		t.Error(err)
+	}
+	idsArgs := &ArgsCacheIDsWithApiKey{APIKey: "12345", Tenant: "cgrates.org"}
+	if err := dS.CacheSv1Clear(idsArgs, &rplyStr); err != nil {
+		t.Error(err)
+	}
+	var rplyStats map[string]*ltcache.CacheStats
+	if err := dS.CacheSv1GetCacheStats(idsArgs, &rplyStats); err != nil {
+		t.Error(err)
+	}
+	var rplyStatus map[string]string
+	if err := dS.CacheSv1PrecacheStatus(idsArgs, &rplyStatus); err != nil {
+		t.Error(err)
+	}
+	grpArgs := &ArgsGetGroupWithApiKey{APIKey: "12345", Tenant: "cgrates.org"}
+	if err := dS.CacheSv1HasGroup(grpArgs, &rplyBool); err != nil {
+		t.Error(err)
+	}
+	if err := dS.CacheSv1GetGroupItemIDs(grpArgs, &rplyIDs); err != nil {
+		t.Error(err)
+	}
+	if err := dS.CacheSv1RemoveGroup(grpArgs, &rplyStr); err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(eCalls, conn.calls) {
+		t.Errorf("expecting: %+v, received: %+v", eCalls, conn.calls)
+	}
+	grpArgs.APIKey = "unknown"
+	if err := dS.CacheSv1RemoveGroup(grpArgs, &rplyStr); err == nil {
+		t.Error("expecting error for unknown APIKey")
+	} else if len(conn.calls) != len(eCalls) {
+		t.Errorf("unauthorized call dispatched: %+v", conn.calls)
+	}
+}
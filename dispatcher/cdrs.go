@@ -0,0 +1,44 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func (dS *DispatcherService) CdrsV1ProcessCDR(args *CDRWithApiKey,
+	reply *string) (err error) {
+	if err = dS.authorize(utils.CdrsV1ProcessCDR, args.CDR.Tenant,
+		args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(args.CDR.AsCGREvent(), utils.MetaCDRs, dS.cdrS,
+		utils.CdrsV1ProcessCDR, &args.CDR, reply)
+}
+
+func (dS *DispatcherService) CdrsV1RateCDRs(args *ArgsRateCDRsWithApiKey,
+	reply *string) (err error) {
+	tnt := tenantOrDefault(args.Tenant)
+	if err = dS.authorize(utils.CdrsV1RateCDRs, tnt,
+		args.APIKey, nil); err != nil {
+		return
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt, ID: utils.UUIDSha1Prefix()},
+		utils.MetaCDRs, dS.cdrS, utils.CdrsV1RateCDRs, args.AttrRateCdrs, reply)
+}
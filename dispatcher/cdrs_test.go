@@ -0,0 +1,57 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDspCdrsV1(t *testing.T) {
+	conn := new(mockDspConn)
+	dS := newTestDispatcherService(strings.Join([]string{utils.CdrsV1ProcessCDR,
+		utils.CdrsV1RateCDRs}, utils.INFIELD_SEP), conn)
+	var reply string
+	if err := dS.CdrsV1ProcessCDR(&CDRWithApiKey{
+		APIKey: "12345",
+		CDR: engine.CDR{
+			Tenant:      "cgrates.org",
+			OriginID:    "TestDspCdrsV1",
+			Account:     "1001",
+			Destination: "1002",
+		},
+	}, &reply); err != nil {
+		t.Error(err)
+	}
+	// tenant defaulted from config
+	if err := dS.CdrsV1RateCDRs(&ArgsRateCDRsWithApiKey{APIKey: "12345"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := dS.CdrsV1RateCDRs(&ArgsRateCDRsWithApiKey{APIKey: "unknown"}, &reply); err == nil {
+		t.Error("expecting error for unknown APIKey")
+	}
+	eCalls := []string{utils.CdrsV1ProcessCDR, utils.CdrsV1RateCDRs}
+	if !reflect.DeepEqual(eCalls, conn.calls) {
+		t.Errorf("expecting: %+v, received: %+v", eCalls, conn.calls)
+	}
+}
@@ -21,15 +21,19 @@ package dispatcher
 import (
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 )
 
 // NewDispatcherService initializes a DispatcherService
-func NewDispatcherService(dm *engine.DataManager, rals, resS, thdS,
-	statS, splS, attrS, sessionS rpcclient.RpcClientConnection) (*DispatcherService, error) {
+func NewDispatcherService(dm *engine.DataManager, cfg *config.DispatcherSCfg,
+	filterS *engine.FilterS, hosts map[string]rpcclient.RpcClientConnection, rals, resS, thdS,
+	statS, splS, attrS, sessionS, cdrS, cacheS,
+	apierS rpcclient.RpcClientConnection) (*DispatcherService, error) {
 	if rals != nil && reflect.ValueOf(rals).IsNil() {
 		rals = nil
 	}
@@ -51,30 +55,73 @@ func NewDispatcherService(dm *engine.DataManager, rals, resS, thdS,
 	if sessionS != nil && reflect.ValueOf(sessionS).IsNil() {
 		sessionS = nil
 	}
+	if cdrS != nil && reflect.ValueOf(cdrS).IsNil() {
+		cdrS = nil
+	}
+	if cacheS != nil && reflect.ValueOf(cacheS).IsNil() {
+		cacheS = nil
+	}
+	if apierS != nil && reflect.ValueOf(apierS).IsNil() {
+		apierS = nil
+	}
+	for hostID, conn := range hosts {
+		if conn != nil && reflect.ValueOf(conn).IsNil() {
+			delete(hosts, hostID)
+		}
+	}
+	loads := newHostLoads()
 	return &DispatcherService{dm: dm,
+		cfg:      cfg,
+		filterS:  filterS,
+		hosts:    hosts,
+		loads:    loads,
+		health:   newHostsHealth(cfg.FailureThreshold, cfg.RecoveryThreshold),
+		stopChan: make(chan struct{}),
+		strategies: map[string]strategyDispatcher{
+			utils.MetaWeight:     new(weightStrategy),
+			utils.MetaRoundRobin: newRoundRobinStrategy(),
+			utils.MetaHash:       new(hashStrategy),
+			utils.MetaLoad:       &loadStrategy{loads: loads},
+		},
 		rals:     rals,
 		resS:     resS,
 		thdS:     thdS,
 		statS:    statS,
 		splS:     splS,
 		attrS:    attrS,
-		sessionS: sessionS}, nil
+		sessionS: sessionS,
+		cdrS:     cdrS,
+		cacheS:   cacheS,
+		apierS:   apierS}, nil
 }
 
 // DispatcherService  is the service handling dispatcher
 type DispatcherService struct {
-	dm       *engine.DataManager
-	rals     rpcclient.RpcClientConnection // RALs connections
-	resS     rpcclient.RpcClientConnection // ResourceS connections
-	thdS     rpcclient.RpcClientConnection // ThresholdS connections
-	statS    rpcclient.RpcClientConnection // StatS connections
-	splS     rpcclient.RpcClientConnection // SupplierS connections
-	attrS    rpcclient.RpcClientConnection // AttributeS connections
-	sessionS rpcclient.RpcClientConnection // SessionS server connections
+	dm         *engine.DataManager
+	cfg        *config.DispatcherSCfg
+	filterS    *engine.FilterS
+	hosts      map[string]rpcclient.RpcClientConnection // connections towards hosts referenced in DispatcherProfiles
+	loads      *hostLoads                               // requests in progress per host
+	health     *hostsHealth                             // results of the health checks, unhealthy hosts are not dispatched to
+	stopChan   chan struct{}                            // stops the health checks on shutdown
+	strategies map[string]strategyDispatcher
+	rals       rpcclient.RpcClientConnection // RALs connections
+	resS       rpcclient.RpcClientConnection // ResourceS connections
+	thdS       rpcclient.RpcClientConnection // ThresholdS connections
+	statS      rpcclient.RpcClientConnection // StatS connections
+	splS       rpcclient.RpcClientConnection // SupplierS connections
+	attrS      rpcclient.RpcClientConnection // AttributeS connections
+	sessionS   rpcclient.RpcClientConnection // SessionS server connections
+	cdrS       rpcclient.RpcClientConnection // CDRs connections
+	cacheS     rpcclient.RpcClientConnection // CacheS connections
+	apierS     rpcclient.RpcClientConnection // ApierV1 connections
 }
 
 // ListenAndServe will initialize the service
 func (dS *DispatcherService) ListenAndServe(exitChan chan bool) error {
+	if dS.cfg.HealthCheckInterval > 0 && len(dS.hosts) != 0 {
+		go dS.runHealthChecks(dS.stopChan)
+	}
 	e := <-exitChan
 	exitChan <- e // put back for the others listening for shutdown request
 	return nil
@@ -83,6 +130,7 @@ func (dS *DispatcherService) ListenAndServe(exitChan chan bool) error {
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() error {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	close(dS.stopChan)
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 	return nil
 }
@@ -92,5 +140,83 @@ func (dS *DispatcherService) authorizeEvent(ev *utils.CGREvent,
 	if dS.attrS == nil {
 		return utils.NewErrNotConnected(utils.AttributeS)
 	}
-	return dS.attrS.Call(utils.AttributeSv1ProcessEvent, ev, reply)
+	return dS.attrS.Call(utils.AttributeSv1ProcessEvent,
+		&engine.AttrArgsProcessEvent{CGREvent: *ev}, reply)
+}
+
+// authorize checks via AttributeS if the APIKey is allowed to call the method
+func (dS *DispatcherService) authorize(method, tenant, apiKey string,
+	evTime *time.Time) (err error) {
+	ev := &utils.CGREvent{
+		Tenant:  tenant,
+		ID:      utils.UUIDSha1Prefix(),
+		Context: utils.StringPointer(utils.MetaAuth),
+		Time:    evTime,
+		Event: map[string]interface{}{
+			utils.APIKey: apiKey,
+		},
+	}
+	var rplyEv engine.AttrSProcessEventReply
+	if err = dS.authorizeEvent(ev, &rplyEv); err != nil {
+		return
+	}
+	var apiMethods string
+	if apiMethods, err = rplyEv.CGREvent.FieldAsString(utils.APIMethods); err != nil {
+		return
+	}
+	if !utils.ParseStringMap(apiMethods).HasKey(method) {
+		return utils.ErrUnauthorizedApi
+	}
+	return
+}
+
+// Dispatch routes the API call to the hosts of the DispatcherProfile matching the event,
+// trying the next host in strategy order on network errors.
+// If no profile matches, the call goes to the connection configured for the subsystem.
+func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
+	subsysConn rpcclient.RpcClientConnection, serviceMethod string,
+	args interface{}, reply interface{}) (err error) {
+	var dPrfls engine.DispatcherProfiles
+	if dPrfls, err = engine.MatchingDispatcherProfilesForEvent(dS.dm, dS.filterS,
+		ev, subsys); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		if subsysConn == nil {
+			return utils.NewErrNotConnected(subsys)
+		}
+		return subsysConn.Call(serviceMethod, args, reply)
+	}
+	dPrfl := dPrfls[0]
+	strategy, has := dS.strategies[dPrfl.Strategy]
+	if !has {
+		return fmt.Errorf("unsupported dispatching strategy: <%s> in profile: <%s>",
+			dPrfl.Strategy, dPrfl.TenantID())
+	}
+	err = nil
+	for _, hostID := range strategy.hostIDs(dPrfl, ev) {
+		conn, has := dS.hosts[hostID]
+		if !has {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> no connection for host: <%s> in profile: <%s>",
+					utils.DispatcherS, hostID, dPrfl.TenantID()))
+			continue
+		}
+		if !dS.health.isHealthy(hostID) { // ejected by health checks
+			continue
+		}
+		dS.loads.incr(hostID)
+		err = conn.Call(serviceMethod, args, reply)
+		dS.loads.decr(hostID)
+		if !isNetworkError(err) {
+			return
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: <%s> dispatching <%s> to host: <%s>",
+				utils.DispatcherS, err.Error(), serviceMethod, hostID))
+	}
+	if err == nil { // no host could be used
+		err = utils.NewErrNotConnected(subsys)
+	}
+	return
 }
@@ -0,0 +1,125 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// mockAuthConn replies as AttributeS with apiMethods allowed for the APIKey "12345"
+type mockAuthConn struct {
+	apiMethods string
+}
+
+func (mc *mockAuthConn) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	attrArgs := args.(*engine.AttrArgsProcessEvent)
+	if attrArgs.Event[utils.APIKey] != "12345" {
+		return utils.ErrNotFound
+	}
+	rplyEv := attrArgs.CGREvent
+	rplyEv.Event = map[string]interface{}{utils.APIMethods: mc.apiMethods}
+	*reply.(*engine.AttrSProcessEventReply) = engine.AttrSProcessEventReply{CGREvent: &rplyEv}
+	return nil
+}
+
+// mockDspConn records the API methods dispatched to it
+type mockDspConn struct {
+	calls []string
+}
+
+func (mc *mockDspConn) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	mc.calls = append(mc.calls, serviceMethod)
+	return nil
+}
+
+// newTestDispatcherService returns a DispatcherService without DispatcherProfiles,
+// sending the authorized APIs to the subsystem connection conn
+func newTestDispatcherService(apiMethods string, conn rpcclient.RpcClientConnection) *DispatcherService {
+	data, _ := engine.NewMapStorage()
+	dS, _ := NewDispatcherService(engine.NewDataManager(data), &config.DispatcherSCfg{},
+		nil, nil, conn, nil, nil, nil, nil, &mockAuthConn{apiMethods: apiMethods},
+		nil, conn, conn, conn)
+	return dS
+}
+
+func TestDspAuthorize(t *testing.T) {
+	dS := newTestDispatcherService(utils.ApierV1GetAccount, nil)
+	if err := dS.authorize(utils.ApierV1GetAccount, "cgrates.org", "12345", nil); err != nil {
+		t.Error(err)
+	}
+	if err := dS.authorize(utils.ApierV1GetAccounts, "cgrates.org",
+		"12345", nil); err != utils.ErrUnauthorizedApi {
+		t.Errorf("expecting: %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := dS.authorize(utils.ApierV1GetAccount, "cgrates.org",
+		"unknown", nil); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDspDispatchSpread(t *testing.T) {
+	data, _ := engine.NewMapStorage()
+	dm := engine.NewDataManager(data)
+	if err := dm.SetDispatcherProfile(&engine.DispatcherProfile{
+		Tenant:     "cgrates.org",
+		ID:         "DSP_RR",
+		Subsystems: []string{utils.MetaAttributes},
+		Strategy:   utils.MetaRoundRobin,
+		Hosts: engine.DispatcherHosts{
+			&engine.DispatcherHost{ID: "HOST1", Weight: 10},
+			&engine.DispatcherHost{ID: "HOST2", Weight: 30},
+			&engine.DispatcherHost{ID: "HOST3", Weight: 20},
+		},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	conns := map[string]*mockDspConn{"HOST1": new(mockDspConn),
+		"HOST2": new(mockDspConn), "HOST3": new(mockDspConn)}
+	hosts := make(map[string]rpcclient.RpcClientConnection)
+	for hostID, conn := range conns {
+		hosts[hostID] = conn
+	}
+	cfg, _ := config.NewDefaultCGRConfig()
+	dS, _ := NewDispatcherService(dm, &config.DispatcherSCfg{},
+		engine.NewFilterS(cfg, nil, nil, dm), hosts,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "TestDspDispatchSpread",
+		Event: map[string]interface{}{utils.Account: "1001"}}
+	for i := 0; i < 60; i++ {
+		var reply string
+		if err := dS.Dispatch(ev, utils.MetaAttributes, nil,
+			utils.AttributeSv1Ping, "", &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eHits := map[string]int{"HOST1": 10, "HOST2": 30, "HOST3": 20}
+	rcvHits := make(map[string]int)
+	for hostID, conn := range conns {
+		rcvHits[hostID] = len(conn.calls)
+	}
+	if !reflect.DeepEqual(eHits, rcvHits) {
+		t.Errorf("expecting: %+v, received: %+v", eHits, rcvHits)
+	}
+}
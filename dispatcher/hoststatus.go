@@ -0,0 +1,173 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// HostStatus is the health of one of the hosts DispatcherS routes to
+type HostStatus struct {
+	HostID    string
+	Healthy   bool      // false when the host is ejected from dispatching
+	Failures  int       // consecutive failed health checks
+	Successes int       // consecutive successful health checks
+	LastCheck time.Time // zero if the host was not checked yet
+	LastError string
+}
+
+// ArgsGetHostStatus filters the hosts returned by DispatcherSv1GetHostStatus, all hosts if empty
+type ArgsGetHostStatus struct {
+	HostIDs []string
+}
+
+func newHostsHealth(failureThreshold, recoveryThreshold int) *hostsHealth {
+	return &hostsHealth{
+		statuses:          make(map[string]*HostStatus),
+		failureThreshold:  failureThreshold,
+		recoveryThreshold: recoveryThreshold,
+	}
+}
+
+// hostsHealth keeps the results of the health checks for each host
+type hostsHealth struct {
+	sync.RWMutex
+	statuses          map[string]*HostStatus
+	failureThreshold  int // eject the host after this number of consecutive failures
+	recoveryThreshold int // use the host again after this number of consecutive successes
+}
+
+// isHealthy returns false only for ejected hosts, hosts not checked yet are considered healthy
+func (hh *hostsHealth) isHealthy(hostID string) bool {
+	hh.RLock()
+	defer hh.RUnlock()
+	hSts, has := hh.statuses[hostID]
+	return !has || hSts.Healthy
+}
+
+// report records the result of one health check, ejecting or recovering the host when the thresholds are reached
+func (hh *hostsHealth) report(hostID string, err error) {
+	hh.Lock()
+	defer hh.Unlock()
+	hSts, has := hh.statuses[hostID]
+	if !has {
+		hSts = &HostStatus{HostID: hostID, Healthy: true}
+		hh.statuses[hostID] = hSts
+	}
+	hSts.LastCheck = time.Now()
+	if err != nil {
+		hSts.Failures++
+		hSts.Successes = 0
+		hSts.LastError = err.Error()
+		if hSts.Healthy && hSts.Failures >= hh.failureThreshold {
+			hSts.Healthy = false
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> ejecting host: <%s> after %d failed health checks, last error: <%s>",
+					utils.DispatcherS, hostID, hSts.Failures, hSts.LastError))
+		}
+		return
+	}
+	hSts.Successes++
+	hSts.Failures = 0
+	hSts.LastError = ""
+	if !hSts.Healthy && hSts.Successes >= hh.recoveryThreshold {
+		hSts.Healthy = true
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> host: <%s> recovered after %d successful health checks",
+				utils.DispatcherS, hostID, hSts.Successes))
+	}
+}
+
+// status returns a copy of the statuses for the hostIDs, ordered by HostID
+func (hh *hostsHealth) status(hostIDs []string) (hStss []*HostStatus) {
+	hh.RLock()
+	defer hh.RUnlock()
+	hStss = make([]*HostStatus, 0, len(hostIDs))
+	for _, hostID := range hostIDs {
+		hSts := &HostStatus{HostID: hostID, Healthy: true}
+		if cached, has := hh.statuses[hostID]; has {
+			*hSts = *cached
+		}
+		hStss = append(hStss, hSts)
+	}
+	sort.Slice(hStss, func(i, j int) bool { return hStss[i].HostID < hStss[j].HostID })
+	return
+}
+
+// checkHost calls the health check methods on the host, one successful reply is enough
+func (dS *DispatcherService) checkHost(conn rpcclient.RpcClientConnection) (err error) {
+	for _, method := range dS.cfg.HealthCheckMethods {
+		var reply string
+		if err = conn.Call(method, "", &reply); err == nil {
+			return
+		}
+	}
+	return
+}
+
+// checkHosts runs one health check on all hosts in parallel so a hanging host does not delay the others
+func (dS *DispatcherService) checkHosts() {
+	var wg sync.WaitGroup
+	for hostID, conn := range dS.hosts {
+		wg.Add(1)
+		go func(hostID string, conn rpcclient.RpcClientConnection) {
+			dS.health.report(hostID, dS.checkHost(conn))
+			wg.Done()
+		}(hostID, conn)
+	}
+	wg.Wait()
+}
+
+// runHealthChecks checks the hosts periodically until stopChan is closed
+func (dS *DispatcherService) runHealthChecks(stopChan chan struct{}) {
+	for {
+		dS.checkHosts()
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(dS.cfg.HealthCheckInterval):
+		}
+	}
+}
+
+// DispatcherSv1GetHostStatus returns the health of the hosts
+func (dS *DispatcherService) DispatcherSv1GetHostStatus(args *ArgsGetHostStatus,
+	reply *[]*HostStatus) (err error) {
+	hostIDs := args.HostIDs
+	if len(hostIDs) == 0 {
+		hostIDs = make([]string, 0, len(dS.hosts))
+		for hostID := range dS.hosts {
+			hostIDs = append(hostIDs, hostID)
+		}
+	} else {
+		for _, hostID := range hostIDs {
+			if _, has := dS.hosts[hostID]; !has {
+				return utils.ErrNotFound
+			}
+		}
+	}
+	*reply = dS.health.status(hostIDs)
+	return
+}
@@ -0,0 +1,103 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestDspHostsHealthReport(t *testing.T) {
+	hh := newHostsHealth(2, 2)
+	if !hh.isHealthy("HOST1") {
+		t.Error("unchecked host should be healthy")
+	}
+	errTimeout := errors.New("REPLY_TIMEOUT")
+	hh.report("HOST1", errTimeout)
+	if !hh.isHealthy("HOST1") {
+		t.Error("host ejected before reaching failure threshold")
+	}
+	hh.report("HOST1", nil) // success resets the failures
+	hh.report("HOST1", errTimeout)
+	if !hh.isHealthy("HOST1") {
+		t.Error("host ejected without consecutive failures")
+	}
+	hh.report("HOST1", errTimeout)
+	if hh.isHealthy("HOST1") {
+		t.Error("host not ejected after reaching failure threshold")
+	}
+	hh.report("HOST1", nil)
+	if hh.isHealthy("HOST1") {
+		t.Error("host recovered before reaching recovery threshold")
+	}
+	hh.report("HOST1", nil)
+	if !hh.isHealthy("HOST1") {
+		t.Error("host not recovered after reaching recovery threshold")
+	}
+	if sts := hh.status([]string{"HOST1"}); len(sts) != 1 ||
+		!sts[0].Healthy || sts[0].Successes != 2 ||
+		sts[0].Failures != 0 || sts[0].LastError != "" {
+		t.Errorf("received: %s", utils.ToJSON(sts))
+	}
+}
+
+type mockPingConn struct {
+	err error
+}
+
+func (mc *mockPingConn) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if mc.err != nil {
+		return mc.err
+	}
+	*reply.(*string) = utils.Pong
+	return nil
+}
+
+func TestDspCheckHosts(t *testing.T) {
+	dS := &DispatcherService{
+		cfg: &config.DispatcherSCfg{
+			HealthCheckMethods: []string{utils.AttributeSv1Ping},
+			FailureThreshold:   1,
+			RecoveryThreshold:  1,
+		},
+		hosts: map[string]rpcclient.RpcClientConnection{
+			"HOST1": &mockPingConn{},
+			"HOST2": &mockPingConn{err: errors.New("REPLY_TIMEOUT")},
+		},
+		health: newHostsHealth(1, 1),
+	}
+	dS.checkHosts()
+	var sts []*HostStatus
+	if err := dS.DispatcherSv1GetHostStatus(&ArgsGetHostStatus{}, &sts); err != nil {
+		t.Fatal(err)
+	}
+	if len(sts) != 2 ||
+		sts[0].HostID != "HOST1" || !sts[0].Healthy ||
+		sts[1].HostID != "HOST2" || sts[1].Healthy || sts[1].LastError != "REPLY_TIMEOUT" {
+		t.Errorf("received: %s", utils.ToJSON(sts))
+	}
+	if err := dS.DispatcherSv1GetHostStatus(&ArgsGetHostStatus{HostIDs: []string{"HOST3"}},
+		&sts); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/rpc"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// strategyDispatcher orders the hosts of a DispatcherProfile for one request
+type strategyDispatcher interface {
+	// hostIDs returns the IDs of the hosts in the order they should be tried
+	hostIDs(dPrfl *engine.DispatcherProfile, ev *utils.CGREvent) []string
+}
+
+// weightedHostIDs returns the host IDs of the profile ordered by Weight
+func weightedHostIDs(dPrfl *engine.DispatcherProfile) []string {
+	hosts := dPrfl.Hosts.Clone()
+	hosts.Sort()
+	return hosts.HostIDs()
+}
+
+// firstHostID returns the hostIDs with the one at idx moved first, the others keep their order for failover
+func firstHostID(hostIDs []string, idx int) []string {
+	return append(append(append(make([]string, 0, len(hostIDs)), hostIDs[idx]), hostIDs[:idx]...), hostIDs[idx+1:]...)
+}
+
+// hostWeights returns the weights used to spread the requests over the hosts,
+// all hosts weighing the same if none has a positive Weight
+func hostWeights(hosts engine.DispatcherHosts) (weights []float64, total float64) {
+	weights = make([]float64, len(hosts))
+	for i, host := range hosts {
+		if host.Weight > 0 {
+			weights[i] = host.Weight
+			total += host.Weight
+		}
+	}
+	if total == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+	return
+}
+
+// rotateHostIDs returns the hostIDs starting with the one at idx, the ones before it are kept for failover
+func rotateHostIDs(hostIDs []string, idx int) []string {
+	return append(append(make([]string, 0, len(hostIDs)), hostIDs[idx:]...), hostIDs[:idx]...)
+}
+
+// isNetworkError returns true for errors after which the request can be sent to the next host
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, isNetErr := err.(net.Error); isNetErr {
+		return true
+	}
+	return err.Error() == rpc.ErrShutdown.Error() ||
+		err.Error() == rpcclient.ErrReplyTimeout.Error() ||
+		strings.HasPrefix(err.Error(), "NOT_CONNECTED")
+}
+
+// weightStrategy dispatches randomly, each host receiving a share of the requests proportional to its Weight
+type weightStrategy struct{}
+
+func (*weightStrategy) hostIDs(dPrfl *engine.DispatcherProfile, ev *utils.CGREvent) []string {
+	hosts := dPrfl.Hosts.Clone()
+	hosts.Sort()
+	hostIDs := hosts.HostIDs()
+	if len(hostIDs) == 0 {
+		return hostIDs
+	}
+	weights, total := hostWeights(hosts)
+	pick := rand.Float64() * total
+	for i, weight := range weights {
+		if pick -= weight; pick < 0 {
+			return firstHostID(hostIDs, i)
+		}
+	}
+	return hostIDs
+}
+
+func newRoundRobinStrategy() *roundRobinStrategy {
+	return &roundRobinStrategy{crtWeights: make(map[string]map[string]float64)}
+}
+
+// roundRobinStrategy dispatches each request of a profile to the next host, interleaving them
+// so each host receives a share of the requests proportional to its Weight (smooth weighted round-robin)
+type roundRobinStrategy struct {
+	sync.Mutex
+	crtWeights map[string]map[string]float64 // current weight of the hosts, per DispatcherProfile
+}
+
+func (rr *roundRobinStrategy) hostIDs(dPrfl *engine.DispatcherProfile, ev *utils.CGREvent) []string {
+	hosts := dPrfl.Hosts.Clone()
+	hosts.Sort()
+	hostIDs := hosts.HostIDs()
+	if len(hostIDs) == 0 {
+		return hostIDs
+	}
+	weights, total := hostWeights(hosts)
+	rr.Lock()
+	crtWeights, has := rr.crtWeights[dPrfl.TenantID()]
+	if !has {
+		crtWeights = make(map[string]float64)
+		rr.crtWeights[dPrfl.TenantID()] = crtWeights
+	}
+	var idx int
+	for i, hostID := range hostIDs {
+		crtWeights[hostID] += weights[i]
+		if crtWeights[hostID] > crtWeights[hostIDs[idx]] {
+			idx = i
+		}
+	}
+	crtWeights[hostIDs[idx]] -= total
+	rr.Unlock()
+	return firstHostID(hostIDs, idx)
+}
+
+// hashStrategy dispatches requests with the same value of the hash field to the same host,
+// ie: all requests of one session based on OriginID
+type hashStrategy struct{}
+
+func (*hashStrategy) hostIDs(dPrfl *engine.DispatcherProfile, ev *utils.CGREvent) []string {
+	hostIDs := weightedHostIDs(dPrfl)
+	if len(hostIDs) == 0 {
+		return hostIDs
+	}
+	fldName := utils.OriginID
+	if fldIface, has := dPrfl.StrategyParams[utils.MetaHashField]; has {
+		if fld, canCast := utils.CastFieldIfToString(fldIface); canCast && fld != "" {
+			fldName = fld
+		}
+	}
+	fldVal, err := ev.FieldAsString(fldName)
+	if err != nil || fldVal == "" { // nothing to hash on, keep the weight order
+		return hostIDs
+	}
+	h := fnv.New32a()
+	h.Write([]byte(fldVal))
+	return rotateHostIDs(hostIDs, int(h.Sum32()%uint32(len(hostIDs))))
+}
+
+func newHostLoads() *hostLoads {
+	return &hostLoads{loads: make(map[string]int64)}
+}
+
+// hostLoads counts the requests in progress for each host
+type hostLoads struct {
+	sync.RWMutex
+	loads map[string]int64
+}
+
+func (hl *hostLoads) incr(hostID string) {
+	hl.Lock()
+	hl.loads[hostID]++
+	hl.Unlock()
+}
+
+func (hl *hostLoads) decr(hostID string) {
+	hl.Lock()
+	if hl.loads[hostID]--; hl.loads[hostID] <= 0 {
+		delete(hl.loads, hostID)
+	}
+	hl.Unlock()
+}
+
+// snapshot returns the current loads for the hostIDs
+func (hl *hostLoads) snapshot(hostIDs []string) (loads map[string]int64) {
+	loads = make(map[string]int64, len(hostIDs))
+	hl.RLock()
+	for _, hostID := range hostIDs {
+		loads[hostID] = hl.loads[hostID]
+	}
+	hl.RUnlock()
+	return
+}
+
+// loadStrategy dispatches to the host with the least requests in progress,
+// hosts with equal load are ordered by Weight
+type loadStrategy struct {
+	loads *hostLoads
+}
+
+func (ls *loadStrategy) hostIDs(dPrfl *engine.DispatcherProfile, ev *utils.CGREvent) []string {
+	hostIDs := weightedHostIDs(dPrfl)
+	loads := ls.loads.snapshot(hostIDs)
+	sort.SliceStable(hostIDs, func(i, j int) bool {
+		return loads[hostIDs[i]] < loads[hostIDs[j]]
+	})
+	return hostIDs
+}
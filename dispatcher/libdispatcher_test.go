@@ -0,0 +1,158 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+var dspTestPrfl = &engine.DispatcherProfile{
+	Tenant: "cgrates.org",
+	ID:     "DSP_TEST",
+	Hosts: engine.DispatcherHosts{
+		&engine.DispatcherHost{ID: "HOST1", Weight: 10},
+		&engine.DispatcherHost{ID: "HOST2", Weight: 30},
+		&engine.DispatcherHost{ID: "HOST3", Weight: 20},
+	},
+}
+
+func TestDspWeightStrategy(t *testing.T) {
+	ws := new(weightStrategy)
+	hits := make(map[string]int)
+	for i := 0; i < 6000; i++ {
+		rcv := ws.hostIDs(dspTestPrfl, nil)
+		if len(rcv) != 3 {
+			t.Fatalf("expecting all hosts for failover, received: %+v", rcv)
+		}
+		hits[rcv[0]]++
+	}
+	// spread proportional to weights 10, 30 and 20
+	for hostID, eHits := range map[string]int{"HOST1": 1000, "HOST2": 3000, "HOST3": 2000} {
+		if hits[hostID] < eHits*8/10 || hits[hostID] > eHits*12/10 {
+			t.Errorf("host: %s, expecting around %d requests, received: %d", hostID, eHits, hits[hostID])
+		}
+	}
+	// failover in weight order
+	prfl := &engine.DispatcherProfile{
+		Tenant: "cgrates.org",
+		ID:     "DSP_ONE",
+		Hosts: engine.DispatcherHosts{
+			&engine.DispatcherHost{ID: "HOST1", Weight: 0},
+			&engine.DispatcherHost{ID: "HOST2", Weight: 10},
+			&engine.DispatcherHost{ID: "HOST3", Weight: 0},
+		},
+	}
+	eIDs := []string{"HOST2", "HOST1", "HOST3"}
+	if rcv := ws.hostIDs(prfl, nil); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+	}
+}
+
+func TestDspRoundRobinStrategy(t *testing.T) {
+	rr := newRoundRobinStrategy()
+	for _, eIDs := range [][]string{
+		[]string{"HOST2", "HOST3", "HOST1"},
+		[]string{"HOST3", "HOST2", "HOST1"},
+		[]string{"HOST2", "HOST3", "HOST1"},
+		[]string{"HOST1", "HOST2", "HOST3"},
+		[]string{"HOST2", "HOST3", "HOST1"},
+		[]string{"HOST3", "HOST2", "HOST1"},
+		[]string{"HOST2", "HOST3", "HOST1"},
+	} {
+		if rcv := rr.hostIDs(dspTestPrfl, nil); !reflect.DeepEqual(eIDs, rcv) {
+			t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+		}
+	}
+	// hosts without weight share the requests equally
+	prfl := &engine.DispatcherProfile{
+		Tenant: "cgrates.org",
+		ID:     "DSP_NO_WEIGHT",
+		Hosts: engine.DispatcherHosts{
+			&engine.DispatcherHost{ID: "HOST1"},
+			&engine.DispatcherHost{ID: "HOST2"},
+		},
+	}
+	hits := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		hits[rr.hostIDs(prfl, nil)[0]]++
+	}
+	if hits["HOST1"] != 5 || hits["HOST2"] != 5 {
+		t.Errorf("received: %+v", hits)
+	}
+}
+
+func TestDspHashStrategy(t *testing.T) {
+	hs := new(hashStrategy)
+	ev := &utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{utils.OriginID: "session1"}}
+	first := hs.hostIDs(dspTestPrfl, ev)
+	for i := 0; i < 5; i++ { // same session always lands on same host
+		if rcv := hs.hostIDs(dspTestPrfl, ev); !reflect.DeepEqual(first, rcv) {
+			t.Errorf("expecting: %+v, received: %+v", first, rcv)
+		}
+	}
+	if len(first) != 3 {
+		t.Errorf("expecting all hosts for failover, received: %+v", first)
+	}
+	// no hash field in event, weight order
+	eIDs := []string{"HOST2", "HOST3", "HOST1"}
+	if rcv := hs.hostIDs(dspTestPrfl, &utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{}}); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+	}
+}
+
+func TestDspLoadStrategy(t *testing.T) {
+	loads := newHostLoads()
+	ls := &loadStrategy{loads: loads}
+	loads.incr("HOST2")
+	loads.incr("HOST2")
+	loads.incr("HOST3")
+	eIDs := []string{"HOST1", "HOST3", "HOST2"}
+	if rcv := ls.hostIDs(dspTestPrfl, nil); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	loads.decr("HOST2")
+	loads.decr("HOST2")
+	eIDs = []string{"HOST2", "HOST1", "HOST3"}
+	if rcv := ls.hostIDs(dspTestPrfl, nil); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, rcv)
+	}
+}
+
+func TestDspIsNetworkError(t *testing.T) {
+	if isNetworkError(nil) {
+		t.Error("nil is not a network error")
+	}
+	if isNetworkError(utils.ErrNotFound) {
+		t.Error("NOT_FOUND is not a network error")
+	}
+	if !isNetworkError(errors.New(rpcclient.ErrReplyTimeout.Error())) {
+		t.Error("REPLY_TIMEOUT should be a network error")
+	}
+	if !isNetworkError(utils.NewErrNotConnected(utils.AttributeS)) {
+		t.Error("NOT_CONNECTED should be a network error")
+	}
+}
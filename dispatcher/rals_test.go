@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDspResponder(t *testing.T) {
+	conn := new(mockDspConn)
+	dS := newTestDispatcherService(strings.Join([]string{utils.ResponderGetCost,
+		utils.ResponderMaxDebit, utils.ResponderGetMaxSessionTime}, utils.INFIELD_SEP), conn)
+	args := &CallDescriptorWithApiKey{
+		APIKey: "12345",
+		CallDescriptor: engine.CallDescriptor{
+			Direction:   utils.OUT,
+			Category:    "call",
+			Tenant:      "cgrates.org",
+			Subject:     "1001",
+			Account:     "1001",
+			Destination: "1002",
+			TimeStart:   time.Date(2018, 5, 10, 10, 0, 0, 0, time.UTC),
+			TimeEnd:     time.Date(2018, 5, 10, 10, 1, 0, 0, time.UTC),
+		},
+	}
+	var cc engine.CallCost
+	if err := dS.ResponderGetCost(args, &cc); err != nil {
+		t.Error(err)
+	}
+	if err := dS.ResponderDebit(args, &cc); err != utils.ErrUnauthorizedApi {
+		t.Errorf("expecting: %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := dS.ResponderMaxDebit(args, &cc); err != nil {
+		t.Error(err)
+	}
+	var maxTime float64
+	if err := dS.ResponderGetMaxSessionTime(args, &maxTime); err != nil {
+		t.Error(err)
+	}
+	eCalls := []string{utils.ResponderGetCost, utils.ResponderMaxDebit,
+		utils.ResponderGetMaxSessionTime}
+	if !reflect.DeepEqual(eCalls, conn.calls) {
+		t.Errorf("expecting: %+v, received: %+v", eCalls, conn.calls)
+	}
+}
@@ -19,21 +19,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatcher
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) ResourceSv1Ping(ign string, rpl *string) (err error) {
-	if dS.resS == nil {
-		return utils.NewErrNotConnected(utils.ResourceS)
-	}
-	return dS.resS.Call(utils.ResourceSv1Ping, ign, rpl)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaResources, dS.resS, utils.ResourceSv1Ping, ign, rpl)
 }
 
 func (dS *DispatcherService) ResourceSv1GetResourcesForEvent(args ArgsV1ResUsageWithApiKey, reply *engine.Resources) (err error) {
-	if dS.resS == nil {
-		return utils.NewErrNotConnected(utils.ResourceS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -54,6 +50,7 @@ func (dS *DispatcherService) ResourceSv1GetResourcesForEvent(args ArgsV1ResUsage
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.ResourceSv1GetResourcesForEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.resS.Call(utils.ResourceSv1GetResourcesForEvent, args.ArgRSv1ResourceUsage, reply)
+	return dS.Dispatch(&args.ArgRSv1ResourceUsage.CGREvent, utils.MetaResources, dS.resS,
+		utils.ResourceSv1GetResourcesForEvent, args.ArgRSv1ResourceUsage, reply)
 
 }
@@ -19,23 +19,19 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatcher
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) SessionSv1Ping(ign string, rpl *string) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
-	return dS.sessionS.Call(utils.SessionSv1Ping, ign, rpl)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaSessionS, dS.sessionS, utils.SessionSv1Ping, ign, rpl)
 }
 
 func (dS *DispatcherService) SessionSv1AuthorizeEventWithDigest(args *AuthorizeArgsWithApiKey,
 	reply *sessions.V1AuthorizeReplyWithDigest) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -56,14 +52,12 @@ func (dS *DispatcherService) SessionSv1AuthorizeEventWithDigest(args *AuthorizeA
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1AuthorizeEventWithDigest) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1AuthorizeEventWithDigest, args.V1AuthorizeArgs, reply)
+	return dS.Dispatch(&args.V1AuthorizeArgs.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1AuthorizeEventWithDigest, args.V1AuthorizeArgs, reply)
 }
 
 func (dS *DispatcherService) SessionSv1InitiateSessionWithDigest(args *InitArgsWithApiKey,
 	reply *sessions.V1InitSessionReply) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -84,14 +78,12 @@ func (dS *DispatcherService) SessionSv1InitiateSessionWithDigest(args *InitArgsW
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1InitiateSessionWithDigest) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1InitiateSessionWithDigest, args.V1InitSessionArgs, reply)
+	return dS.Dispatch(&args.V1InitSessionArgs.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1InitiateSessionWithDigest, args.V1InitSessionArgs, reply)
 }
 
 func (dS *DispatcherService) SessionSv1ProcessCDR(args *CGREvWithApiKey,
 	reply *string) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -112,14 +104,12 @@ func (dS *DispatcherService) SessionSv1ProcessCDR(args *CGREvWithApiKey,
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1ProcessCDR) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1ProcessCDR, args.CGREvent, reply)
+	return dS.Dispatch(&args.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1ProcessCDR, args.CGREvent, reply)
 }
 
 func (dS *DispatcherService) SessionSv1ProcessEvent(args *ProcessEventWithApiKey,
 	reply *sessions.V1ProcessEventReply) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -140,14 +130,12 @@ func (dS *DispatcherService) SessionSv1ProcessEvent(args *ProcessEventWithApiKey
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1ProcessEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1ProcessEvent, args.V1ProcessEventArgs, reply)
+	return dS.Dispatch(&args.V1ProcessEventArgs.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1ProcessEvent, args.V1ProcessEventArgs, reply)
 }
 
 func (dS *DispatcherService) SessionSv1TerminateSession(args *TerminateSessionWithApiKey,
 	reply *string) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -168,14 +156,12 @@ func (dS *DispatcherService) SessionSv1TerminateSession(args *TerminateSessionWi
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1TerminateSession) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1TerminateSession, args.V1TerminateSessionArgs, reply)
+	return dS.Dispatch(&args.V1TerminateSessionArgs.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1TerminateSession, args.V1TerminateSessionArgs, reply)
 }
 
 func (dS *DispatcherService) SessionSv1UpdateSession(args *UpdateSessionWithApiKey,
 	reply *sessions.V1UpdateSessionReply) (err error) {
-	if dS.sessionS == nil {
-		return utils.NewErrNotConnected(utils.SessionS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -196,5 +182,6 @@ func (dS *DispatcherService) SessionSv1UpdateSession(args *UpdateSessionWithApiK
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SessionSv1UpdateSession) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.sessionS.Call(utils.SessionSv1UpdateSession, args.V1UpdateSessionArgs, reply)
+	return dS.Dispatch(&args.V1UpdateSessionArgs.CGREvent, utils.MetaSessionS, dS.sessionS,
+		utils.SessionSv1UpdateSession, args.V1UpdateSessionArgs, reply)
 }
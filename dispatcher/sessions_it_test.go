@@ -367,8 +367,12 @@ func testDspSessionUpdate(t *testing.T) {
 		t.Error(err)
 	}
 	eAttrs := &engine.AttrSProcessEventReply{
-		MatchedProfile: "ATTR_ACNT_1001",
-		AlteredFields:  []string{"OfficeGroup"},
+		MatchedProfile:  "ATTR_ACNT_1001",
+		AlteredFields:   []string{"OfficeGroup"},
+		MatchedProfiles: []string{"ATTR_ACNT_1001"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "ATTR_ACNT_1001",
+				AlteredFields: []string{"OfficeGroup"}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "TestSSv1ItUpdateSession",
@@ -500,8 +504,12 @@ func testDspSessionProcessEvent(t *testing.T) {
 		t.Errorf("Unexpected ResourceAllocation: %s", *rply.ResourceAllocation)
 	}
 	eAttrs := &engine.AttrSProcessEventReply{
-		MatchedProfile: "ATTR_ACNT_1001",
-		AlteredFields:  []string{"OfficeGroup"},
+		MatchedProfile:  "ATTR_ACNT_1001",
+		AlteredFields:   []string{"OfficeGroup"},
+		MatchedProfiles: []string{"ATTR_ACNT_1001"},
+		ProcessRuns: []*engine.AttrSProcessRun{
+			&engine.AttrSProcessRun{MatchedProfile: "ATTR_ACNT_1001",
+				AlteredFields: []string{"OfficeGroup"}}},
 		CGREvent: &utils.CGREvent{
 			Tenant:  "cgrates.org",
 			ID:      "TestSSv1ItProcessEvent",
@@ -21,22 +21,18 @@ package dispatcher
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) StatSv1Ping(ign string, reply *string) error {
-	if dS.statS == nil {
-		return utils.NewErrNotConnected(utils.StatS)
-	}
-	return dS.statS.Call(utils.StatSv1Ping, ign, reply)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaStats, dS.statS, utils.StatSv1Ping, ign, reply)
 }
 
 func (dS *DispatcherService) StatSv1GetStatQueuesForEvent(args *CGREvWithApiKey,
 	reply *[]string) (err error) {
-	if dS.statS == nil {
-		return utils.NewErrNotConnected(utils.StatS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -57,14 +53,12 @@ func (dS *DispatcherService) StatSv1GetStatQueuesForEvent(args *CGREvWithApiKey,
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.StatSv1GetStatQueuesForEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.statS.Call(utils.StatSv1GetStatQueuesForEvent, args.CGREvent, reply)
+	return dS.Dispatch(&args.CGREvent, utils.MetaStats, dS.statS,
+		utils.StatSv1GetStatQueuesForEvent, args.CGREvent, reply)
 }
 
 func (dS *DispatcherService) StatSv1GetQueueStringMetrics(args *TntIDWithApiKey,
 	reply *map[string]string) (err error) {
-	if dS.statS == nil {
-		return utils.NewErrNotConnected(utils.StatS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -85,14 +79,12 @@ func (dS *DispatcherService) StatSv1GetQueueStringMetrics(args *TntIDWithApiKey,
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.StatSv1GetQueueStringMetrics) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.statS.Call(utils.StatSv1GetQueueStringMetrics, args.TenantID, reply)
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.Tenant, ID: args.ID}, utils.MetaStats, dS.statS,
+		utils.StatSv1GetQueueStringMetrics, args.TenantID, reply)
 }
 
 func (dS *DispatcherService) StatSv1ProcessEvent(args *CGREvWithApiKey,
 	reply *[]string) (err error) {
-	if dS.statS == nil {
-		return utils.NewErrNotConnected(utils.StatS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -113,5 +105,6 @@ func (dS *DispatcherService) StatSv1ProcessEvent(args *CGREvWithApiKey,
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.StatSv1ProcessEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.statS.Call(utils.StatSv1ProcessEvent, args.CGREvent, reply)
+	return dS.Dispatch(&args.CGREvent, utils.MetaStats, dS.statS,
+		utils.StatSv1ProcessEvent, args.CGREvent, reply)
 }
@@ -19,22 +19,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatcher
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) SupplierSv1Ping(ign string, reply *string) error {
-	if dS.splS == nil {
-		return utils.NewErrNotConnected(utils.SupplierS)
-	}
-	return dS.splS.Call(utils.SupplierSv1Ping, ign, reply)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaSuppliers, dS.splS, utils.SupplierSv1Ping, ign, reply)
 }
 
 func (dS *DispatcherService) SupplierSv1GetSuppliers(args *ArgsGetSuppliersWithApiKey,
 	reply *engine.SortedSuppliers) (err error) {
-	if dS.splS == nil {
-		return utils.NewErrNotConnected(utils.SupplierS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -55,6 +51,7 @@ func (dS *DispatcherService) SupplierSv1GetSuppliers(args *ArgsGetSuppliersWithA
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.SupplierSv1GetSuppliers) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.splS.Call(utils.SupplierSv1GetSuppliers, args.ArgsGetSuppliers, reply)
+	return dS.Dispatch(&args.ArgsGetSuppliers.CGREvent, utils.MetaSuppliers, dS.splS,
+		utils.SupplierSv1GetSuppliers, args.ArgsGetSuppliers, reply)
 
 }
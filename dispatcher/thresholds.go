@@ -19,22 +19,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatcher
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 func (dS *DispatcherService) ThresholdSv1Ping(ign string, reply *string) error {
-	if dS.thdS == nil {
-		return utils.NewErrNotConnected(utils.ThresholdS)
-	}
-	return dS.thdS.Call(utils.ThresholdSv1Ping, ign, reply)
+	return dS.Dispatch(&utils.CGREvent{Tenant: config.CgrConfig().DefaultTenant},
+		utils.MetaThresholds, dS.thdS, utils.ThresholdSv1Ping, ign, reply)
 }
 
 func (dS *DispatcherService) ThresholdSv1GetThresholdsForEvent(args *ArgsProcessEventWithApiKey,
 	t *engine.Thresholds) (err error) {
-	if dS.thdS == nil {
-		return utils.NewErrNotConnected(utils.ThresholdS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -55,14 +51,12 @@ func (dS *DispatcherService) ThresholdSv1GetThresholdsForEvent(args *ArgsProcess
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.ThresholdSv1GetThresholdsForEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.thdS.Call(utils.ThresholdSv1GetThresholdsForEvent, args.ArgsProcessEvent, t)
+	return dS.Dispatch(&args.ArgsProcessEvent.CGREvent, utils.MetaThresholds, dS.thdS,
+		utils.ThresholdSv1GetThresholdsForEvent, args.ArgsProcessEvent, t)
 }
 
 func (dS *DispatcherService) ThresholdSv1ProcessEvent(args *ArgsProcessEventWithApiKey,
 	tIDs *[]string) (err error) {
-	if dS.thdS == nil {
-		return utils.NewErrNotConnected(utils.ThresholdS)
-	}
 	ev := &utils.CGREvent{
 		Tenant:  args.Tenant,
 		ID:      utils.UUIDSha1Prefix(),
@@ -83,5 +77,6 @@ func (dS *DispatcherService) ThresholdSv1ProcessEvent(args *ArgsProcessEventWith
 	if !utils.ParseStringMap(apiMethods).HasKey(utils.ThresholdSv1ProcessEvent) {
 		return utils.ErrUnauthorizedApi
 	}
-	return dS.thdS.Call(utils.ThresholdSv1ProcessEvent, args.ArgsProcessEvent, tIDs)
+	return dS.Dispatch(&args.ArgsProcessEvent.CGREvent, utils.MetaThresholds, dS.thdS,
+		utils.ThresholdSv1ProcessEvent, args.ArgsProcessEvent, tIDs)
 }
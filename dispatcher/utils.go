@@ -21,6 +21,7 @@ package dispatcher
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
@@ -37,6 +38,11 @@ type CGREvWithApiKey struct {
 	utils.CGREvent
 }
 
+type ArgsAttrProcessEventWithApiKey struct {
+	APIKey string
+	engine.AttrArgsProcessEvent
+}
+
 type TntIDWithApiKey struct {
 	utils.TenantID
 	APIKey string
@@ -86,3 +92,66 @@ type UpdateSessionWithApiKey struct {
 	APIKey string
 	sessions.V1UpdateSessionArgs
 }
+
+type CallDescriptorWithApiKey struct {
+	APIKey string
+	engine.CallDescriptor
+}
+
+type CDRWithApiKey struct {
+	APIKey string
+	engine.CDR
+}
+
+type ArgsRateCDRsWithApiKey struct {
+	APIKey string
+	Tenant string
+	utils.AttrRateCdrs
+}
+
+type ArgsGetCacheItemIDsWithApiKey struct {
+	APIKey string
+	Tenant string
+	engine.ArgsGetCacheItemIDs
+}
+
+type ArgsGetCacheItemWithApiKey struct {
+	APIKey string
+	Tenant string
+	engine.ArgsGetCacheItem
+}
+
+type ArgsGetGroupWithApiKey struct {
+	APIKey string
+	Tenant string
+	engine.ArgsGetGroup
+}
+
+type ArgsCacheIDsWithApiKey struct {
+	APIKey   string
+	Tenant   string
+	CacheIDs []string
+}
+
+type AttrGetAccountWithApiKey struct {
+	APIKey string
+	utils.AttrGetAccount
+}
+
+type AttrGetAccountsWithApiKey struct {
+	APIKey string
+	utils.AttrGetAccounts
+}
+
+type UsageRecordWithApiKey struct {
+	APIKey string
+	engine.UsageRecord
+}
+
+// tenantOrDefault returns the tenant, defaulting to the one in config if empty
+func tenantOrDefault(tnt string) string {
+	if tnt == "" {
+		return config.CgrConfig().DefaultTenant
+	}
+	return tnt
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"io/ioutil"
 	"net/smtp"
 	"path"
 	"reflect"
@@ -82,6 +83,12 @@ const (
 	TopUpZeroNegative         = "*topup_zero_negative"
 	SetExpiry                 = "*set_expiry"
 	MetaPublishAccount        = "*publish_account"
+	MetaHTTPPost              = "*http_post"
+	MetaHTTPPostAsync         = "*http_post_async"
+	MetaAMQPPost              = "*amqp_post"
+	MetaFilePost              = "*file_post"
+	MetaDisableProfile        = "*disable_profile"
+	MetaEnableProfile         = "*enable_profile"
 )
 
 func (a *Action) Clone() *Action {
@@ -90,11 +97,14 @@ func (a *Action) Clone() *Action {
 	return &clonedAction
 }
 
-type actionTypeFunc func(*Account, *CDRStatsQueueTriggered, *Action, Actions) error
+// actionTypeFunc is executed for each action, extraData is the context of the execution, ie: the ThresholdHit
+type actionTypeFunc func(*Account, *CDRStatsQueueTriggered, *Action, Actions, interface{}) error
+
+// accountActionFunc is implemented by the actions not considering the extraData
+type accountActionFunc func(*Account, *CDRStatsQueueTriggered, *Action, Actions) error
 
 func getActionFunc(typ string) (actionTypeFunc, bool) {
-	actionFuncMap := map[string]actionTypeFunc{
-		LOG:                       logAction,
+	actionFuncMap := map[string]accountActionFunc{
 		CDRLOG:                    cdrLogAction,
 		RESET_TRIGGERS:            resetTriggersAction,
 		SET_RECURRENT:             setRecurrentAction,
@@ -109,8 +119,6 @@ func getActionFunc(typ string) (actionTypeFunc, bool) {
 		RESET_COUNTERS:            resetCountersAction,
 		ENABLE_ACCOUNT:            enableAccountAction,
 		DISABLE_ACCOUNT:           disableAccountAction,
-		CALL_URL:                  callUrl,
-		CALL_URL_ASYNC:            callUrlAsync,
 		MAIL_ASYNC:                mailAsync,
 		SET_DDESTINATIONS:         setddestinations,
 		REMOVE_ACCOUNT:            removeAccountAction,
@@ -122,11 +130,27 @@ func getActionFunc(typ string) (actionTypeFunc, bool) {
 		SetExpiry:                 setExpiryAction,
 		MetaPublishAccount:        publishAccount,
 	}
-	f, exists := actionFuncMap[typ]
+	if f, exists := actionFuncMap[typ]; exists {
+		return func(acc *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+			return f(acc, sq, a, acs)
+		}, true
+	}
+	extraDataFuncMap := map[string]actionTypeFunc{ // actions using the extraData
+		LOG:                logAction,
+		CALL_URL:           callUrl,
+		CALL_URL_ASYNC:     callUrlAsync,
+		MetaHTTPPost:       httpPostAction,
+		MetaHTTPPostAsync:  httpPostAsyncAction,
+		MetaAMQPPost:       amqpPostAction,
+		MetaFilePost:       filePostAction,
+		MetaDisableProfile: disableProfileAction,
+		MetaEnableProfile:  enableProfileAction,
+	}
+	f, exists := extraDataFuncMap[typ]
 	return f, exists
 }
 
-func logAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions) (err error) {
+func logAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) (err error) {
 	if ub != nil {
 		body, _ := json.Marshal(ub)
 		utils.Logger.Info(fmt.Sprintf("Threshold hit, Balance: %s", body))
@@ -135,6 +159,10 @@ func logAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions)
 		body, _ := json.Marshal(sq)
 		utils.Logger.Info(fmt.Sprintf("Threshold hit, CDRStatsQueue: %s", body))
 	}
+	if thHit, isThHit := extraData.(*ThresholdHit); isThHit {
+		body, _ := json.Marshal(thHit)
+		utils.Logger.Info(fmt.Sprintf("Threshold hit, ThresholdHit: %s", body))
+	}
 	return
 }
 
@@ -494,15 +522,9 @@ func genericReset(ub *Account) error {
 	return nil
 }
 
-func callUrl(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions) error {
-	var o interface{}
-	if ub != nil {
-		o = ub
-	}
-	if sq != nil {
-		o = sq
-	}
-	jsn, err := json.Marshal(o)
+// callUrl posts the account, the stats queue or the ThresholdHit, same as *http_post
+func callUrl(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
 	if err != nil {
 		return err
 	}
@@ -517,15 +539,8 @@ func callUrl(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions) er
 }
 
 // Does not block for posts, no error reports
-func callUrlAsync(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions) error {
-	var o interface{}
-	if ub != nil {
-		o = ub
-	}
-	if sq != nil {
-		o = sq
-	}
-	jsn, err := json.Marshal(o)
+func callUrlAsync(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
 	if err != nil {
 		return err
 	}
@@ -878,6 +893,134 @@ func publishAccount(acnt *Account, sq *CDRStatsQueueTriggered,
 	return nil
 }
 
+// postContent returns the JSON posted by the *_post and *call_url actions,
+// extraData (ie: ThresholdHit) has priority over the account and the stats queue
+func postContent(ub *Account, sq *CDRStatsQueueTriggered, extraData interface{}) ([]byte, error) {
+	var o interface{}
+	if ub != nil {
+		o = ub
+	}
+	if sq != nil {
+		o = sq
+	}
+	if extraData != nil {
+		o = extraData
+	}
+	return json.Marshal(o)
+}
+
+// httpPostAction posts the content to the address in ExtraParameters, writing it in failed_posts_dir on errors
+func httpPostAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
+	if err != nil {
+		return err
+	}
+	cfg := config.CgrConfig()
+	ffn := &utils.FallbackFileName{Module: fmt.Sprintf("%s>%s", utils.ActionsPoster, a.ActionType),
+		Transport: utils.MetaHTTPjson, Address: a.ExtraParameters,
+		RequestID: utils.GenUUID(), FileSuffix: utils.JSNSuffix}
+	_, err = utils.NewHTTPPoster(cfg.HttpSkipTlsVerify, cfg.ReplyTimeout).Post(a.ExtraParameters,
+		utils.CONTENT_JSON, jsn, cfg.PosterAttempts, path.Join(cfg.FailedPostsDir, ffn.AsString()))
+	return err
+}
+
+// httpPostAsyncAction does not block for posts, no error reports
+func httpPostAsyncAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
+	if err != nil {
+		return err
+	}
+	cfg := config.CgrConfig()
+	ffn := &utils.FallbackFileName{Module: fmt.Sprintf("%s>%s", utils.ActionsPoster, a.ActionType),
+		Transport: utils.MetaHTTPjson, Address: a.ExtraParameters,
+		RequestID: utils.GenUUID(), FileSuffix: utils.JSNSuffix}
+	go utils.NewHTTPPoster(cfg.HttpSkipTlsVerify, cfg.ReplyTimeout).Post(a.ExtraParameters,
+		utils.CONTENT_JSON, jsn, cfg.PosterAttempts, path.Join(cfg.FailedPostsDir, ffn.AsString()))
+	return nil
+}
+
+// amqpPostAction publishes the content to the AMQP queue defined by the URL in ExtraParameters
+func amqpPostAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
+	if err != nil {
+		return err
+	}
+	cfg := config.CgrConfig()
+	ffn := &utils.FallbackFileName{Module: fmt.Sprintf("%s>%s", utils.ActionsPoster, a.ActionType),
+		Transport: utils.MetaAMQPjsonMap, Address: a.ExtraParameters,
+		RequestID: utils.GenUUID(), FileSuffix: utils.JSNSuffix}
+	amqpPoster, err := utils.AMQPPostersCache.GetAMQPPoster(a.ExtraParameters,
+		cfg.PosterAttempts, cfg.FailedPostsDir)
+	if err != nil {
+		return err
+	}
+	chn, err := amqpPoster.Post(nil, utils.CONTENT_JSON, jsn, ffn.AsString())
+	if chn != nil {
+		chn.Close()
+	}
+	return err
+}
+
+// filePostAction writes the content as new file in the directory from ExtraParameters
+func filePostAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	jsn, err := postContent(ub, sq, extraData)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(a.ExtraParameters,
+		utils.ActionsPoster+"_"+utils.GenUUID()+utils.JSNSuffix), jsn, 0644)
+}
+
+// setProfileDisabled changes the Disabled flag of the profile in ExtraParameters,
+// defined as <*resources|*suppliers|*stats>:[Tenant:]ID, with Tenant defaulting to the one of the ThresholdHit
+func setProfileDisabled(a *Action, extraData interface{}, disabled bool) (err error) {
+	params := strings.Split(a.ExtraParameters, utils.InInFieldSep)
+	var tnt, id string
+	switch len(params) {
+	case 2:
+		tnt, id = config.CgrConfig().DefaultTenant, params[1]
+		if thHit, isThHit := extraData.(*ThresholdHit); isThHit {
+			tnt = thHit.Tenant
+		}
+	case 3:
+		tnt, id = params[1], params[2]
+	default:
+		return fmt.Errorf("invalid parameters for action %s: <%s>", a.ActionType, a.ExtraParameters)
+	}
+	switch params[0] {
+	case utils.MetaResources:
+		var rp *ResourceProfile
+		if rp, err = dm.GetResourceProfile(tnt, id, true, utils.NonTransactional); err != nil {
+			return
+		}
+		rp.Disabled = disabled
+		return dm.SetResourceProfile(rp, false)
+	case utils.MetaSuppliers:
+		var splPrfl *SupplierProfile
+		if splPrfl, err = dm.GetSupplierProfile(tnt, id, true, utils.NonTransactional); err != nil {
+			return
+		}
+		splPrfl.Disabled = disabled
+		return dm.SetSupplierProfile(splPrfl, false)
+	case utils.MetaStats:
+		var sqPrfl *StatQueueProfile
+		if sqPrfl, err = dm.GetStatQueueProfile(tnt, id, true, utils.NonTransactional); err != nil {
+			return
+		}
+		sqPrfl.Disabled = disabled
+		return dm.SetStatQueueProfile(sqPrfl, false)
+	}
+	return fmt.Errorf("unsupported profile type for action %s: <%s>", a.ActionType, params[0])
+}
+
+func disableProfileAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	return setProfileDisabled(a, extraData, true)
+}
+
+func enableProfileAction(ub *Account, sq *CDRStatsQueueTriggered, a *Action, acs Actions, extraData interface{}) error {
+	return setProfileDisabled(a, extraData, false)
+}
+
 // Structure to store actions according to weight
 type Actions []*Action
 
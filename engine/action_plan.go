@@ -41,6 +41,7 @@ type ActionTiming struct {
 	accountIDs   utils.StringMap // copy of action plans accounts
 	actionPlanID string          // the id of the belonging action plan (info only)
 	stCache      time.Time       // cached time of the next start
+	extraData    interface{}     // data passed to the actions, ie: the ThresholdHit
 }
 
 type Task struct {
@@ -331,7 +332,7 @@ func (at *ActionTiming) Execute(successActions, failedActions chan *Action) (err
 					transactionFailed = true
 					break
 				}
-				if err := actionFunction(acc, nil, a, aac); err != nil {
+				if err := actionFunction(acc, nil, a, aac, at.extraData); err != nil {
 					utils.Logger.Err(fmt.Sprintf("Error executing action %s: %v!", a.ActionType, err))
 					transactionFailed = true
 					if failedActions != nil {
@@ -370,7 +371,7 @@ func (at *ActionTiming) Execute(successActions, failedActions chan *Action) (err
 				}
 				break
 			}
-			if err := actionFunction(nil, nil, a, aac); err != nil {
+			if err := actionFunction(nil, nil, a, aac, at.extraData); err != nil {
 				utils.Logger.Err(fmt.Sprintf("Error executing accountless action %s: %v!", a.ActionType, err))
 				if failedActions != nil {
 					go func() { failedActions <- a }()
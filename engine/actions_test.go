@@ -20,6 +20,9 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
@@ -2730,6 +2733,77 @@ func TestCacheGetClonedActions(t *testing.T) {
 	}
 }
 
+func TestActionFilePostThresholdHit(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "TestActionFilePostThresholdHit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outDir)
+	thHit := &ThresholdHit{Tenant: "cgrates.org", ThresholdID: "THD_RES_1",
+		Hits: 3, Event: &utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+			Event: map[string]interface{}{utils.ResourceID: "RES_1"}}}
+	a := &Action{ActionType: MetaFilePost, ExtraParameters: outDir}
+	if err := filePostAction(nil, nil, a, nil, thHit); err != nil {
+		t.Fatal(err)
+	}
+	files, err := ioutil.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("expecting one file, received: %d", len(files))
+	}
+	content, err := ioutil.ReadFile(path.Join(outDir, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rcv ThresholdHit
+	if err := json.Unmarshal(content, &rcv); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(thHit, &rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(thHit), utils.ToJSON(rcv))
+	}
+}
+
+func TestActionDisableEnableProfile(t *testing.T) {
+	rp := &ResourceProfile{Tenant: "cgrates.org", ID: "RES_DISABLE",
+		UsageTTL: time.Duration(1 * time.Minute), Limit: 2}
+	if err := dm.SetResourceProfile(rp, false); err != nil {
+		t.Fatal(err)
+	}
+	thHit := &ThresholdHit{Tenant: "cgrates.org", ThresholdID: "THD_1"}
+	a := &Action{ActionType: MetaDisableProfile,
+		ExtraParameters: utils.MetaResources + ":RES_DISABLE"}
+	if err := disableProfileAction(nil, nil, a, nil, thHit); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetResourceProfile("cgrates.org", "RES_DISABLE",
+		false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !rcv.Disabled {
+		t.Errorf("expecting disabled profile: %s", utils.ToJSON(rcv))
+	}
+	a = &Action{ActionType: MetaEnableProfile,
+		ExtraParameters: utils.MetaResources + ":cgrates.org:RES_DISABLE"}
+	if err := enableProfileAction(nil, nil, a, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetResourceProfile("cgrates.org", "RES_DISABLE",
+		false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv.Disabled {
+		t.Errorf("expecting enabled profile: %s", utils.ToJSON(rcv))
+	}
+	a = &Action{ActionType: MetaEnableProfile, ExtraParameters: "RES_DISABLE"}
+	if err := enableProfileAction(nil, nil, a, nil, nil); err == nil {
+		t.Error("expecting error for invalid parameters")
+	}
+	a = &Action{ActionType: MetaEnableProfile,
+		ExtraParameters: utils.MetaThresholds + ":THD_1"}
+	if err := enableProfileAction(nil, nil, a, nil, nil); err == nil {
+		t.Error("expecting error for unsupported profile type")
+	}
+}
+
 /**************** Benchmarks ********************************/
 
 func BenchmarkUUID(b *testing.B) {
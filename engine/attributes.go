@@ -27,10 +27,13 @@ import (
 )
 
 func NewAttributeService(dm *DataManager, filterS *FilterS,
-	stringIndexedFields, prefixIndexedFields *[]string) (*AttributeService, error) {
+	stringIndexedFields, prefixIndexedFields, suffixIndexedFields *[]string,
+	processRuns int) (*AttributeService, error) {
 	return &AttributeService{dm: dm, filterS: filterS,
 		stringIndexedFields: stringIndexedFields,
-		prefixIndexedFields: prefixIndexedFields}, nil
+		prefixIndexedFields: prefixIndexedFields,
+		suffixIndexedFields: suffixIndexedFields,
+		processRuns:         processRuns}, nil
 }
 
 type AttributeService struct {
@@ -38,6 +41,8 @@ type AttributeService struct {
 	filterS             *FilterS
 	stringIndexedFields *[]string
 	prefixIndexedFields *[]string
+	suffixIndexedFields *[]string
+	processRuns         int // default number of profiles applied on one event
 }
 
 // ListenAndServe will initialize the service
@@ -64,13 +69,13 @@ func (alS *AttributeService) matchingAttributeProfilesForEvent(ev *utils.CGREven
 	}
 	attrIdxKey = utils.ConcatenatedKey(ev.Tenant, contextVal)
 	matchingAPs := make(map[string]*AttributeProfile)
-	aPrflIDs, err := matchingItemIDsForEvent(ev.Event, alS.stringIndexedFields, alS.prefixIndexedFields,
+	aPrflIDs, err := matchingItemIDsForEvent(ev.Event, alS.stringIndexedFields, alS.prefixIndexedFields, alS.suffixIndexedFields,
 		alS.dm, utils.CacheAttributeFilterIndexes, attrIdxKey)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			return nil, err
 		}
-		if aPrflIDs, err = matchingItemIDsForEvent(ev.Event, alS.stringIndexedFields, alS.prefixIndexedFields,
+		if aPrflIDs, err = matchingItemIDsForEvent(ev.Event, alS.stringIndexedFields, alS.prefixIndexedFields, alS.suffixIndexedFields,
 			alS.dm, utils.CacheAttributeFilterIndexes, utils.ConcatenatedKey(ev.Tenant, utils.META_ANY)); err != nil {
 			return nil, err
 		}
@@ -109,14 +114,25 @@ func (alS *AttributeService) matchingAttributeProfilesForEvent(ev *utils.CGREven
 	return
 }
 
-func (alS *AttributeService) attributeProfileForEvent(ev *utils.CGREvent) (attrPrfl *AttributeProfile, err error) {
+// attributeProfileForEvent returns the matching profile with the highest weight, ignoring the ones in processedPrfls
+func (alS *AttributeService) attributeProfileForEvent(ev *utils.CGREvent,
+	processedPrfls utils.StringMap) (attrPrfl *AttributeProfile, err error) {
 	var attrPrfls AttributeProfiles
 	if attrPrfls, err = alS.matchingAttributeProfilesForEvent(ev); err != nil {
 		return
-	} else if len(attrPrfls) == 0 {
-		return nil, utils.ErrNotFound
 	}
-	return attrPrfls[0], nil
+	for _, attrPrfl = range attrPrfls {
+		if !processedPrfls.HasKey(attrPrfl.ID) {
+			return
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+// AttrArgsProcessEvent is the event processed by AttributeS
+type AttrArgsProcessEvent struct {
+	ProcessRuns *int // number of profiles applied on the event, overwrites the process_runs from config
+	utils.CGREvent
 }
 
 // AttrSFldNameValue is a helper struct for AttrSDigest deserialization
@@ -125,10 +141,18 @@ type AttrSFieldNameValue struct {
 	FieldValue string
 }
 
-type AttrSProcessEventReply struct {
+// AttrSProcessRun details the changes done by one profile when processing an event
+type AttrSProcessRun struct {
 	MatchedProfile string
 	AlteredFields  []string
-	CGREvent       *utils.CGREvent
+}
+
+type AttrSProcessEventReply struct {
+	MatchedProfile  string             // first profile applied on the event
+	AlteredFields   []string           // fields altered by all the runs
+	MatchedProfiles []string           // all the profiles applied on the event, in process order
+	ProcessRuns     []*AttrSProcessRun // changes done by each profile
+	CGREvent        *utils.CGREvent
 }
 
 // Digest returns serialized version of alteredFields in AttrSProcessEventReply
@@ -148,8 +172,10 @@ func (attrReply *AttrSProcessEventReply) Digest() (rplyDigest string) {
 }
 
 // processEvent will match event with attribute profile and do the necessary replacements
-func (alS *AttributeService) processEvent(ev *utils.CGREvent) (rply *AttrSProcessEventReply, err error) {
-	attrPrf, err := alS.attributeProfileForEvent(ev)
+// the profiles in processedPrfls are not considered for matching
+func (alS *AttributeService) processEvent(ev *utils.CGREvent,
+	processedPrfls utils.StringMap) (rply *AttrSProcessEventReply, err error) {
+	attrPrf, err := alS.attributeProfileForEvent(ev, processedPrfls)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +186,14 @@ func (alS *AttributeService) processEvent(ev *utils.CGREvent) (rply *AttrSProces
 			anyInitial, hasAny := initialMp[utils.ANY]
 			if hasAny && anyInitial.Append &&
 				initialMp[utils.ANY].Substitute != interface{}(utils.META_NONE) {
-				rply.CGREvent.Event[fldName] = anyInitial.Substitute
+				substitute, err := anyInitial.SubstituteValue(ev.Event, alS.filterS.timezone())
+				if err != nil {
+					if err == utils.ErrFilterNotPassingNoCaps {
+						continue // template filters not matching, field not altered
+					}
+					return nil, err
+				}
+				rply.CGREvent.Event[fldName] = substitute
 			}
 			rply.AlteredFields = append(rply.AlteredFields, fldName)
 			continue
@@ -173,7 +206,14 @@ func (alS *AttributeService) processEvent(ev *utils.CGREvent) (rply *AttrSProces
 			if attrVal.Substitute == interface{}(utils.META_NONE) {
 				delete(rply.CGREvent.Event, fldName)
 			} else {
-				rply.CGREvent.Event[fldName] = attrVal.Substitute
+				substitute, err := attrVal.SubstituteValue(ev.Event, alS.filterS.timezone())
+				if err != nil {
+					if err == utils.ErrFilterNotPassingNoCaps {
+						continue
+					}
+					return nil, err
+				}
+				rply.CGREvent.Event[fldName] = substitute
 			}
 			rply.AlteredFields = append(rply.AlteredFields, fldName)
 		}
@@ -191,7 +231,7 @@ func (alS *AttributeService) processEvent(ev *utils.CGREvent) (rply *AttrSProces
 
 func (alS *AttributeService) V1GetAttributeForEvent(ev *utils.CGREvent,
 	attrPrfl *AttributeProfile) (err error) {
-	attrPrf, err := alS.attributeProfileForEvent(ev)
+	attrPrf, err := alS.attributeProfileForEvent(ev, nil)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
@@ -202,17 +242,49 @@ func (alS *AttributeService) V1GetAttributeForEvent(ev *utils.CGREvent,
 	return
 }
 
-func (alS *AttributeService) V1ProcessEvent(ev *utils.CGREvent,
+// V1ProcessEvent applies the matching profiles on the event, one per run in weight order,
+// the filters being checked against the event altered by the previous runs
+func (alS *AttributeService) V1ProcessEvent(args *AttrArgsProcessEvent,
 	reply *AttrSProcessEventReply) (err error) {
-	if ev.Event == nil {
+	if args.Event == nil {
 		return utils.NewErrMandatoryIeMissing("Event")
 	}
-	evReply, err := alS.processEvent(ev)
-	if err != nil {
-		if err != utils.ErrNotFound {
-			err = utils.NewErrServerError(err)
+	processRuns := alS.processRuns
+	if args.ProcessRuns != nil {
+		processRuns = *args.ProcessRuns
+	}
+	if processRuns < 1 {
+		processRuns = 1
+	}
+	ev := &args.CGREvent
+	var evReply *AttrSProcessEventReply
+	processedPrfls := make(utils.StringMap)
+	for i := 0; i < processRuns; i++ {
+		var runReply *AttrSProcessEventReply
+		if runReply, err = alS.processEvent(ev, processedPrfls); err != nil {
+			if err == utils.ErrNotFound && i != 0 { // no more profiles matching
+				err = nil
+				break
+			}
+			if err != utils.ErrNotFound {
+				err = utils.NewErrServerError(err)
+			}
+			return err
 		}
-		return err
+		processedPrfls[runReply.MatchedProfile] = true
+		if evReply == nil {
+			evReply = &AttrSProcessEventReply{MatchedProfile: runReply.MatchedProfile}
+		}
+		evReply.MatchedProfiles = append(evReply.MatchedProfiles, runReply.MatchedProfile)
+		evReply.ProcessRuns = append(evReply.ProcessRuns, &AttrSProcessRun{
+			MatchedProfile: runReply.MatchedProfile, AlteredFields: runReply.AlteredFields})
+		for _, fldName := range runReply.AlteredFields {
+			if !utils.IsSliceMember(evReply.AlteredFields, fldName) {
+				evReply.AlteredFields = append(evReply.AlteredFields, fldName)
+			}
+		}
+		evReply.CGREvent = runReply.CGREvent
+		ev = runReply.CGREvent
 	}
 	*reply = *evReply
 	return
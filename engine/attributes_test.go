@@ -289,28 +289,28 @@ func TestAttributeMatchingAttributeProfilesForEvent(t *testing.T) {
 }
 
 func TestAttributeProfileForEvent(t *testing.T) {
-	atrp, err := srv.attributeProfileForEvent(sev)
+	atrp, err := srv.attributeProfileForEvent(sev, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
 	if !reflect.DeepEqual(atrPs[0], atrp) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(atrPs[0]), utils.ToJSON(atrp))
 	}
-	atrp, err = srv.attributeProfileForEvent(sev2)
+	atrp, err = srv.attributeProfileForEvent(sev2, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
 	if !reflect.DeepEqual(atrPs[1], atrp) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(atrPs[1]), utils.ToJSON(atrp))
 	}
-	atrp, err = srv.attributeProfileForEvent(sev3)
+	atrp, err = srv.attributeProfileForEvent(sev3, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
 	if !reflect.DeepEqual(atrPs[2], atrp) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(atrPs[2]), utils.ToJSON(atrp))
 	}
-	atrp, err = srv.attributeProfileForEvent(sev4)
+	atrp, err = srv.attributeProfileForEvent(sev4, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -325,7 +325,7 @@ func TestAttributeProcessEvent(t *testing.T) {
 		AlteredFields:  []string{"FL1"},
 		CGREvent:       sev,
 	}
-	atrp, err := srv.processEvent(sev)
+	atrp, err := srv.processEvent(sev, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -341,7 +341,7 @@ func TestAttributeProcessEvent(t *testing.T) {
 		AlteredFields:  []string{"FL1"},
 		CGREvent:       sev2,
 	}
-	atrp, err = srv.processEvent(sev2)
+	atrp, err = srv.processEvent(sev2, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -357,7 +357,7 @@ func TestAttributeProcessEvent(t *testing.T) {
 		AlteredFields:  []string{"FL1"},
 		CGREvent:       sev3,
 	}
-	atrp, err = srv.processEvent(sev3)
+	atrp, err = srv.processEvent(sev3, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -373,7 +373,7 @@ func TestAttributeProcessEvent(t *testing.T) {
 		AlteredFields:  []string{"FL1"},
 		CGREvent:       sev4,
 	}
-	atrp, err = srv.processEvent(sev4)
+	atrp, err = srv.processEvent(sev4, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -566,3 +566,200 @@ func TestAttributeIndexer(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestAttributeV1ProcessEventWithProcessRuns(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmAtrRuns := NewDataManager(data)
+	attrS := &AttributeService{dm: dmAtrRuns,
+		filterS: &FilterS{dm: dmAtrRuns}, processRuns: 1}
+	attrPrfs := []*AttributeProfile{
+		&AttributeProfile{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR_ACCOUNT",
+			Contexts:  []string{utils.MetaSessionS},
+			FilterIDs: []string{"*string:Account:1001"},
+			Attributes: []*Attribute{
+				&Attribute{
+					FieldName:  utils.Subject,
+					Initial:    utils.ANY,
+					Substitute: "1001",
+					Append:     true,
+				},
+			},
+			Weight: 20,
+		},
+		&AttributeProfile{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR_SUBJECT",
+			Contexts:  []string{utils.MetaSessionS},
+			FilterIDs: []string{"*string:Subject:1001"},
+			Attributes: []*Attribute{
+				&Attribute{
+					FieldName:  utils.Category,
+					Initial:    utils.ANY,
+					Substitute: "premium",
+					Append:     true,
+				},
+			},
+			Weight: 10,
+		},
+	}
+	for _, attrPrf := range attrPrfs {
+		if err := dmAtrRuns.SetAttributeProfile(attrPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := &AttrArgsProcessEvent{
+		CGREvent: utils.CGREvent{
+			Tenant:  "cgrates.org",
+			ID:      "TestAttributeV1ProcessEventWithProcessRuns",
+			Context: utils.StringPointer(utils.MetaSessionS),
+			Event: map[string]interface{}{
+				utils.Account: "1001",
+			},
+		},
+	}
+	var rply AttrSProcessEventReply
+	if err := attrS.V1ProcessEvent(args, &rply); err != nil {
+		t.Fatal(err)
+	}
+	eRply := AttrSProcessEventReply{
+		MatchedProfile:  "ATTR_ACCOUNT",
+		AlteredFields:   []string{utils.Subject},
+		MatchedProfiles: []string{"ATTR_ACCOUNT"},
+		ProcessRuns: []*AttrSProcessRun{
+			&AttrSProcessRun{MatchedProfile: "ATTR_ACCOUNT",
+				AlteredFields: []string{utils.Subject}}},
+		CGREvent: &utils.CGREvent{
+			Tenant:  "cgrates.org",
+			ID:      "TestAttributeV1ProcessEventWithProcessRuns",
+			Context: utils.StringPointer(utils.MetaSessionS),
+			Event: map[string]interface{}{
+				utils.Account: "1001",
+				utils.Subject: "1001",
+			},
+		},
+	}
+	if !reflect.DeepEqual(eRply, rply) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eRply), utils.ToJSON(rply))
+	}
+	// second profile matches only on the event altered by the first one
+	args.ProcessRuns = utils.IntPointer(5)
+	rply = AttrSProcessEventReply{}
+	if err := attrS.V1ProcessEvent(args, &rply); err != nil {
+		t.Fatal(err)
+	}
+	eRply.AlteredFields = []string{utils.Subject, utils.Category}
+	eRply.MatchedProfiles = []string{"ATTR_ACCOUNT", "ATTR_SUBJECT"}
+	eRply.ProcessRuns = append(eRply.ProcessRuns,
+		&AttrSProcessRun{MatchedProfile: "ATTR_SUBJECT",
+			AlteredFields: []string{utils.Category}})
+	eRply.CGREvent.Event[utils.Category] = "premium"
+	if !reflect.DeepEqual(eRply, rply) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eRply), utils.ToJSON(rply))
+	}
+	args.Event = map[string]interface{}{utils.Account: "1002"}
+	if err := attrS.V1ProcessEvent(args, &rply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestAttributeSplitRSRRules(t *testing.T) {
+	if rules := splitRSRRules("Usage{*duration_seconds;*round:2};^10;Cost{*multiply:2;*round:1}",
+		utils.INFIELD_SEP); !reflect.DeepEqual([]string{"Usage{*duration_seconds;*round:2}",
+		"^10", "Cost{*multiply:2;*round:1}"}, rules) {
+		t.Errorf("received: %+v", rules)
+	}
+	if rules := splitRSRRules(`~Account:s/^(\d{3})//;Usage{*duration_seconds;*round:2}`,
+		utils.INFIELD_SEP); !reflect.DeepEqual([]string{`~Account:s/^(\d{3})//`,
+		"Usage{*duration_seconds;*round:2}"}, rules) {
+		t.Errorf("received: %+v", rules)
+	}
+}
+
+func TestAttributeSubstituteValue(t *testing.T) {
+	ev := map[string]interface{}{
+		"CountryCode":     "49",
+		utils.Destination: "0151123",
+		utils.Usage:       time.Duration(90 * time.Second),
+		utils.AnswerTime:  time.Date(2018, 7, 14, 14, 25, 0, 0, time.UTC),
+		"DisconnectTime":  time.Date(2018, 7, 14, 14, 27, 0, 0, time.UTC),
+	}
+	attr := &Attribute{FieldName: utils.Destination, Initial: utils.ANY,
+		Substitute: "1002"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != "1002" {
+		t.Errorf("received: %+v", subst)
+	}
+	attr = &Attribute{FieldName: utils.Destination, Initial: utils.ANY,
+		Type: utils.META_COMPOSED, Substitute: `^+;CountryCode;~Destination:s/^0(\d+)/${1}/`}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != "+49151123" {
+		t.Errorf("received: %+v", subst)
+	}
+	attr = &Attribute{FieldName: "TotalSeconds", Initial: utils.ANY,
+		Type: utils.MetaSum, Substitute: "Usage{*duration_seconds};^10"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != 100.0 {
+		t.Errorf("received: %+v", subst)
+	}
+	attr = &Attribute{FieldName: "TotalMinutes", Initial: utils.ANY,
+		Type: utils.MetaSum, Substitute: "Usage{*duration_seconds;*divide:60};^0.25"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != 1.75 {
+		t.Errorf("received: %+v", subst)
+	}
+	attr = &Attribute{FieldName: utils.Usage, Initial: utils.ANY,
+		Type: utils.MetaUsageDifference, Substitute: "DisconnectTime;AnswerTime"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != time.Duration(2*time.Minute) {
+		t.Errorf("received: %+v", subst)
+	}
+	ev["SetupTime"] = "2018-07-14 14:25:00" // no location, parsed in the timezone received
+	attr = &Attribute{FieldName: utils.Usage, Initial: utils.ANY,
+		Type: utils.MetaUsageDifference, Substitute: "DisconnectTime;SetupTime"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if subst, err := attr.SubstituteValue(ev, "UTC"); err != nil {
+		t.Error(err)
+	} else if subst != time.Duration(2*time.Minute) {
+		t.Errorf("received: %+v", subst)
+	} else if subst, err := attr.SubstituteValue(ev, "Europe/Berlin"); err != nil {
+		t.Error(err)
+	} else if subst != time.Duration(2*time.Hour+2*time.Minute) {
+		t.Errorf("received: %+v", subst)
+	}
+	attr = &Attribute{FieldName: utils.Destination, Initial: utils.ANY,
+		Type: utils.META_COMPOSED, Substitute: "^+;Prefix;Destination"}
+	if err := attr.Compile(); err != nil {
+		t.Error(err)
+	} else if _, err := attr.SubstituteValue(ev, "UTC"); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing("Prefix").Error() {
+		t.Errorf("received error: %v", err)
+	}
+	attr = &Attribute{FieldName: utils.Usage, Initial: utils.ANY,
+		Type: utils.MetaUsageDifference, Substitute: "DisconnectTime"}
+	if err := attr.Compile(); err == nil {
+		t.Error("expecting error for one field *usage_difference")
+	}
+	attr = &Attribute{FieldName: utils.Usage, Initial: utils.ANY,
+		Type: "*unsupported", Substitute: "1002"}
+	if err := attr.Compile(); err == nil {
+		t.Error("expecting error for unsupported type")
+	}
+}
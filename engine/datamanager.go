@@ -148,7 +148,8 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		utils.ThresholdProfilePrefix,
 		utils.FilterPrefix,
 		utils.SupplierProfilePrefix,
-		utils.AttributeProfilePrefix}, prfx) {
+		utils.AttributeProfilePrefix,
+		utils.DispatcherProfilePrefix}, prfx) {
 		return utils.NewCGRError(utils.DataManager,
 			utils.MandatoryIEMissingCaps,
 			utils.UnsupportedCachePrefix,
@@ -240,6 +241,9 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.AttributeProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetAttributeProfile(tntID.Tenant, tntID.ID, true, utils.NonTransactional)
+		case utils.DispatcherProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetDispatcherProfile(tntID.Tenant, tntID.ID, true, utils.NonTransactional)
 		}
 		if err != nil {
 			return utils.NewCGRError(utils.DataManager,
@@ -332,12 +336,42 @@ func (dm *DataManager) GetFilter(tenant, id string,
 }
 
 func (dm *DataManager) SetFilter(fltr *Filter) (err error) {
+	if err = dm.checkFilterCycle(fltr, []string{fltr.ID}); err != nil {
+		return
+	}
 	if err = dm.DataDB().SetFilterDrv(fltr); err != nil {
 		return
 	}
 	return dm.CacheDataFromDB(utils.FilterPrefix, []string{fltr.TenantID()}, true)
 }
 
+// checkFilterCycle follows the filter groups (*or, *and, *not) of fltr,
+// returning error if one of them leads back to a filter already in path
+func (dm *DataManager) checkFilterCycle(fltr *Filter, path []string) (err error) {
+	for _, rule := range fltr.Rules {
+		if !isFilterGroup(rule.Type) {
+			continue
+		}
+		for _, refID := range rule.Values {
+			refPath := append(path[:len(path):len(path)], refID)
+			if utils.IsSliceMember(path, refID) {
+				return utils.NewErrFilterCycle(refPath)
+			}
+			refFltr, err := dm.GetFilter(fltr.Tenant, refID, false, utils.NonTransactional)
+			if err != nil {
+				if err == utils.ErrNotFound { // not yet defined, the cycle will be detected when setting it
+					continue
+				}
+				return err
+			}
+			if err = dm.checkFilterCycle(refFltr, refPath); err != nil {
+				return err
+			}
+		}
+	}
+	return
+}
+
 func (dm *DataManager) RemoveFilter(tenant, id, transactionID string) (err error) {
 	if err = dm.DataDB().RemoveFilterDrv(tenant, id); err != nil {
 		return
@@ -463,7 +497,7 @@ func (dm *DataManager) SetThresholdProfile(th *ThresholdProfile, withIndex bool)
 			for _, flt := range fltr.Rules {
 				var fldType, fldName string
 				var fldVals []string
-				if utils.IsSliceMember([]string{MetaString, MetaPrefix}, flt.Type) {
+				if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
 					fldType, fldName = flt.Type, flt.FieldName
 					fldVals = flt.Values
 				} else {
@@ -572,7 +606,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 			for _, flt := range fltr.Rules {
 				var fldType, fldName string
 				var fldVals []string
-				if utils.IsSliceMember([]string{MetaString, MetaPrefix}, flt.Type) {
+				if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
 					fldType, fldName = flt.Type, flt.FieldName
 					fldVals = flt.Values
 				} else {
@@ -765,7 +799,7 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 			for _, flt := range fltr.Rules {
 				var fldType, fldName string
 				var fldVals []string
-				if utils.IsSliceMember([]string{MetaString, MetaPrefix}, flt.Type) {
+				if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
 					fldType, fldName = flt.Type, flt.FieldName
 					fldVals = flt.Values
 				} else {
@@ -1255,7 +1289,7 @@ func (dm *DataManager) SetSupplierProfile(supp *SupplierProfile, withIndex bool)
 			for _, flt := range fltr.Rules {
 				var fldType, fldName string
 				var fldVals []string
-				if utils.IsSliceMember([]string{MetaString, MetaPrefix}, flt.Type) {
+				if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
 					fldType, fldName = flt.Type, flt.FieldName
 					fldVals = flt.Values
 				} else {
@@ -1309,15 +1343,8 @@ func (dm *DataManager) GetAttributeProfile(tenant, id string, skipCache bool,
 		}
 		return nil, err
 	}
-	alsPrf.attributes = make(map[string]map[interface{}]*Attribute)
-	for _, attr := range alsPrf.Attributes {
-		alsPrf.attributes[attr.FieldName] = make(map[interface{}]*Attribute)
-		alsPrf.attributes[attr.FieldName][attr.Initial] = &Attribute{
-			FieldName:  attr.FieldName,
-			Initial:    attr.Initial,
-			Substitute: attr.Substitute,
-			Append:     attr.Append,
-		}
+	if err = alsPrf.compile(); err != nil {
+		return nil, err
 	}
 	Cache.Set(utils.CacheAttributeProfiles, tntID, alsPrf, nil,
 		cacheCommit(transactionID), transactionID)
@@ -1325,6 +1352,9 @@ func (dm *DataManager) GetAttributeProfile(tenant, id string, skipCache bool,
 }
 
 func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool) (err error) {
+	if _, err = ap.compiledAttributes(); err != nil {
+		return
+	}
 	oldAP, err := dm.GetAttributeProfile(ap.Tenant, ap.ID, true, utils.NonTransactional)
 	if err != nil && err != utils.ErrNotFound {
 		return err
@@ -1392,7 +1422,7 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 				for _, flt := range fltr.Rules {
 					var fldType, fldName string
 					var fldVals []string
-					if utils.IsSliceMember([]string{MetaString, MetaPrefix}, flt.Type) {
+					if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
 						fldType, fldName = flt.Type, flt.FieldName
 						fldVals = flt.Values
 					} else {
@@ -1433,3 +1463,152 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, contexts []stri
 	}
 	return
 }
+
+func (dm *DataManager) GetDispatcherProfile(tenant, id string, skipCache bool,
+	transactionID string) (dpp *DispatcherProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if !skipCache {
+		if x, ok := Cache.Get(utils.CacheDispatcherProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*DispatcherProfile), nil
+		}
+	}
+	dpp, err = dm.dataDB.GetDispatcherProfileDrv(tenant, id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			Cache.Set(utils.CacheDispatcherProfiles, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID)
+		}
+		return nil, err
+	}
+	dpp.Hosts.Sort()
+	Cache.Set(utils.CacheDispatcherProfiles, tntID, dpp, nil,
+		cacheCommit(transactionID), transactionID)
+	return
+}
+
+func (dm *DataManager) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bool) (err error) {
+	oldDpp, err := dm.GetDispatcherProfile(dpp.Tenant, dpp.ID, true, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().SetDispatcherProfileDrv(dpp); err != nil {
+		return err
+	}
+	if err = dm.CacheDataFromDB(utils.DispatcherProfilePrefix, []string{dpp.TenantID()}, true); err != nil {
+		return
+	}
+	if withIndex {
+		if oldDpp != nil {
+			for _, subsys := range oldDpp.Subsystems {
+				var needsRemove bool
+				if !utils.IsSliceMember(dpp.Subsystems, subsys) {
+					needsRemove = true
+				} else {
+					for _, fltrID := range oldDpp.FilterIDs {
+						if !utils.IsSliceMember(dpp.FilterIDs, fltrID) {
+							needsRemove = true
+						}
+					}
+				}
+				if needsRemove {
+					if err = NewFilterIndexer(dm, utils.DispatcherProfilePrefix,
+						utils.ConcatenatedKey(dpp.Tenant, subsys)).RemoveItemFromIndex(dpp.ID); err != nil {
+						return
+					}
+				}
+			}
+		}
+		for _, subsys := range dpp.Subsystems {
+			indexer := NewFilterIndexer(dm, utils.DispatcherProfilePrefix, utils.ConcatenatedKey(dpp.Tenant, subsys))
+			//Verify matching Filters for every FilterID from DispatcherProfile
+			fltrIDs := make([]string, len(dpp.FilterIDs))
+			for i, fltrID := range dpp.FilterIDs {
+				fltrIDs[i] = fltrID
+			}
+			if len(fltrIDs) == 0 {
+				fltrIDs = []string{utils.META_NONE}
+			}
+			for _, fltrID := range fltrIDs {
+				var fltr *Filter
+				if fltrID == utils.META_NONE {
+					fltr = &Filter{
+						Tenant: dpp.Tenant,
+						ID:     dpp.ID,
+						Rules: []*FilterRule{
+							&FilterRule{
+								Type:      utils.MetaDefault,
+								FieldName: utils.META_ANY,
+								Values:    []string{utils.META_ANY},
+							},
+						},
+					}
+				} else if fltr, err = dm.GetFilter(dpp.Tenant, fltrID,
+					false, utils.NonTransactional); err != nil {
+					if err == utils.ErrNotFound {
+						err = fmt.Errorf("broken reference to filter: %+v for DispatcherProfile: %+v",
+							fltrID, dpp)
+					}
+					return
+				}
+				for _, flt := range fltr.Rules {
+					var fldType, fldName string
+					var fldVals []string
+					if utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix}, flt.Type) {
+						fldType, fldName = flt.Type, flt.FieldName
+						fldVals = flt.Values
+					} else {
+						fldType, fldName = utils.MetaDefault, utils.META_ANY
+						fldVals = []string{utils.META_ANY}
+					}
+					for _, fldVal := range fldVals {
+						if err = indexer.loadFldNameFldValIndex(fldType,
+							fldName, fldVal); err != nil && err != utils.ErrNotFound {
+							return err
+						}
+					}
+				}
+				indexer.IndexTPFilter(FilterToTPFilter(fltr), dpp.ID)
+			}
+			if err = indexer.StoreIndexes(true, utils.NonTransactional); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (dm *DataManager) RemoveDispatcherProfile(tenant, id string, subsystems []string,
+	transactionID string, withIndex bool) (err error) {
+	if err = dm.DataDB().RemoveDispatcherProfileDrv(tenant, id); err != nil {
+		return
+	}
+	Cache.Remove(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(transactionID), transactionID)
+	if withIndex {
+		for _, subsys := range subsystems {
+			if err = NewFilterIndexer(dm, utils.DispatcherProfilePrefix,
+				utils.ConcatenatedKey(tenant, subsys)).RemoveItemFromIndex(id); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// GetSessions returns the snapshot of an active session, one StoredSession per run
+func (dm *DataManager) GetSessions(cgrID string) (ss []*StoredSession, err error) {
+	return dm.DataDB().GetSessionsDrv(cgrID)
+}
+
+// SetSessions snapshots the runs of an active session
+func (dm *DataManager) SetSessions(cgrID string, ss []*StoredSession) (err error) {
+	return dm.DataDB().SetSessionsDrv(cgrID, ss)
+}
+
+// RemoveSessions removes the snapshot of a session which is not active anymore
+func (dm *DataManager) RemoveSessions(cgrID string) (err error) {
+	return dm.DataDB().RemoveSessionsDrv(cgrID)
+}
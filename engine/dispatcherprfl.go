@@ -0,0 +1,129 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// DispatcherHost is one of the hosts a DispatcherProfile routes to
+type DispatcherHost struct {
+	ID     string  // references a connection defined in dispatcher hosts configuration
+	Weight float64 // used for ordering and by the *weight strategy
+	Params map[string]interface{}
+}
+
+// DispatcherHosts is a sortable list of DispatcherHost
+type DispatcherHosts []*DispatcherHost
+
+// Sort is part of sort interface, sort based on Weight
+func (dHs DispatcherHosts) Sort() {
+	sort.SliceStable(dHs, func(i, j int) bool { return dHs[i].Weight > dHs[j].Weight })
+}
+
+// Clone returns a shallow copy of the list so it can be reordered without affecting the profile
+func (dHs DispatcherHosts) Clone() (cln DispatcherHosts) {
+	cln = make(DispatcherHosts, len(dHs))
+	copy(cln, dHs)
+	return
+}
+
+// HostIDs returns the IDs of the hosts, keeping their order
+func (dHs DispatcherHosts) HostIDs() (hostIDs []string) {
+	hostIDs = make([]string, len(dHs))
+	for i, dH := range dHs {
+		hostIDs[i] = dH.ID
+	}
+	return
+}
+
+// DispatcherProfile is the config for one routing rule of DispatcherS
+type DispatcherProfile struct {
+	Tenant             string
+	ID                 string
+	Subsystems         []string // bind this DispatcherProfile to subsystems, eg: *attributes, *sessions
+	FilterIDs          []string
+	ActivationInterval *utils.ActivationInterval // Activation interval
+	Strategy           string                    // <*weight|*round_robin|*hash|*load>
+	StrategyParams     map[string]interface{}    // ie for *hash: *hash_field
+	Weight             float64                   // used for profile sorting on match
+	Hosts              DispatcherHosts           // dispatch to these hosts
+}
+
+func (dP *DispatcherProfile) TenantID() string {
+	return utils.ConcatenatedKey(dP.Tenant, dP.ID)
+}
+
+// DispatcherProfiles is a sortable list of Dispatcher profiles
+type DispatcherProfiles []*DispatcherProfile
+
+// Sort is part of sort interface, sort based on Weight
+func (dps DispatcherProfiles) Sort() {
+	sort.Slice(dps, func(i, j int) bool { return dps[i].Weight > dps[j].Weight })
+}
+
+// MatchingDispatcherProfilesForEvent returns ordered list of DispatcherProfiles
+// for subsystem which are matching the event and are active by the time of the call
+// the profiles for all subsystems (*any) are used when none of the subsystem ones is matching
+func MatchingDispatcherProfilesForEvent(dm *DataManager, filterS *FilterS,
+	ev *utils.CGREvent, subsys string) (dPrfls DispatcherProfiles, err error) {
+	if dPrfls, err = matchingDispatcherProfiles(dm, filterS, ev, subsys); err != utils.ErrNotFound ||
+		subsys == utils.META_ANY {
+		return
+	}
+	return matchingDispatcherProfiles(dm, filterS, ev, utils.META_ANY)
+}
+
+// matchingDispatcherProfiles returns ordered list of DispatcherProfiles indexed for subsys,
+// matching the event and active by the time of the call
+func matchingDispatcherProfiles(dm *DataManager, filterS *FilterS,
+	ev *utils.CGREvent, subsys string) (dPrfls DispatcherProfiles, err error) {
+	dPrflIDs, err := matchingItemIDsForEvent(ev.Event, nil, nil, nil,
+		dm, utils.CacheDispatcherFilterIndexes, utils.ConcatenatedKey(ev.Tenant, subsys))
+	if err != nil {
+		return nil, err
+	}
+	for dPrflID := range dPrflIDs {
+		dPrfl, err := dm.GetDispatcherProfile(ev.Tenant, dPrflID, false, utils.NonTransactional)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if dPrfl.ActivationInterval != nil && ev.Time != nil &&
+			!dPrfl.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
+			continue
+		}
+		if pass, err := filterS.Pass(ev.Tenant, dPrfl.FilterIDs,
+			NavigableMap(ev.Event)); err != nil {
+			return nil, err
+		} else if !pass {
+			continue
+		}
+		dPrfls = append(dPrfls, dPrfl)
+	}
+	if len(dPrfls) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	dPrfls.Sort()
+	return
+}
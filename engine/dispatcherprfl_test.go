@@ -0,0 +1,131 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDispatcherHostsSort(t *testing.T) {
+	hosts := DispatcherHosts{
+		&DispatcherHost{ID: "HOST1", Weight: 10},
+		&DispatcherHost{ID: "HOST2", Weight: 30},
+		&DispatcherHost{ID: "HOST3", Weight: 20},
+	}
+	cln := hosts.Clone()
+	cln.Sort()
+	if eIDs := []string{"HOST2", "HOST3", "HOST1"}; !reflect.DeepEqual(eIDs, cln.HostIDs()) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, cln.HostIDs())
+	}
+	if eIDs := []string{"HOST1", "HOST2", "HOST3"}; !reflect.DeepEqual(eIDs, hosts.HostIDs()) {
+		t.Errorf("original hosts modified: %+v", hosts.HostIDs())
+	}
+}
+
+func TestMatchingDispatcherProfilesForEvent(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmDsp := NewDataManager(data)
+	fltrS := &FilterS{dm: dmDsp}
+	rule, err := NewFilterRule(MetaString, utils.Account, []string{"1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dmDsp.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_DSP_1001",
+		Rules: []*FilterRule{rule}}); err != nil {
+		t.Fatal(err)
+	}
+	dpps := []*DispatcherProfile{
+		&DispatcherProfile{
+			Tenant:     "cgrates.org",
+			ID:         "DSP_1001",
+			Subsystems: []string{utils.MetaAttributes},
+			FilterIDs:  []string{"FLTR_DSP_1001"},
+			Strategy:   utils.MetaWeight,
+			Weight:     20,
+			Hosts:      DispatcherHosts{&DispatcherHost{ID: "HOST1", Weight: 10}},
+		},
+		&DispatcherProfile{
+			Tenant:     "cgrates.org",
+			ID:         "DSP_DEFAULT",
+			Subsystems: []string{utils.MetaAttributes},
+			Strategy:   utils.MetaRoundRobin,
+			Weight:     10,
+			Hosts: DispatcherHosts{&DispatcherHost{ID: "HOST1", Weight: 10},
+				&DispatcherHost{ID: "HOST2", Weight: 20}},
+		},
+	}
+	for _, dpp := range dpps {
+		if err = dmDsp.SetDispatcherProfile(dpp, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+		Event: map[string]interface{}{utils.Account: "1001"}}
+	if rcv, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaAttributes); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 2 || rcv[0].ID != "DSP_1001" || rcv[1].ID != "DSP_DEFAULT" {
+		t.Errorf("received: %s", utils.ToJSON(rcv))
+	}
+	ev.Event[utils.Account] = "1002"
+	if rcv, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaAttributes); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 1 || rcv[0].ID != "DSP_DEFAULT" {
+		t.Errorf("received: %s", utils.ToJSON(rcv))
+	}
+	if _, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaSessionS); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err = dmDsp.RemoveDispatcherProfile("cgrates.org", "DSP_DEFAULT",
+		[]string{utils.MetaAttributes}, utils.NonTransactional, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaAttributes); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// DSP_1001 indexed for the subsystem but not passing its filters, fallback on *any
+	if err = dmDsp.SetDispatcherProfile(&DispatcherProfile{
+		Tenant:     "cgrates.org",
+		ID:         "DSP_ANY",
+		Subsystems: []string{utils.META_ANY},
+		Strategy:   utils.MetaWeight,
+		Hosts:      DispatcherHosts{&DispatcherHost{ID: "HOST2", Weight: 10}},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaAttributes); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 1 || rcv[0].ID != "DSP_ANY" {
+		t.Errorf("received: %s", utils.ToJSON(rcv))
+	}
+	ev.Event[utils.Account] = "1001"
+	if rcv, err := MatchingDispatcherProfilesForEvent(dmDsp, fltrS,
+		ev, utils.MetaAttributes); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 1 || rcv[0].ID != "DSP_1001" {
+		t.Errorf("received: %s", utils.ToJSON(rcv))
+	}
+}
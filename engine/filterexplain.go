@@ -0,0 +1,223 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgsExplainFilters are the arguments for FilterSv1.ExplainFilters
+type ArgsExplainFilters struct {
+	FilterIDs []string // filter IDs or inline rules
+	Subsystem string   // optional, <*attributes|*suppliers|*thresholds|*stats|*resources>, list the profiles selected by its indexes
+	utils.CGREvent
+}
+
+// FiltersExplanation is the result of a dry-run over a list of filters
+type FiltersExplanation struct {
+	Pass       bool // same result as FilterS.Pass
+	Filters    []*FilterExplanation
+	ProfileIDs []string // profiles of the Subsystem selected by the filter indexes, before checking their filters
+}
+
+// FilterExplanation details the evaluation of one Filter
+type FilterExplanation struct {
+	ID     string
+	Active bool // inactive filters are skipped by FilterS.Pass, their rules are not evaluated
+	Pass   bool
+	Reason string // why the filter was not evaluated, ie: not found or not active
+	Rules  []*FilterRuleExplanation
+}
+
+// FilterRuleExplanation details the evaluation of one FilterRule
+type FilterRuleExplanation struct {
+	Type       string
+	FieldName  string
+	Values     []string
+	FieldValue interface{} // value read out of the event for FieldName
+	Pass       bool
+	Reason     string               // why the rule did not pass
+	Filters    []*FilterExplanation // evaluation of the referenced filters for *or, *and, *not
+}
+
+// V1ExplainFilters evaluates the filters for the event, explaining the result of each rule
+func (fS *FilterS) V1ExplainFilters(args *ArgsExplainFilters, reply *FiltersExplanation) (err error) {
+	tenant := args.Tenant
+	if tenant == "" {
+		tenant = config.CgrConfig().DefaultTenant
+	}
+	ev := NavigableMap(args.Event)
+	fExp := &FiltersExplanation{Filters: fS.explainFilters(tenant, args.FilterIDs, ev)}
+	if fExp.Pass, err = fS.Pass(tenant, args.FilterIDs, ev); err != nil { // the reason is already in the explanation
+		fExp.Pass = false
+	}
+	if args.Subsystem != "" {
+		if fExp.ProfileIDs, err = fS.indexedProfileIDs(tenant, args.Subsystem, &args.CGREvent); err != nil {
+			return
+		}
+	}
+	*reply = *fExp
+	return nil
+}
+
+// explainFilters evaluates all the rules of the filters, without stopping at the first failing one
+func (fS *FilterS) explainFilters(tenant string, filterIDs []string, ev DataProvider) (fExps []*FilterExplanation) {
+	fExps = make([]*FilterExplanation, len(filterIDs))
+	for i, fltrID := range filterIDs {
+		fExps[i] = &FilterExplanation{ID: fltrID}
+		f, err := fS.dm.GetFilter(tenant, fltrID, false, utils.NonTransactional)
+		if err != nil {
+			fExps[i].Reason = err.Error()
+			continue
+		}
+		fExps[i].Active = f.ActivationInterval == nil ||
+			f.ActivationInterval.IsActiveAtTime(time.Now())
+		fExps[i].Pass = true
+		if !fExps[i].Active { // skipped, does not fail the event
+			fExps[i].Reason = "filter not active, skipped"
+			continue
+		}
+		fExps[i].Rules = make([]*FilterRuleExplanation, len(f.Rules))
+		for j, rule := range f.Rules {
+			fExps[i].Rules[j] = fS.explainRule(tenant, rule, ev)
+			if !fExps[i].Rules[j].Pass {
+				fExps[i].Pass = false
+			}
+		}
+	}
+	return
+}
+
+// explainRule evaluates one rule, recording the value of the field and the reason of failure
+func (fS *FilterS) explainRule(tenant string, rule *FilterRule, ev DataProvider) (rExp *FilterRuleExplanation) {
+	rExp = &FilterRuleExplanation{Type: rule.Type, FieldName: rule.FieldName, Values: rule.Values}
+	fieldFound := true
+	if rule.FieldName != "" {
+		var err error
+		if rExp.FieldValue, err = ev.FieldAsInterface(
+			strings.Split(rule.FieldName, utils.HIERARCHY_SEP)); err != nil {
+			if err != utils.ErrNotFound {
+				rExp.Reason = err.Error()
+				return
+			}
+			fieldFound = false
+		}
+	}
+	if isFilterGroup(rule.Type) {
+		rExp.Filters = fS.explainFilters(tenant, rule.Values, ev)
+	}
+	var err error
+	if rExp.Pass, err = fS.passRule(tenant, rule, ev); err != nil {
+		rExp.Pass = false
+		rExp.Reason = err.Error()
+		return
+	}
+	if rExp.Pass {
+		return
+	}
+	switch {
+	case rule.Type == MetaOr:
+		rExp.Reason = "none of the referenced filters passing"
+	case rule.Type == MetaAnd:
+		rExp.Reason = "not all of the referenced filters passing"
+	case rule.Type == MetaNot:
+		rExp.Reason = "one of the referenced filters passing"
+	case strings.HasPrefix(rule.Type, MetaNot):
+		rExp.Reason = "negated rule matching"
+	case !fieldFound:
+		rExp.Reason = fmt.Sprintf("field %s not found in event", rule.FieldName)
+	case rule.Type == MetaEmpty:
+		rExp.Reason = fmt.Sprintf("field %s not empty", rule.FieldName)
+	default:
+		rExp.Reason = fmt.Sprintf("not matching values %v", rule.Values)
+	}
+	return
+}
+
+// indexedProfileIDs returns the IDs of the subsystem profiles selected by the filter indexes for the event,
+// the same way the subsystem queries them
+func (fS *FilterS) indexedProfileIDs(tenant, subsys string, ev *utils.CGREvent) (prflIDs []string, err error) {
+	var cacheID string
+	idxKeys := []string{tenant}
+	var stringFldIDs, prefixFldIDs, suffixFldIDs *[]string
+	switch subsys {
+	case utils.MetaAttributes:
+		cacheID = utils.CacheAttributeFilterIndexes
+		contextVal := utils.META_DEFAULT
+		if ev.Context != nil && *ev.Context != "" {
+			contextVal = *ev.Context
+		}
+		idxKeys = []string{utils.ConcatenatedKey(tenant, contextVal),
+			utils.ConcatenatedKey(tenant, utils.META_ANY)}
+		if fS.cfg != nil {
+			stringFldIDs, prefixFldIDs, suffixFldIDs = fS.cfg.AttributeSCfg().StringIndexedFields,
+				fS.cfg.AttributeSCfg().PrefixIndexedFields, fS.cfg.AttributeSCfg().SuffixIndexedFields
+		}
+	case utils.MetaSuppliers:
+		cacheID = utils.CacheSupplierFilterIndexes
+		if fS.cfg != nil {
+			stringFldIDs, prefixFldIDs, suffixFldIDs = fS.cfg.SupplierSCfg().StringIndexedFields,
+				fS.cfg.SupplierSCfg().PrefixIndexedFields, fS.cfg.SupplierSCfg().SuffixIndexedFields
+		}
+	case utils.MetaThresholds:
+		cacheID = utils.CacheThresholdFilterIndexes
+		if fS.cfg != nil {
+			stringFldIDs, prefixFldIDs, suffixFldIDs = fS.cfg.ThresholdSCfg().StringIndexedFields,
+				fS.cfg.ThresholdSCfg().PrefixIndexedFields, fS.cfg.ThresholdSCfg().SuffixIndexedFields
+		}
+	case utils.MetaStats:
+		cacheID = utils.CacheStatFilterIndexes
+		if fS.cfg != nil {
+			stringFldIDs, prefixFldIDs, suffixFldIDs = fS.cfg.StatSCfg().StringIndexedFields,
+				fS.cfg.StatSCfg().PrefixIndexedFields, fS.cfg.StatSCfg().SuffixIndexedFields
+		}
+	case utils.MetaResources:
+		cacheID = utils.CacheResourceFilterIndexes
+		if fS.cfg != nil {
+			stringFldIDs, prefixFldIDs, suffixFldIDs = fS.cfg.ResourceSCfg().StringIndexedFields,
+				fS.cfg.ResourceSCfg().PrefixIndexedFields, fS.cfg.ResourceSCfg().SuffixIndexedFields
+		}
+	default:
+		return nil, fmt.Errorf("unsupported subsystem: %s", subsys)
+	}
+	for _, idxKey := range idxKeys { // the next key is only queried if nothing was found with the previous one
+		var itemIDs utils.StringMap
+		if itemIDs, err = matchingItemIDsForEvent(ev.Event, stringFldIDs, prefixFldIDs, suffixFldIDs,
+			fS.dm, cacheID, idxKey); err != nil {
+			if err != utils.ErrNotFound {
+				return nil, err
+			}
+			err = nil
+			continue
+		}
+		prflIDs = itemIDs.Slice()
+		sort.Strings(prflIDs)
+		break
+	}
+	if prflIDs == nil {
+		prflIDs = []string{}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFilterSV1ExplainFilters(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmExp := NewDataManager(data)
+	fltrS := &FilterS{dm: dmExp}
+	for _, fltr := range []*Filter{
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_EXP_1",
+			Rules: []*FilterRule{
+				&FilterRule{Type: MetaString, FieldName: utils.Account, Values: []string{"1001"}},
+				&FilterRule{Type: MetaPrefix, FieldName: utils.Destination, Values: []string{"+49"}},
+				&FilterRule{Type: MetaString, FieldName: utils.Subject, Values: []string{"1001"}},
+			}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_EXP_OR",
+			Rules: []*FilterRule{&FilterRule{Type: MetaOr, Values: []string{"FLTR_EXP_1", "*string:Account:1001"}}}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_EXP_INACTIVE",
+			Rules: []*FilterRule{&FilterRule{Type: MetaString, FieldName: utils.Account, Values: []string{"1002"}}},
+			ActivationInterval: &utils.ActivationInterval{
+				ActivationTime: time.Date(2014, 7, 14, 14, 25, 0, 0, time.UTC),
+				ExpiryTime:     time.Date(2014, 7, 15, 14, 25, 0, 0, time.UTC)}},
+	} {
+		if err := dmExp.SetFilter(fltr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dmExp.SetAttributeProfile(&AttributeProfile{Tenant: "cgrates.org", ID: "ATTR_EXP_1",
+		Contexts: []string{utils.META_ANY}, FilterIDs: []string{"FLTR_EXP_1"}}, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &ArgsExplainFilters{
+		FilterIDs: []string{"FLTR_EXP_1", "FLTR_EXP_OR", "FLTR_EXP_UNKNOWN"},
+		Subsystem: utils.MetaAttributes,
+		CGREvent: utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+			Event: map[string]interface{}{utils.Account: "1001", utils.Destination: "+3312345"}},
+	}
+	var reply FiltersExplanation
+	if err := fltrS.V1ExplainFilters(args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Pass {
+		t.Error("expecting filters not passing")
+	}
+	if len(reply.Filters) != 3 {
+		t.Fatalf("received: %s", utils.ToJSON(reply))
+	}
+	eRules := []*FilterRuleExplanation{
+		&FilterRuleExplanation{Type: MetaString, FieldName: utils.Account, Values: []string{"1001"},
+			FieldValue: "1001", Pass: true},
+		&FilterRuleExplanation{Type: MetaPrefix, FieldName: utils.Destination, Values: []string{"+49"},
+			FieldValue: "+3312345", Reason: "not matching values [+49]"},
+		&FilterRuleExplanation{Type: MetaString, FieldName: utils.Subject, Values: []string{"1001"},
+			Reason: "field Subject not found in event"},
+	}
+	if fExp := reply.Filters[0]; fExp.ID != "FLTR_EXP_1" || !fExp.Active || fExp.Pass ||
+		!reflect.DeepEqual(eRules, fExp.Rules) {
+		t.Errorf("expecting rules: %s, received: %s", utils.ToJSON(eRules), utils.ToJSON(fExp))
+	}
+	if fExp := reply.Filters[1]; !fExp.Pass || len(fExp.Rules) != 1 ||
+		len(fExp.Rules[0].Filters) != 2 || fExp.Rules[0].Filters[0].Pass || !fExp.Rules[0].Filters[1].Pass {
+		t.Errorf("received: %s", utils.ToJSON(fExp))
+	}
+	if fExp := reply.Filters[2]; fExp.Pass || fExp.Reason != utils.ErrNotFound.Error() {
+		t.Errorf("received: %s", utils.ToJSON(fExp))
+	}
+	if eIDs := []string{"ATTR_EXP_1"}; !reflect.DeepEqual(eIDs, reply.ProfileIDs) {
+		t.Errorf("expecting: %+v, received: %+v", eIDs, reply.ProfileIDs)
+	}
+	args.FilterIDs = []string{"FLTR_EXP_OR", "FLTR_EXP_INACTIVE"}
+	args.Subsystem = ""
+	reply = FiltersExplanation{}
+	if err := fltrS.V1ExplainFilters(args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Pass || len(reply.Filters) != 2 {
+		t.Fatalf("received: %s", utils.ToJSON(reply))
+	}
+	if fExp := reply.Filters[1]; fExp.Active || !fExp.Pass ||
+		fExp.Reason != "filter not active, skipped" || fExp.Rules != nil {
+		t.Errorf("received: %s", utils.ToJSON(fExp))
+	}
+	args.Subsystem = "*unknown"
+	if err := fltrS.V1ExplainFilters(args, &reply); err == nil {
+		t.Error("expecting error for unsupported subsystem")
+	}
+}
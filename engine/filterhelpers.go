@@ -27,7 +27,7 @@ import (
 // matchingItemIDsForEvent returns the list of item IDs matching fieldName/fieldValue for an event
 // fieldIDs limits the fields which are checked against indexes
 // helper on top of dataDB.MatchFilterIndex, adding utils.ANY to list of fields queried
-func matchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldIDs *[]string,
+func matchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldIDs, suffixFldIDs *[]string,
 	dm *DataManager, cacheID, itemIDPrefix string) (itemIDs utils.StringMap, err error) {
 	itemIDs = make(utils.StringMap)
 	allFieldIDs := make([]string, len(ev))
@@ -37,8 +37,8 @@ func matchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldI
 		i += 1
 	}
 	stringFieldVals := map[string]string{utils.ANY: utils.ANY} // cache here field string values, start with default one
-	filterIndexTypes := []string{MetaString, MetaPrefix, utils.MetaDefault}
-	for i, fieldIDs := range []*[]string{stringFldIDs, prefixFldIDs, nil} { // same routine for both string and prefix filter types
+	filterIndexTypes := []string{MetaString, MetaPrefix, MetaSuffix, utils.MetaDefault}
+	for i, fieldIDs := range []*[]string{stringFldIDs, prefixFldIDs, suffixFldIDs, nil} { // same routine for string, prefix and suffix filter types
 		if filterIndexTypes[i] == utils.MetaDefault {
 			fieldIDs = &[]string{utils.ANY} // so we can query DB for unindexed filters
 		}
@@ -64,6 +64,8 @@ func matchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldI
 			// default is only one fieldValue checked
 			if filterIndexTypes[i] == MetaPrefix {
 				fldVals = utils.SplitPrefix(fldVal, 1) // all prefixes till last digit
+			} else if filterIndexTypes[i] == MetaSuffix {
+				fldVals = utils.SplitSuffix(fldVal) // all suffixes, longest first
 			}
 			var dbItemIDs utils.StringMap // list of items matched in DB
 			for _, val := range fldVals {
@@ -75,7 +77,7 @@ func matchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldI
 					}
 					return nil, err
 				}
-				break // we got at least one answer back, longest prefix wins
+				break // we got at least one answer back, longest prefix/suffix wins
 			}
 			for itemID := range dbItemIDs {
 				if _, hasIt := itemIDs[itemID]; !hasIt { // Add it to list if not already there
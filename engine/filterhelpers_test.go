@@ -77,7 +77,7 @@ func TestFilterMatchingItemIDsForEvent(t *testing.T) {
 		utils.AnswerTime: time.Date(2014, 7, 14, 14, 30, 0, 0, time.UTC),
 		"Field":          "profile",
 	}
-	aPrflIDs, err := matchingItemIDsForEvent(matchEV, nil, nil,
+	aPrflIDs, err := matchingItemIDsForEvent(matchEV, nil, nil, nil,
 		dmMatch, utils.CacheAttributeFilterIndexes, prefix)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
@@ -89,7 +89,7 @@ func TestFilterMatchingItemIDsForEvent(t *testing.T) {
 	matchEV = map[string]interface{}{
 		"Field": "profilePrefix",
 	}
-	aPrflIDs, err = matchingItemIDsForEvent(matchEV, nil, nil,
+	aPrflIDs, err = matchingItemIDsForEvent(matchEV, nil, nil, nil,
 		dmMatch, utils.CacheAttributeFilterIndexes, prefix)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
@@ -101,7 +101,7 @@ func TestFilterMatchingItemIDsForEvent(t *testing.T) {
 	matchEV = map[string]interface{}{
 		"Weight": "200",
 	}
-	aPrflIDs, err = matchingItemIDsForEvent(matchEV, nil, nil,
+	aPrflIDs, err = matchingItemIDsForEvent(matchEV, nil, nil, nil,
 		dmMatch, utils.CacheAttributeFilterIndexes, prefix)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
@@ -111,3 +111,33 @@ func TestFilterMatchingItemIDsForEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", defaultFilterID, aPrflIDs)
 	}
 }
+
+func TestFilterMatchingItemIDsForEventSuffix(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmSfx := NewDataManager(data)
+	rule, err := NewFilterRule(MetaSuffix, utils.Destination, []string{"963"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fltr := &Filter{Tenant: config.CgrConfig().DefaultTenant,
+		ID: "suffixFilter", Rules: []*FilterRule{rule}}
+	dmSfx.SetFilter(fltr)
+	prefix := utils.ConcatenatedKey(config.CgrConfig().DefaultTenant, utils.MetaRating)
+	atrRFI := NewFilterIndexer(dmSfx, utils.AttributeProfilePrefix, prefix)
+	atrRFI.IndexTPFilter(FilterToTPFilter(fltr), "suffixFilterID")
+	if err = atrRFI.StoreIndexes(true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	ev := map[string]interface{}{utils.Destination: "+4986517174963"}
+	if aPrflIDs, err := matchingItemIDsForEvent(ev, nil, nil, &[]string{utils.Destination},
+		dmSfx, utils.CacheAttributeFilterIndexes, prefix); err != nil {
+		t.Error(err)
+	} else if _, has := aPrflIDs["suffixFilterID"]; !has {
+		t.Errorf("Expecting: %+v, received: %+v", "suffixFilterID", aPrflIDs)
+	}
+	ev[utils.Destination] = "+4986517174964"
+	if _, err := matchingItemIDsForEvent(ev, nil, nil, &[]string{utils.Destination},
+		dmSfx, utils.CacheAttributeFilterIndexes, prefix); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -72,7 +72,7 @@ func (rfi *FilterIndexer) IndexTPFilter(tpFltr *utils.TPFilterProfile, itemID st
 				rfi.chngdIndxKeys[concatKey] = true
 			}
 			rfi.chngdRevIndxKeys[itemID] = true
-		case MetaPrefix:
+		case MetaPrefix, MetaSuffix:
 			for _, fldVal := range fltr.Values {
 				concatKey := utils.ConcatenatedKey(fltr.Type, fltr.FieldName, fldVal)
 				if _, hasIt := rfi.indexes[concatKey]; !hasIt {
@@ -119,6 +119,9 @@ func (rfi *FilterIndexer) cacheRemItemType() { // ToDo: tune here by removing pe
 	case utils.AttributeProfilePrefix:
 		Cache.Clear([]string{utils.CacheAttributeFilterIndexes, utils.CacheAttributeFilterRevIndexes})
 
+	case utils.DispatcherProfilePrefix:
+		Cache.Clear([]string{utils.CacheDispatcherFilterIndexes, utils.CacheDispatcherFilterRevIndexes})
+
 	}
 }
 
@@ -18,7 +18,9 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,20 +34,53 @@ import (
 const (
 	MetaString         = "*string"
 	MetaPrefix         = "*prefix"
+	MetaSuffix         = "*suffix"
+	MetaIPNet          = "*ipnet"
+	MetaRegex          = "*regex"
 	MetaTimings        = "*timings"
 	MetaRSR            = "*rsr"
 	MetaStatS          = "*stats"
 	MetaDestinations   = "*destinations"
+	MetaResources      = "*resources"
+	MetaAccounts       = "*accounts"
 	MetaMinCapPrefix   = "*min_"
 	MetaMaxCapPrefix   = "*max_"
 	MetaLessThan       = "*lt"
 	MetaLessOrEqual    = "*lte"
 	MetaGreaterThan    = "*gt"
 	MetaGreaterOrEqual = "*gte"
+	MetaExists         = "*exists"
+	MetaEmpty          = "*empty"
+	MetaOr             = "*or"  // filter group passing when one of the referenced filters passes
+	MetaAnd            = "*and" // filter group passing when all of the referenced filters pass
+
+	// MetaNot prefixes the negated rule types, the *lt/*lte/*gt/*gte negate each other
+	// it is also the filter group passing when none of the referenced filters pass
+	MetaNot             = "*not"
+	MetaNotString       = "*notstring"
+	MetaNotPrefix       = "*notprefix"
+	MetaNotSuffix       = "*notsuffix"
+	MetaNotIPNet        = "*notipnet"
+	MetaNotRegex        = "*notregex"
+	MetaNotTimings      = "*nottimings"
+	MetaNotRSR          = "*notrsr"
+	MetaNotStatS        = "*notstats"
+	MetaNotDestinations = "*notdestinations"
+	MetaNotResources    = "*notresources"
+	MetaNotAccounts     = "*notaccounts"
+	MetaNotExists       = "*notexists"
+	MetaNotEmpty        = "*notempty"
+
+	// metrics and values for the *resources and *accounts rules
+	ResourceUsageMetric     = "usage"     // total usage of the resource
+	ResourceAvailableMetric = "available" // units left until the limit of the resource
+	AccountBalanceMetric    = "balance"   // total value of the account balances with one type
+	MetaDisabled            = "*disabled" // passing for disabled accounts
 )
 
-func NewFilterS(cfg *config.CGRConfig, statSChan chan rpcclient.RpcClientConnection, dm *DataManager) *FilterS {
-	return &FilterS{statSChan: statSChan, dm: dm}
+func NewFilterS(cfg *config.CGRConfig, statSChan, resSChan chan rpcclient.RpcClientConnection,
+	dm *DataManager) *FilterS {
+	return &FilterS{cfg: cfg, statSChan: statSChan, resSChan: resSChan, dm: dm}
 }
 
 // FilterS is a service used to take decisions in case of filters
@@ -54,7 +89,10 @@ type FilterS struct {
 	cfg        *config.CGRConfig
 	statSChan  chan rpcclient.RpcClientConnection // reference towards internal statS connection, used for lazy connect
 	statSConns rpcclient.RpcClientConnection
-	sSConnMux  sync.RWMutex // make sure only one goroutine attempts connecting
+	sSConnMux  sync.RWMutex                       // make sure only one goroutine attempts connecting
+	resSChan   chan rpcclient.RpcClientConnection // reference towards internal resourceS connection, used for lazy connect
+	resSConns  rpcclient.RpcClientConnection
+	rSConnMux  sync.RWMutex // make sure only one goroutine attempts connecting
 	dm         *DataManager
 }
 
@@ -65,12 +103,42 @@ func (fS *FilterS) connStatS() (err error) {
 	if fS.statSConns != nil { // connection was populated between locks
 		return
 	}
-	fS.statSConns, err = NewRPCPool(rpcclient.POOL_FIRST,
+	if fS.cfg == nil || len(fS.cfg.FilterSCfg().StatSConns) == 0 { // not configured, the rule will complain
+		return
+	}
+	var statSConns *rpcclient.RpcClientPool
+	if statSConns, err = NewRPCPool(rpcclient.POOL_FIRST,
 		fS.cfg.TLSClientKey, fS.cfg.TLSClientCerificate,
 		fS.cfg.ConnectAttempts, fS.cfg.Reconnects,
 		fS.cfg.ConnectTimeout, fS.cfg.ReplyTimeout,
 		fS.cfg.FilterSCfg().StatSConns,
-		fS.statSChan, fS.cfg.InternalTtl)
+		fS.statSChan, fS.cfg.InternalTtl); err != nil {
+		return
+	}
+	fS.statSConns = statSConns
+	return
+}
+
+// connResourceS will connect towards ResourceS
+func (fS *FilterS) connResourceS() (err error) {
+	fS.rSConnMux.Lock()
+	defer fS.rSConnMux.Unlock()
+	if fS.resSConns != nil { // connection was populated between locks
+		return
+	}
+	if fS.cfg == nil || len(fS.cfg.FilterSCfg().ResourceSConns) == 0 { // not configured, the rule will complain
+		return
+	}
+	var resSConns *rpcclient.RpcClientPool
+	if resSConns, err = NewRPCPool(rpcclient.POOL_FIRST,
+		fS.cfg.TLSClientKey, fS.cfg.TLSClientCerificate,
+		fS.cfg.ConnectAttempts, fS.cfg.Reconnects,
+		fS.cfg.ConnectTimeout, fS.cfg.ReplyTimeout,
+		fS.cfg.FilterSCfg().ResourceSConns,
+		fS.resSChan, fS.cfg.InternalTtl); err != nil {
+		return
+	}
+	fS.resSConns = resSConns
 	return
 }
 
@@ -91,7 +159,7 @@ func (fS *FilterS) Pass(tenant string, filterIDs []string, ev DataProvider) (pas
 			continue
 		}
 		for _, fltr := range f.Rules {
-			if pass, err = fltr.Pass(ev, fS.statSConns); err != nil || !pass {
+			if pass, err = fS.passRule(tenant, fltr, ev); err != nil || !pass {
 				return pass, err
 			}
 		}
@@ -100,10 +168,89 @@ func (fS *FilterS) Pass(tenant string, filterIDs []string, ev DataProvider) (pas
 	return
 }
 
+// passRule checks one rule, the filter groups and the rules querying other subsystems
+// are evaluated here since they need the tenant and the connections of FilterS
+func (fS *FilterS) passRule(tenant string, fltr *FilterRule, ev DataProvider) (pass bool, err error) {
+	switch fltr.Type {
+	case MetaAnd:
+		return fS.Pass(tenant, fltr.Values, ev)
+	case MetaOr, MetaNot:
+		for _, fltrID := range fltr.Values {
+			if pass, err = fS.Pass(tenant, []string{fltrID}, ev); err != nil {
+				return false, err
+			} else if pass {
+				break
+			}
+		}
+		if fltr.Type == MetaNot {
+			pass = !pass
+		}
+		return
+	case MetaStatS, MetaNotStatS:
+		fS.sSConnMux.RLock()
+		statSConns := fS.statSConns
+		fS.sSConnMux.RUnlock()
+		if statSConns == nil {
+			if err = fS.connStatS(); err != nil {
+				return false, err
+			}
+			statSConns = fS.statSConns
+		}
+		return fltr.Pass(fS.dm, ev, statSConns)
+	case MetaResources, MetaNotResources:
+		fS.rSConnMux.RLock()
+		resSConns := fS.resSConns
+		fS.rSConnMux.RUnlock()
+		if resSConns == nil {
+			if err = fS.connResourceS(); err != nil {
+				return false, err
+			}
+			resSConns = fS.resSConns
+		}
+		pass, err = fltr.passResourceS(tenant, resSConns)
+	case MetaTimings, MetaNotTimings:
+		pass, err = fltr.passTimings(fS.dm, fS.timezone(), ev)
+	case MetaAccounts, MetaNotAccounts:
+		pass, err = fltr.passAccounts(fS.dm, tenant, ev)
+	default:
+		return fltr.Pass(fS.dm, ev, nil)
+	}
+	if err != nil {
+		return false, err
+	}
+	if strings.HasPrefix(fltr.Type, MetaNot) {
+		pass = !pass
+	}
+	return
+}
+
+// timezone returns the timezone used to parse the event times
+func (fS *FilterS) timezone() string {
+	if fS.cfg == nil {
+		return config.CgrConfig().DefaultTimezone
+	}
+	return fS.cfg.DefaultTimezone
+}
+
+// isFilterGroup returns true for the rule types referencing other filters in their Values
+func isFilterGroup(rfType string) bool {
+	return rfType == MetaOr || rfType == MetaAnd || rfType == MetaNot
+}
+
 // NewFilterFromInline parses an inline rule into a compiled Filter
 func NewFilterFromInline(tenant, inlnRule string) (f *Filter, err error) {
 	ruleSplt := strings.Split(inlnRule, utils.InInFieldSep)
-	if len(ruleSplt) != 3 {
+	var fieldName string
+	var vals []string
+	switch {
+	case len(ruleSplt) == 2 && isFilterGroup(ruleSplt[0]): // filter groups, ie: *or:FLTR_A;FLTR_B
+		vals = strings.Split(ruleSplt[1], utils.INFIELD_SEP)
+	case len(ruleSplt) == 2: // rules without values, ie: *exists:Account
+		fieldName = ruleSplt[1]
+	case len(ruleSplt) == 3:
+		fieldName = ruleSplt[1]
+		vals = strings.Split(ruleSplt[2], utils.INFIELD_SEP)
+	default:
 		return nil, fmt.Errorf("inline parse error for string: <%s>", inlnRule)
 	}
 	f = &Filter{
@@ -112,8 +259,8 @@ func NewFilterFromInline(tenant, inlnRule string) (f *Filter, err error) {
 		Rules: []*FilterRule{
 			&FilterRule{
 				Type:      ruleSplt[0],
-				FieldName: ruleSplt[1],
-				Values:    strings.Split(ruleSplt[2], utils.INFIELD_SEP)}},
+				FieldName: fieldName,
+				Values:    vals}},
 	}
 	if err = f.Compile(); err != nil {
 		return nil, err
@@ -143,16 +290,27 @@ func (f *Filter) Compile() (err error) {
 }
 
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
-	if !utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaTimings, MetaRSR, MetaStatS, MetaDestinations,
-		MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual}, rfType) {
+	if !utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix, MetaIPNet, MetaRegex,
+		MetaTimings, MetaRSR, MetaStatS, MetaDestinations, MetaResources, MetaAccounts,
+		MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual, MetaExists, MetaEmpty,
+		MetaNotString, MetaNotPrefix, MetaNotSuffix, MetaNotIPNet, MetaNotRegex,
+		MetaNotTimings, MetaNotRSR, MetaNotStatS, MetaNotDestinations, MetaNotResources, MetaNotAccounts,
+		MetaNotExists, MetaNotEmpty, MetaOr, MetaAnd, MetaNot}, rfType) {
 		return nil, fmt.Errorf("Unsupported filter Type: %s", rfType)
 	}
-	if fieldName == "" && utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaTimings, MetaDestinations,
-		MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual}, rfType) {
+	if fieldName == "" && utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix, MetaIPNet, MetaRegex,
+		MetaTimings, MetaDestinations, MetaAccounts,
+		MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual, MetaExists, MetaEmpty,
+		MetaNotString, MetaNotPrefix, MetaNotSuffix, MetaNotIPNet, MetaNotRegex,
+		MetaNotTimings, MetaNotDestinations, MetaNotAccounts, MetaNotExists, MetaNotEmpty}, rfType) {
 		return nil, fmt.Errorf("FieldName is mandatory for Type: %s", rfType)
 	}
-	if len(vals) == 0 && utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaTimings, MetaRSR,
-		MetaDestinations, MetaDestinations, MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual}, rfType) {
+	if len(vals) == 0 && utils.IsSliceMember([]string{MetaString, MetaPrefix, MetaSuffix, MetaIPNet, MetaRegex,
+		MetaTimings, MetaRSR, MetaResources, MetaAccounts,
+		MetaDestinations, MetaDestinations, MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual,
+		MetaNotString, MetaNotPrefix, MetaNotSuffix, MetaNotIPNet, MetaNotRegex,
+		MetaNotTimings, MetaNotRSR, MetaNotDestinations, MetaNotResources, MetaNotAccounts,
+		MetaOr, MetaAnd, MetaNot}, rfType) {
 		return nil, fmt.Errorf("Values is mandatory for Type: %s", rfType)
 	}
 	rf := &FilterRule{Type: rfType, FieldName: fieldName, Values: vals}
@@ -168,23 +326,75 @@ type RFStatSThreshold struct {
 	ThresholdValue float64
 }
 
+// RFResourceThreshold is the compiled value of a *resources rule, ie: RES_CALLS:*max_usage:50
+type RFResourceThreshold struct {
+	ResourceID     string
+	ThresholdType  string // <*min_|*max_><usage|available>
+	ThresholdValue float64
+}
+
+// RFAccountThreshold is the compiled value of an *accounts rule, ie: *monetary:*min_balance:10 or *disabled
+type RFAccountThreshold struct {
+	BalanceType    string
+	ThresholdType  string // <*min_balance|*max_balance|*disabled>
+	ThresholdValue float64
+}
+
+// parseRFThreshold parses values in the form ID:<*min_|*max_><metric>:ThresholdValue,
+// metrics limiting the metrics accepted
+func parseRFThreshold(val string, metrics []string) (id, thType string, thVal float64, err error) {
+	valSplt := strings.Split(val, utils.InInFieldSep)
+	if len(valSplt) != 3 {
+		return "", "", 0, fmt.Errorf("Value %s needs to contain 3 items", val)
+	}
+	id, thType = valSplt[0], valSplt[1]
+	var metric string
+	if strings.HasPrefix(thType, MetaMinCapPrefix) {
+		metric = thType[len(MetaMinCapPrefix):]
+	} else if strings.HasPrefix(thType, MetaMaxCapPrefix) {
+		metric = thType[len(MetaMaxCapPrefix):]
+	} else {
+		return "", "", 0, fmt.Errorf("Value %s contains unsupported ThresholdType prefix", val)
+	}
+	if !utils.IsSliceMember(metrics, metric) {
+		return "", "", 0, fmt.Errorf("Value %s contains unsupported ThresholdType: %s", val, thType)
+	}
+	if thVal, err = strconv.ParseFloat(valSplt[2], 64); err != nil {
+		return "", "", 0, err
+	}
+	return
+}
+
+// thresholdPasses compares val with the threshold the same way as *stats:
+// *min_ passes from thVal up, *max_ passes below thVal
+func thresholdPasses(thType string, val, thVal float64) bool {
+	if strings.HasPrefix(thType, MetaMinCapPrefix) {
+		return val >= thVal
+	}
+	return val < thVal
+}
+
 // FilterRule filters requests coming into various places
 // Pass rule: default negative, one mathing rule should pass the filter
 type FilterRule struct {
-	Type            string              // Filter type (*string, *timing, *rsr_filters, *stats, *lt, *lte, *gt, *gte)
+	Type            string              // Filter type (*string, *prefix, *suffix, *ipnet, *regex, *timing, *rsr_filters, *stats, *lt, *lte, *gt, *gte, *resources, *accounts, *exists, *empty and their *not variants, *or, *and, *not filter groups)
 	FieldName       string              // Name of the field providing us the Values to check (used in case of some )
 	Values          []string            // Filter definition
 	rsrFields       utils.RSRFields     // Cache here the RSRFilter Values
 	statSThresholds []*RFStatSThreshold // Cached compiled RFStatsThreshold out of Values
+	ipNets          []*net.IPNet        // Cached parsed CIDRs for *ipnet
+	regexps         []*regexp.Regexp    // Cached compiled expressions for *regex
+	resThresholds   []*RFResourceThreshold
+	acntThresholds  []*RFAccountThreshold
 }
 
 // Separate method to compile RSR fields
 func (rf *FilterRule) CompileValues() (err error) {
-	if rf.Type == MetaRSR {
+	if rf.Type == MetaRSR || rf.Type == MetaNotRSR {
 		if rf.rsrFields, err = utils.ParseRSRFieldsFromSlice(rf.Values); err != nil {
 			return
 		}
-	} else if rf.Type == MetaStatS {
+	} else if rf.Type == MetaStatS || rf.Type == MetaNotStatS {
 		rf.statSThresholds = make([]*RFStatSThreshold, len(rf.Values))
 		for i, val := range rf.Values {
 			valSplt := strings.Split(val, utils.InInFieldSep)
@@ -204,30 +414,85 @@ func (rf *FilterRule) CompileValues() (err error) {
 			}
 			rf.statSThresholds[i] = st
 		}
+	} else if rf.Type == MetaIPNet || rf.Type == MetaNotIPNet {
+		rf.ipNets = make([]*net.IPNet, len(rf.Values))
+		for i, val := range rf.Values {
+			if _, rf.ipNets[i], err = net.ParseCIDR(val); err != nil {
+				return fmt.Errorf("Value %s is not a valid CIDR: %s", val, err.Error())
+			}
+		}
+	} else if rf.Type == MetaResources || rf.Type == MetaNotResources {
+		rf.resThresholds = make([]*RFResourceThreshold, len(rf.Values))
+		for i, val := range rf.Values {
+			rt := new(RFResourceThreshold)
+			if rt.ResourceID, rt.ThresholdType, rt.ThresholdValue, err = parseRFThreshold(val,
+				[]string{ResourceUsageMetric, ResourceAvailableMetric}); err != nil {
+				return
+			}
+			rf.resThresholds[i] = rt
+		}
+	} else if rf.Type == MetaAccounts || rf.Type == MetaNotAccounts {
+		rf.acntThresholds = make([]*RFAccountThreshold, len(rf.Values))
+		for i, val := range rf.Values {
+			at := &RFAccountThreshold{ThresholdType: MetaDisabled}
+			if val != MetaDisabled {
+				if at.BalanceType, at.ThresholdType, at.ThresholdValue, err = parseRFThreshold(val,
+					[]string{AccountBalanceMetric}); err != nil {
+					return
+				}
+			}
+			rf.acntThresholds[i] = at
+		}
+	} else if rf.Type == MetaRegex || rf.Type == MetaNotRegex {
+		rf.regexps = make([]*regexp.Regexp, len(rf.Values))
+		for i, val := range rf.Values {
+			if rf.regexps[i], err = regexp.Compile(val); err != nil {
+				return fmt.Errorf("Value %s is not a valid regexp: %s", val, err.Error())
+			}
+		}
 	}
 	return
 }
 
 // Pass is the method which should be used from outside.
-func (fltr *FilterRule) Pass(dP DataProvider, rpcClnt rpcclient.RpcClientConnection) (bool, error) {
+// The *not variants invert the result of the rule, ie: *notstring passes also if the field is missing
+// dm is used by the rules reading their references out of DataDB (*timings, *destinations)
+func (fltr *FilterRule) Pass(dm *DataManager, dP DataProvider, rpcClnt rpcclient.RpcClientConnection) (pass bool, err error) {
 	switch fltr.Type {
-	case MetaString:
-		return fltr.passString(dP)
-	case MetaPrefix:
-		return fltr.passStringPrefix(dP)
-	case MetaTimings:
-		return fltr.passTimings(dP)
-	case MetaDestinations:
-		return fltr.passDestinations(dP)
-	case MetaRSR:
-		return fltr.passRSR(dP)
-	case MetaStatS:
-		return fltr.passStatS(dP, rpcClnt)
+	case MetaString, MetaNotString:
+		pass, err = fltr.passString(dP)
+	case MetaPrefix, MetaNotPrefix:
+		pass, err = fltr.passStringPrefix(dP)
+	case MetaSuffix, MetaNotSuffix:
+		pass, err = fltr.passStringSuffix(dP)
+	case MetaIPNet, MetaNotIPNet:
+		pass, err = fltr.passIPNet(dP)
+	case MetaRegex, MetaNotRegex:
+		pass, err = fltr.passRegex(dP)
+	case MetaTimings, MetaNotTimings:
+		pass, err = fltr.passTimings(dm, config.CgrConfig().DefaultTimezone, dP)
+	case MetaDestinations, MetaNotDestinations:
+		pass, err = fltr.passDestinations(dm, dP)
+	case MetaRSR, MetaNotRSR:
+		pass, err = fltr.passRSR(dP)
+	case MetaStatS, MetaNotStatS:
+		pass, err = fltr.passStatS(dP, rpcClnt)
 	case MetaLessThan, MetaLessOrEqual, MetaGreaterThan, MetaGreaterOrEqual:
-		return fltr.passGreaterThan(dP)
+		pass, err = fltr.passGreaterThan(dP)
+	case MetaExists, MetaNotExists:
+		pass, err = fltr.passExists(dP)
+	case MetaEmpty, MetaNotEmpty:
+		pass, err = fltr.passEmpty(dP)
 	default:
-		return false, utils.ErrNotImplemented
+		err = utils.ErrNotImplemented
 	}
+	if err != nil {
+		return false, err
+	}
+	if strings.HasPrefix(fltr.Type, MetaNot) {
+		pass = !pass
+	}
+	return
 }
 
 func (fltr *FilterRule) passString(dP DataProvider) (bool, error) {
@@ -246,6 +511,40 @@ func (fltr *FilterRule) passString(dP DataProvider) (bool, error) {
 	return false, nil
 }
 
+// passExists checks if the field is present in the event
+func (fltr *FilterRule) passExists(dP DataProvider) (bool, error) {
+	if _, err := dP.FieldAsInterface(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP)); err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// passEmpty checks if the field is present in the event but without value (nil, empty string, slice or map)
+func (fltr *FilterRule) passEmpty(dP DataProvider) (bool, error) {
+	val, err := dP.FieldAsInterface(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if val == nil {
+		return true, nil
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0, nil
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil(), nil
+	default:
+		return false, nil
+	}
+}
+
 func (fltr *FilterRule) passStringPrefix(dP DataProvider) (bool, error) {
 	strVal, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
 	if err != nil {
@@ -262,12 +561,108 @@ func (fltr *FilterRule) passStringPrefix(dP DataProvider) (bool, error) {
 	return false, nil
 }
 
-// ToDo when Timings will be available in DataDb
-func (fltr *FilterRule) passTimings(dP DataProvider) (bool, error) {
-	return false, utils.ErrNotImplemented
+func (fltr *FilterRule) passStringSuffix(dP DataProvider) (bool, error) {
+	strVal, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, sfx := range fltr.Values {
+		if strings.HasSuffix(strVal, sfx) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passIPNet checks if the IP address in FieldName belongs to one of the networks in Values
+func (fltr *FilterRule) passIPNet(dP DataProvider) (bool, error) {
+	strVal, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	ip := net.ParseIP(strVal)
+	if ip == nil { // not an IP address, cannot match
+		return false, nil
+	}
+	for _, ipNet := range fltr.ipNets {
+		if ipNet.Contains(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passRegex checks the value of FieldName against the expressions in Values
+func (fltr *FilterRule) passRegex(dP DataProvider) (bool, error) {
+	strVal, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, re := range fltr.regexps {
+		if re.MatchString(strVal) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-func (fltr *FilterRule) passDestinations(dP DataProvider) (bool, error) {
+// passTimings checks the time in FieldName against the timings referenced in Values,
+// passing if one of them is active at that time
+func (fltr *FilterRule) passTimings(dm *DataManager, timezone string, dP DataProvider) (bool, error) {
+	tmIface, err := dP.FieldAsInterface(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	tm, err := utils.IfaceAsTime(tmIface, timezone)
+	if err != nil {
+		return false, err
+	}
+	for _, tmgID := range fltr.Values {
+		tmg, err := dm.GetTiming(tmgID, false, utils.NonTransactional)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				continue
+			}
+			return false, err
+		}
+		if timingActiveAt(tmg, tm) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// timingActiveAt checks the TPTiming against the time using the same rules as the rating intervals,
+// StartTime and EndTime not in ##:##:## format (ie: *asap) are not limiting
+func timingActiveAt(tmg *utils.TPTiming, tm time.Time) bool {
+	rit := &RITiming{
+		Years:     tmg.Years,
+		Months:    tmg.Months,
+		MonthDays: tmg.MonthDays,
+		WeekDays:  tmg.WeekDays,
+	}
+	if len(strings.Split(tmg.StartTime, utils.InInFieldSep)) == 3 {
+		rit.StartTime = tmg.StartTime
+	}
+	if len(strings.Split(tmg.EndTime, utils.InInFieldSep)) == 3 {
+		rit.EndTime = tmg.EndTime
+	}
+	return rit.IsActiveAt(tm)
+}
+
+func (fltr *FilterRule) passDestinations(dm *DataManager, dP DataProvider) (bool, error) {
 	dst, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
 	if err != nil {
 		if err == utils.ErrNotFound {
@@ -330,6 +725,64 @@ func (fltr *FilterRule) passStatS(dP DataProvider,
 	return false, nil
 }
 
+// passResourceS checks the usage of the resources in Values, one threshold matching passes the rule
+func (fltr *FilterRule) passResourceS(tenant string,
+	resourceS rpcclient.RpcClientConnection) (bool, error) {
+	if resourceS == nil || reflect.ValueOf(resourceS).IsNil() {
+		return false, errors.New("Missing ResourceS information")
+	}
+	for _, threshold := range fltr.resThresholds {
+		var rSum ResourceSummary
+		if err := resourceS.Call(utils.ResourceSv1GetResourceSummary,
+			&utils.TenantID{Tenant: tenant, ID: threshold.ResourceID}, &rSum); err != nil {
+			if err.Error() == utils.ErrNotFound.Error() {
+				continue
+			}
+			return false, err
+		}
+		val := rSum.TotalUsage
+		if strings.HasSuffix(threshold.ThresholdType, ResourceAvailableMetric) {
+			val = rSum.Limit - rSum.TotalUsage
+		}
+		if thresholdPasses(threshold.ThresholdType, val, threshold.ThresholdValue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passAccounts checks the account referenced by FieldName, read out of DataDB
+// an unknown account will not pass the rule
+func (fltr *FilterRule) passAccounts(dm *DataManager, tenant string, dP DataProvider) (bool, error) {
+	acntID, err := dP.FieldAsString(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	acnt, err := dm.DataDB().GetAccount(utils.ConcatenatedKey(tenant, acntID))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, threshold := range fltr.acntThresholds {
+		if threshold.ThresholdType == MetaDisabled {
+			if acnt.Disabled {
+				return true, nil
+			}
+			continue
+		}
+		if thresholdPasses(threshold.ThresholdType,
+			acnt.BalanceMap[threshold.BalanceType].GetTotalValue(), threshold.ThresholdValue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (fltr *FilterRule) passGreaterThan(dP DataProvider) (bool, error) {
 	fldIf, err := dP.FieldAsInterface(strings.Split(fltr.FieldName, utils.HIERARCHY_SEP))
 	if err != nil {
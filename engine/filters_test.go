@@ -133,7 +133,7 @@ func TestFilterPassDestinations(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	if passes, err := rf.passDestinations(cd); err != nil {
+	if passes, err := rf.passDestinations(dm, cd); err != nil {
 		t.Error(err)
 	} else if !passes {
 		t.Error("Not passing")
@@ -142,13 +142,71 @@ func TestFilterPassDestinations(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	if passes, err := rf.passDestinations(cd); err != nil {
+	if passes, err := rf.passDestinations(dm, cd); err != nil {
 		t.Error(err)
 	} else if passes {
 		t.Error("Passing")
 	}
 }
 
+func TestFilterPassTimings(t *testing.T) {
+	if err := dm.SetTiming(&utils.TPTiming{
+		ID:        "TM_PEAK",
+		WeekDays:  utils.WeekDays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		StartTime: "08:00:00",
+		EndTime:   "19:59:59",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetTiming(&utils.TPTiming{
+		ID:        "TM_OCTOBER",
+		Months:    utils.Months{time.October},
+		StartTime: utils.ASAP,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ev := NavigableMap(map[string]interface{}{
+		utils.AnswerTime: time.Date(2013, time.October, 7, 14, 50, 0, 0, time.UTC), // Monday
+		utils.SetupTime:  "2013-10-06T14:50:00Z",                                   // Sunday
+	})
+	rf, err := NewFilterRule(MetaTimings, utils.AnswerTime, []string{"TM_PEAK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.passTimings(dm, "UTC", ev); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passing")
+	}
+	rf, err = NewFilterRule(MetaTimings, utils.SetupTime, []string{"TM_PEAK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.passTimings(dm, "UTC", ev); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Passing")
+	}
+	rf, err = NewFilterRule(MetaTimings, utils.SetupTime, []string{"TM_MISSING", "TM_PEAK", "TM_OCTOBER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.passTimings(dm, "UTC", ev); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passing")
+	}
+	rf, err = NewFilterRule(MetaTimings, utils.Usage, []string{"TM_OCTOBER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.passTimings(dm, "UTC", ev); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Passing on missing field")
+	}
+}
+
 func TestFilterPassGreaterThan(t *testing.T) {
 	rf, err := NewFilterRule(MetaLessThan, "ASR", []string{"40"})
 	if err != nil {
@@ -416,3 +474,373 @@ func TestPassFiltersForEventWithEmptyFilter(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", true, pass)
 	}
 }
+
+func TestFilterPassNegated(t *testing.T) {
+	ev := NavigableMap(map[string]interface{}{
+		utils.Account:     "1001",
+		utils.Destination: "+4986517174963",
+	})
+	for _, tc := range []struct {
+		rfType    string
+		fieldName string
+		vals      []string
+		pass      bool
+	}{
+		{MetaNotString, utils.Account, []string{"1001"}, false},
+		{MetaNotString, utils.Account, []string{"1002"}, true},
+		{MetaNotString, utils.Subject, []string{"1001"}, true}, // missing field
+		{MetaNotPrefix, utils.Destination, []string{"+49"}, false},
+		{MetaNotPrefix, utils.Destination, []string{"+40"}, true},
+		{MetaNotRSR, "", []string{"Account(~^10\\d\\d$)"}, false},
+		{MetaNotRSR, "", []string{"Account(~^20\\d\\d$)"}, true},
+	} {
+		rf, err := NewFilterRule(tc.rfType, tc.fieldName, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(dm, ev, nil); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("rule: %+v, expecting: %v, received: %v", rf, tc.pass, pass)
+		}
+	}
+}
+
+func TestFilterPassExistsEmpty(t *testing.T) {
+	ev := NavigableMap(map[string]interface{}{
+		utils.Account:     "1001",
+		utils.Subject:     "",
+		utils.Destination: nil,
+		"ExtraFields":     map[string]interface{}{},
+	})
+	for _, tc := range []struct {
+		rfType    string
+		fieldName string
+		pass      bool
+	}{
+		{MetaExists, utils.Account, true},
+		{MetaExists, utils.Subject, true},
+		{MetaExists, utils.Category, false},
+		{MetaNotExists, utils.Category, true},
+		{MetaNotExists, utils.Account, false},
+		{MetaEmpty, utils.Account, false},
+		{MetaEmpty, utils.Subject, true},
+		{MetaEmpty, utils.Destination, true},
+		{MetaEmpty, "ExtraFields", true},
+		{MetaEmpty, utils.Category, false},
+		{MetaNotEmpty, utils.Account, true},
+		{MetaNotEmpty, utils.Subject, false},
+		{MetaNotEmpty, utils.Category, true},
+	} {
+		rf, err := NewFilterRule(tc.rfType, tc.fieldName, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(dm, ev, nil); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("rule: %+v, expecting: %v, received: %v", rf, tc.pass, pass)
+		}
+	}
+	if _, err := NewFilterRule(MetaExists, "", nil); err == nil {
+		t.Error("expecting error for missing FieldName")
+	}
+	if _, err := NewFilterRule(MetaNotString, utils.Account, nil); err == nil {
+		t.Error("expecting error for missing Values")
+	}
+}
+
+func TestInlineFilterPassNegatedAndExists(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmFilterPass := NewDataManager(data)
+	cfg, _ := config.NewDefaultCGRConfig()
+	filterS := FilterS{
+		cfg: cfg,
+		dm:  dmFilterPass,
+	}
+	ev := NavigableMap(map[string]interface{}{
+		utils.Account: "1001",
+	})
+	if pass, err := filterS.Pass("cgrates.org",
+		[]string{"*notstring:Account:1002;1003", "*exists:Account"}, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Errorf("Expecting: %+v, received: %+v", true, pass)
+	}
+	if pass, err := filterS.Pass("cgrates.org",
+		[]string{"*notexists:Account:"}, ev); err != nil {
+		t.Error(err)
+	} else if pass {
+		t.Errorf("Expecting: %+v, received: %+v", false, pass)
+	}
+}
+
+func TestFilterPassSuffixIPNetRegex(t *testing.T) {
+	ev := NavigableMap(map[string]interface{}{
+		utils.Account:     "1001",
+		utils.Destination: "+4986517174963",
+		"RemoteAddr":      "192.168.56.203",
+		"RemoteAddr6":     "2001:db8::68",
+		"Domain":          "sip.cgrates.org",
+	})
+	for _, tc := range []struct {
+		rfType    string
+		fieldName string
+		vals      []string
+		pass      bool
+	}{
+		{MetaSuffix, utils.Destination, []string{"963"}, true},
+		{MetaSuffix, utils.Destination, []string{"111", "4963"}, true},
+		{MetaSuffix, utils.Destination, []string{"+49"}, false},
+		{MetaSuffix, utils.Category, []string{"call"}, false},
+		{MetaNotSuffix, utils.Destination, []string{"963"}, false},
+		{MetaIPNet, "RemoteAddr", []string{"192.168.56.0/24"}, true},
+		{MetaIPNet, "RemoteAddr", []string{"10.0.0.0/8", "192.168.0.0/16"}, true},
+		{MetaIPNet, "RemoteAddr", []string{"192.168.57.0/24"}, false},
+		{MetaIPNet, "RemoteAddr6", []string{"2001:db8::/32"}, true},
+		{MetaIPNet, "RemoteAddr6", []string{"192.168.56.0/24"}, false},
+		{MetaIPNet, "Domain", []string{"192.168.56.0/24"}, false},
+		{MetaNotIPNet, "RemoteAddr", []string{"10.0.0.0/8"}, true},
+		{MetaRegex, "Domain", []string{`^sip\.`}, true},
+		{MetaRegex, utils.Account, []string{`^10\d\d$`}, true},
+		{MetaRegex, utils.Account, []string{`^20\d\d$`}, false},
+		{MetaNotRegex, utils.Account, []string{`^20\d\d$`}, true},
+	} {
+		rf, err := NewFilterRule(tc.rfType, tc.fieldName, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(dm, ev, nil); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("rule: %+v, expecting: %v, received: %v", rf, tc.pass, pass)
+		}
+	}
+	if _, err := NewFilterRule(MetaIPNet, "RemoteAddr", []string{"192.168.56.1"}); err == nil {
+		t.Error("expecting error for invalid CIDR")
+	}
+	if _, err := NewFilterRule(MetaRegex, utils.Account, []string{"10(01"}); err == nil {
+		t.Error("expecting error for invalid regexp")
+	}
+}
+
+func TestFilterSPassFilterGroups(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmGrp := NewDataManager(data)
+	fltrS := &FilterS{dm: dmGrp}
+	for _, fltr := range []*Filter{
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_GRP_ACNT",
+			Rules: []*FilterRule{&FilterRule{Type: MetaString, FieldName: utils.Account, Values: []string{"1001"}}}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_GRP_SUBJ",
+			Rules: []*FilterRule{&FilterRule{Type: MetaPrefix, FieldName: utils.Subject, Values: []string{"10"}}}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_GRP_OR",
+			Rules: []*FilterRule{&FilterRule{Type: MetaOr, Values: []string{"FLTR_GRP_ACNT", "FLTR_GRP_SUBJ"}}}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_GRP_AND",
+			Rules: []*FilterRule{&FilterRule{Type: MetaAnd, Values: []string{"FLTR_GRP_ACNT", "FLTR_GRP_SUBJ"}}}},
+		&Filter{Tenant: "cgrates.org", ID: "FLTR_GRP_NOT",
+			Rules: []*FilterRule{&FilterRule{Type: MetaNot, Values: []string{"FLTR_GRP_OR"}}}},
+	} {
+		if err := dmGrp.SetFilter(fltr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tc := range []struct {
+		ev                       map[string]interface{}
+		passOr, passAnd, passNot bool
+	}{
+		{map[string]interface{}{utils.Account: "1001", utils.Subject: "1002"}, true, true, false},
+		{map[string]interface{}{utils.Account: "1001", utils.Subject: "2002"}, true, false, false},
+		{map[string]interface{}{utils.Account: "2001", utils.Subject: "1002"}, true, false, false},
+		{map[string]interface{}{utils.Account: "2001", utils.Subject: "2002"}, false, false, true},
+	} {
+		for fltrID, expPass := range map[string]bool{"FLTR_GRP_OR": tc.passOr,
+			"FLTR_GRP_AND": tc.passAnd, "FLTR_GRP_NOT": tc.passNot} {
+			if pass, err := fltrS.Pass("cgrates.org", []string{fltrID}, NavigableMap(tc.ev)); err != nil {
+				t.Error(err)
+			} else if pass != expPass {
+				t.Errorf("filter: %s, event: %+v, expecting: %v, received: %v", fltrID, tc.ev, expPass, pass)
+			}
+		}
+	}
+	ev := NavigableMap(map[string]interface{}{utils.Account: "2001", utils.Subject: "1002"})
+	if pass, err := fltrS.Pass("cgrates.org", []string{"*or:FLTR_GRP_ACNT;FLTR_GRP_SUBJ"}, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("expecting inline *or filter to pass")
+	}
+	if _, err := NewFilterRule(MetaOr, "", nil); err == nil {
+		t.Error("expecting error for missing Values")
+	}
+}
+
+func TestFilterSetFilterCycle(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmCycle := NewDataManager(data)
+	if err := dmCycle.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_CYCLE_A",
+		Rules: []*FilterRule{&FilterRule{Type: MetaOr, Values: []string{"FLTR_CYCLE_B"}}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmCycle.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_CYCLE_B",
+		Rules: []*FilterRule{&FilterRule{Type: MetaNot, Values: []string{"FLTR_CYCLE_C"}}}}); err != nil {
+		t.Fatal(err)
+	}
+	expErr := "FILTER_CYCLE: FLTR_CYCLE_C->FLTR_CYCLE_A->FLTR_CYCLE_B->FLTR_CYCLE_C"
+	if err := dmCycle.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_CYCLE_C",
+		Rules: []*FilterRule{&FilterRule{Type: MetaAnd, Values: []string{"FLTR_CYCLE_A"}}}}); err == nil ||
+		err.Error() != expErr {
+		t.Errorf("expecting: %s, received: %v", expErr, err)
+	}
+	if _, err := dmCycle.GetFilter("cgrates.org", "FLTR_CYCLE_C", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := dmCycle.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_CYCLE_SELF",
+		Rules: []*FilterRule{&FilterRule{Type: MetaOr, Values: []string{"FLTR_CYCLE_SELF"}}}}); err == nil {
+		t.Error("expecting error for filter referencing itself")
+	}
+}
+
+// testResourceS mocks ResourceS returning the summaries of the resources
+type testResourceS map[string]*ResourceSummary
+
+func (trs testResourceS) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.ResourceSv1GetResourceSummary {
+		return utils.ErrNotImplemented
+	}
+	rSum, has := trs[args.(*utils.TenantID).ID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	*reply.(*ResourceSummary) = *rSum
+	return nil
+}
+
+func TestFilterSPassResources(t *testing.T) {
+	fltrS := &FilterS{resSConns: testResourceS{
+		"RES_CALLS": &ResourceSummary{Tenant: "cgrates.org", ID: "RES_CALLS", TotalUsage: 40, Limit: 50},
+	}}
+	ev := NavigableMap(map[string]interface{}{utils.Account: "1001"})
+	for _, tc := range []struct {
+		rfType string
+		vals   []string
+		pass   bool
+	}{
+		{MetaResources, []string{"RES_CALLS:*max_usage:50"}, true},
+		{MetaResources, []string{"RES_CALLS:*max_usage:40"}, false},
+		{MetaResources, []string{"RES_CALLS:*min_usage:40"}, true},
+		{MetaResources, []string{"RES_CALLS:*min_available:20"}, false},
+		{MetaResources, []string{"RES_CALLS:*min_available:20", "RES_CALLS:*max_available:20"}, true},
+		{MetaResources, []string{"RES_UNKNOWN:*max_usage:50"}, false},
+		{MetaNotResources, []string{"RES_CALLS:*max_usage:40"}, true},
+	} {
+		rf, err := NewFilterRule(tc.rfType, "", tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := fltrS.passRule("cgrates.org", rf, ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("rule: %+v, expecting: %v, received: %v", rf, tc.pass, pass)
+		}
+	}
+	for _, vals := range [][]string{{"RES_CALLS:*max_usage"}, {"RES_CALLS:*avg_usage:50"},
+		{"RES_CALLS:*max_units:50"}, {"RES_CALLS:*max_usage:fifty"}} {
+		if _, err := NewFilterRule(MetaResources, "", vals); err == nil {
+			t.Errorf("expecting error for values: %+v", vals)
+		}
+	}
+	rf, _ := NewFilterRule(MetaResources, "", []string{"RES_CALLS:*max_usage:50"})
+	if _, err := new(FilterS).passRule("cgrates.org", rf, ev); err == nil {
+		t.Error("expecting error for missing ResourceS connection")
+	}
+}
+
+func TestFilterSPassAccounts(t *testing.T) {
+	if err := dm.DataDB().SetAccount(&Account{ID: "cgrates.org:FLTR_ACNT_1",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{&Balance{Value: 5}, &Balance{Value: 3}},
+			utils.VOICE:    Balances{&Balance{Value: 60}},
+		}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.DataDB().SetAccount(&Account{ID: "cgrates.org:FLTR_ACNT_2",
+		Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	fltrS := &FilterS{dm: dm}
+	for _, tc := range []struct {
+		rfType string
+		acntID string
+		vals   []string
+		pass   bool
+	}{
+		{MetaAccounts, "FLTR_ACNT_1", []string{"*monetary:*max_balance:10"}, true},
+		{MetaAccounts, "FLTR_ACNT_1", []string{"*monetary:*max_balance:8"}, false},
+		{MetaAccounts, "FLTR_ACNT_1", []string{"*monetary:*min_balance:8"}, true},
+		{MetaAccounts, "FLTR_ACNT_1", []string{"*data:*min_balance:1"}, false},
+		{MetaAccounts, "FLTR_ACNT_1", []string{MetaDisabled}, false},
+		{MetaAccounts, "FLTR_ACNT_2", []string{MetaDisabled}, true},
+		{MetaAccounts, "FLTR_ACNT_UNKNOWN", []string{"*monetary:*max_balance:10"}, false},
+		{MetaNotAccounts, "FLTR_ACNT_1", []string{MetaDisabled}, true},
+	} {
+		rf, err := NewFilterRule(tc.rfType, utils.Account, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ev := NavigableMap(map[string]interface{}{utils.Account: tc.acntID})
+		if pass, err := fltrS.passRule("cgrates.org", rf, ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("rule: %+v, account: %s, expecting: %v, received: %v", rf, tc.acntID, tc.pass, pass)
+		}
+	}
+	if _, err := NewFilterRule(MetaAccounts, utils.Account, []string{"*monetary:*max_usage:10"}); err == nil {
+		t.Error("expecting error for unsupported ThresholdType")
+	}
+}
+
+func TestFilterSPassRuleOwnDataManager(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmOwn := NewDataManager(data)
+	if err := dmOwn.SetTiming(&utils.TPTiming{
+		ID:        "TM_OWN_OCTOBER",
+		Months:    utils.Months{time.October},
+		StartTime: utils.ASAP,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmOwn.DataDB().SetAccount(&Account{ID: "cgrates.org:FLTR_OWN_ACNT",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{&Balance{Value: 5}},
+		}}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.NewDefaultCGRConfig()
+	fltrS := &FilterS{cfg: cfg, dm: dmOwn}
+	ev := NavigableMap(map[string]interface{}{
+		utils.Account:    "FLTR_OWN_ACNT",
+		utils.AnswerTime: time.Date(2013, time.October, 7, 14, 50, 0, 0, time.UTC),
+	})
+	rf, err := NewFilterRule(MetaTimings, utils.AnswerTime, []string{"TM_OWN_OCTOBER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pass, err := fltrS.passRule("cgrates.org", rf, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("timing not read out of the FilterS DataManager")
+	}
+	// direct callers pass their own DataManager
+	if pass, err := rf.Pass(dmOwn, ev, nil); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("timing not read out of the DataManager passed")
+	}
+	if rf, err = NewFilterRule(MetaAccounts, utils.Account, []string{"*monetary:*min_balance:3"}); err != nil {
+		t.Fatal(err)
+	}
+	if pass, err := fltrS.passRule("cgrates.org", rf, ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("account not read out of the FilterS DataManager")
+	}
+}
@@ -19,7 +19,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -27,8 +30,118 @@ import (
 type Attribute struct {
 	FieldName  string
 	Initial    interface{}
+	Type       string // <""|*constant|*composed|*sum|*usage_difference>, empty for *constant
 	Substitute interface{}
 	Append     bool
+	substitute utils.RSRFields // compiled Substitute for the types other than *constant
+}
+
+// Compile parses the Substitute as RSR template for the dynamic types
+func (attr *Attribute) Compile() (err error) {
+	switch attr.Type {
+	case "", utils.META_CONSTANT:
+		return
+	case utils.META_COMPOSED, utils.MetaSum, utils.MetaUsageDifference:
+	default:
+		return fmt.Errorf("unsupported attribute type: <%s>", attr.Type)
+	}
+	substStr, err := utils.IfaceAsString(attr.Substitute)
+	if err != nil {
+		return
+	}
+	if len(substStr) != 0 {
+		if attr.substitute, err = utils.ParseRSRFieldsFromSlice(
+			splitRSRRules(substStr, utils.INFIELD_SEP)); err != nil {
+			return
+		}
+	}
+	if len(attr.substitute) == 0 {
+		return fmt.Errorf("empty substitute for attribute type: <%s>", attr.Type)
+	}
+	if attr.Type == utils.MetaUsageDifference && len(attr.substitute) != 2 {
+		return fmt.Errorf("attribute type: <%s> needs two substitute fields, received: <%s>",
+			attr.Type, substStr)
+	}
+	return
+}
+
+// splitRSRRules splits the substitute rules on sep, ignoring the separators inside converters ({*...})
+// so chained converters like Usage{*duration_seconds;*round:2} are kept within their rule
+func splitRSRRules(fldsStr, sep string) (rules []string) {
+	var inConverters bool
+	var ruleStart int
+	for i := 0; i < len(fldsStr); i++ {
+		switch {
+		case strings.HasPrefix(fldsStr[i:], "{*"):
+			inConverters = true
+		case inConverters && fldsStr[i] == '}':
+			inConverters = false
+		case !inConverters && strings.HasPrefix(fldsStr[i:], sep):
+			rules = append(rules, fldsStr[ruleStart:i])
+			ruleStart = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+	return append(rules, fldsStr[ruleStart:])
+}
+
+// parseSubstitute returns the values of the substitute template fields out of event
+func (attr *Attribute) parseSubstitute(ev map[string]interface{}) (vals []string, err error) {
+	vals = make([]string, len(attr.substitute))
+	for i, rsrFld := range attr.substitute {
+		var fldIface interface{}
+		if !rsrFld.IsStatic() {
+			var has bool
+			if fldIface, has = ev[rsrFld.Id]; !has {
+				return nil, utils.NewErrMandatoryIeMissing(rsrFld.Id)
+			}
+		}
+		if vals[i], err = rsrFld.Parse(fldIface); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// SubstituteValue returns the value to be set in the event, based on the attribute type
+// timezone is used when parsing the time fields of *usage_difference
+func (attr *Attribute) SubstituteValue(ev map[string]interface{}, timezone string) (subst interface{}, err error) {
+	if attr.Type == "" || attr.Type == utils.META_CONSTANT {
+		return attr.Substitute, nil
+	}
+	var vals []string
+	if vals, err = attr.parseSubstitute(ev); err != nil {
+		return
+	}
+	switch attr.Type {
+	case utils.META_COMPOSED:
+		var out string
+		for _, val := range vals {
+			out += val
+		}
+		return out, nil
+	case utils.MetaSum:
+		var sum float64
+		for _, val := range vals {
+			fltVal, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, err
+			}
+			sum += fltVal
+		}
+		return sum, nil
+	case utils.MetaUsageDifference:
+		tEnd, err := utils.ParseTimeDetectLayout(vals[0], timezone)
+		if err != nil {
+			return nil, err
+		}
+		tStart, err := utils.ParseTimeDetectLayout(vals[1], timezone)
+		if err != nil {
+			return nil, err
+		}
+		return tEnd.Sub(tStart), nil
+	}
+	return nil, fmt.Errorf("unsupported attribute type: <%s>", attr.Type)
 }
 
 type AttributeProfile struct {
@@ -46,6 +159,33 @@ func (als *AttributeProfile) TenantID() string {
 	return utils.ConcatenatedKey(als.Tenant, als.ID)
 }
 
+// compiledAttributes returns the compiled Attributes indexed on FieldName and Initial
+func (als *AttributeProfile) compiledAttributes() (attrs map[string]map[interface{}]*Attribute, err error) {
+	attrs = make(map[string]map[interface{}]*Attribute)
+	for _, attr := range als.Attributes {
+		cmpAttr := &Attribute{
+			FieldName:  attr.FieldName,
+			Initial:    attr.Initial,
+			Type:       attr.Type,
+			Substitute: attr.Substitute,
+			Append:     attr.Append,
+		}
+		if err = cmpAttr.Compile(); err != nil {
+			return nil, fmt.Errorf("%s for AttributeProfile: %s, field: %s",
+				err.Error(), als.TenantID(), attr.FieldName)
+		}
+		attrs[attr.FieldName] = make(map[interface{}]*Attribute)
+		attrs[attr.FieldName][attr.Initial] = cmpAttr
+	}
+	return
+}
+
+// compile populates the attributes used when processing events
+func (als *AttributeProfile) compile() (err error) {
+	als.attributes, err = als.compiledAttributes()
+	return
+}
+
 // AttributeProfiles is a sortable list of Attribute profiles
 type AttributeProfiles []*AttributeProfile
 
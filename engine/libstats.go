@@ -39,6 +39,8 @@ type StatQueueProfile struct {
 	Stored             bool
 	Weight             float64
 	MinItems           int
+	BucketInterval     time.Duration // aggregate events into time buckets of this size instead of storing them individually, an event processed again within its bucket is counted once
+	Disabled           bool          // not matching events, ie: disabled by *disable_profile action
 }
 
 func (sqp *StatQueueProfile) TenantID() string {
@@ -54,8 +56,9 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 			EventID    string
 			ExpiryTime *time.Time
 		}, len(sq.SQItems)),
-		SQMetrics: make(map[string][]byte, len(sq.SQMetrics)),
-		MinItems:  sq.MinItems,
+		SQMetrics:      make(map[string][]byte, len(sq.SQMetrics)),
+		MinItems:       sq.MinItems,
+		BucketEventIDs: sq.BucketEventIDs,
 	}
 	for i, sqItm := range sq.SQItems {
 		sSQ.SQItems[i] = sqItm
@@ -78,8 +81,9 @@ type StoredStatQueue struct {
 		EventID    string     // Bounded to the original utils.CGREvent
 		ExpiryTime *time.Time // Used to auto-expire events
 	}
-	SQMetrics map[string][]byte
-	MinItems  int
+	SQMetrics      map[string][]byte
+	MinItems       int
+	BucketEventIDs utils.StringMap
 }
 
 // SqID will compose the unique identifier for the StatQueue out of Tenant and ID
@@ -96,8 +100,9 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 			EventID    string
 			ExpiryTime *time.Time
 		}, len(ssq.SQItems)),
-		SQMetrics: make(map[string]StatMetric, len(ssq.SQMetrics)),
-		MinItems:  ssq.MinItems,
+		SQMetrics:      make(map[string]StatMetric, len(ssq.SQMetrics)),
+		MinItems:       ssq.MinItems,
+		BucketEventIDs: ssq.BucketEventIDs,
 	}
 	for i, sqItm := range ssq.SQItems {
 		sq.SQItems[i] = sqItm
@@ -122,11 +127,12 @@ type StatQueue struct {
 		EventID    string     // Bounded to the original utils.CGREvent
 		ExpiryTime *time.Time // Used to auto-expire events
 	}
-	SQMetrics map[string]StatMetric
-	MinItems  int
-	sqPrfl    *StatQueueProfile
-	dirty     *bool          // needs save
-	ttl       *time.Duration // timeToLeave, picked on each init
+	SQMetrics      map[string]StatMetric
+	MinItems       int
+	BucketEventIDs utils.StringMap // events aggregated into the last bucket, not counted again if processed twice
+	sqPrfl         *StatQueueProfile
+	dirty          *bool          // needs save
+	ttl            *time.Duration // timeToLeave, picked on each init
 }
 
 // SqID will compose the unique identifier for the StatQueue out of Tenant and ID
@@ -137,6 +143,11 @@ func (sq *StatQueue) TenantID() string {
 // ProcessEvent processes a utils.CGREvent, returns true if processed
 func (sq *StatQueue) ProcessEvent(ev *utils.CGREvent) (err error) {
 	sq.remExpired()
+	if sq.sqPrfl.BucketInterval > 0 {
+		sq.addBucketedEvent(ev)
+		return
+	}
+	sq.remSQItem(ev.TenantID()) // processed again, replacing its previous values
 	sq.remOnQueueLength()
 	sq.addStatEvent(ev)
 	return
@@ -170,6 +181,18 @@ func (sq *StatQueue) remExpired() {
 	sq.SQItems = sq.SQItems[*expIdx+1:]
 }
 
+// remSQItem removes the queued item with itemID, together with its values in metrics
+func (sq *StatQueue) remSQItem(itemID string) {
+	for i, item := range sq.SQItems {
+		if item.EventID != itemID {
+			continue
+		}
+		sq.remEventWithID(itemID)
+		sq.SQItems = append(sq.SQItems[:i], sq.SQItems[i+1:]...)
+		return
+	}
+}
+
 // remOnQueueLength removes elements based on QueueLength setting
 func (sq *StatQueue) remOnQueueLength() {
 	if sq.sqPrfl.QueueLength <= 0 { // infinite length
@@ -182,8 +205,22 @@ func (sq *StatQueue) remOnQueueLength() {
 	}
 }
 
+// addSQItem queues a new item, expiring at expFrom + ttl
+func (sq *StatQueue) addSQItem(itemID string, expFrom time.Time) {
+	var expTime *time.Time
+	if sq.ttl != nil && *sq.ttl > 0 {
+		expTime = utils.TimePointer(expFrom.Add(*sq.ttl))
+	}
+	sq.SQItems = append(sq.SQItems,
+		struct {
+			EventID    string
+			ExpiryTime *time.Time
+		}{itemID, expTime})
+}
+
 // addStatEvent computes metrics for an event
 func (sq *StatQueue) addStatEvent(ev *utils.CGREvent) {
+	sq.addSQItem(ev.TenantID(), time.Now())
 	for metricID, metric := range sq.SQMetrics {
 		if err := metric.AddEvent(ev); err != nil {
 			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, add eventID: %s, error: %s",
@@ -192,6 +229,31 @@ func (sq *StatQueue) addStatEvent(ev *utils.CGREvent) {
 	}
 }
 
+// addBucketedEvent computes metrics for an event, aggregating it into the time bucket it belongs to
+// the buckets are queued as items and expire as a whole, QueueLength limiting their number
+func (sq *StatQueue) addBucketedEvent(ev *utils.CGREvent) {
+	bktStart := time.Now().Truncate(sq.sqPrfl.BucketInterval)
+	bktID := bktStart.UTC().Format(time.RFC3339Nano)
+	if len(sq.SQItems) == 0 ||
+		sq.SQItems[len(sq.SQItems)-1].EventID != bktID { // first event in the bucket
+		sq.remOnQueueLength()
+		sq.addSQItem(bktID, bktStart)
+		sq.BucketEventIDs = make(utils.StringMap)
+	} else if sq.BucketEventIDs.HasKey(ev.TenantID()) { // already aggregated, its value cannot be replaced
+		return
+	}
+	if sq.BucketEventIDs == nil {
+		sq.BucketEventIDs = make(utils.StringMap)
+	}
+	sq.BucketEventIDs[ev.TenantID()] = true
+	for metricID, metric := range sq.SQMetrics {
+		if err := metric.AddEventToBucket(bktID, ev); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, add eventID: %s to bucket: %s, error: %s",
+				metricID, ev.TenantID(), bktID, err.Error()))
+		}
+	}
+}
+
 // StatQueues is a sortable list of StatQueue
 type StatQueues []*StatQueue
 
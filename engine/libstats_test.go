@@ -52,9 +52,9 @@ func TestStatRemEventWithID(t *testing.T) {
 			utils.MetaASR: &StatASR{
 				Answered: 1,
 				Count:    2,
-				Events: map[string]bool{
-					"cgrates.org:TestRemEventWithID_1": true,
-					"cgrates.org:TestRemEventWithID_2": false,
+				Events: map[string]*StatWithCount{
+					"cgrates.org:TestRemEventWithID_1": &StatWithCount{Stat: 1, Count: 1},
+					"cgrates.org:TestRemEventWithID_2": &StatWithCount{Stat: 0, Count: 1},
 				},
 			},
 		},
@@ -95,10 +95,10 @@ func TestStatRemExpired(t *testing.T) {
 			utils.MetaASR: &StatASR{
 				Answered: 2,
 				Count:    3,
-				Events: map[string]bool{
-					"cgrates.org:TestStatRemExpired_1": true,
-					"cgrates.org:TestStatRemExpired_2": false,
-					"cgrates.org:TestStatRemExpired_3": true,
+				Events: map[string]*StatWithCount{
+					"cgrates.org:TestStatRemExpired_1": &StatWithCount{Stat: 1, Count: 1},
+					"cgrates.org:TestStatRemExpired_2": &StatWithCount{Stat: 0, Count: 1},
+					"cgrates.org:TestStatRemExpired_3": &StatWithCount{Stat: 1, Count: 1},
 				},
 			},
 		},
@@ -179,8 +179,8 @@ func TestStatAddStatEvent(t *testing.T) {
 			utils.MetaASR: &StatASR{
 				Answered: 1,
 				Count:    1,
-				Events: map[string]bool{
-					"cgrates.org:TestStatRemExpired_1": true,
+				Events: map[string]*StatWithCount{
+					"cgrates.org:TestStatRemExpired_1": &StatWithCount{Stat: 1, Count: 1},
 				},
 			},
 		},
@@ -198,10 +198,94 @@ func TestStatAddStatEvent(t *testing.T) {
 	}
 	ev1.Event = map[string]interface{}{
 		utils.AnswerTime: time.Now()}
-	sq.addStatEvent(ev1)
-	if asr := asrMetric.GetFloat64Value(); asr != 66.66667 {
+	sq.addStatEvent(ev1) // processed again, replacing the previous value
+	if asr := asrMetric.GetFloat64Value(); asr != 100 {
 		t.Errorf("received ASR: %v", asr)
-	} else if asrMetric.Answered != 2 || asrMetric.Count != 3 {
+	} else if asrMetric.Answered != 2 || asrMetric.Count != 2 {
 		t.Errorf("ASR: %v", asrMetric)
 	}
 }
+
+func TestStatProcessEventTwice(t *testing.T) {
+	asr, _ := NewASR(0, "")
+	acd, _ := NewACD(0, "")
+	sq = &StatQueue{
+		sqPrfl:    &StatQueueProfile{QueueLength: 2},
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr, utils.MetaACD: acd},
+	}
+	ev1 := &utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatProcessEventTwice_1",
+		Event: map[string]interface{}{
+			utils.AnswerTime: time.Now(),
+			utils.Usage:      time.Duration(10 * time.Second)}}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatProcessEventTwice_2",
+		Event: map[string]interface{}{
+			utils.AnswerTime: time.Now(),
+			utils.Usage:      time.Duration(20 * time.Second)}}
+	sq.ProcessEvent(ev1)
+	sq.ProcessEvent(ev2)
+	ev1.Event[utils.Usage] = time.Duration(30 * time.Second)
+	sq.ProcessEvent(ev1)
+	if len(sq.SQItems) != 2 {
+		t.Fatalf("wrong items: %+v", sq.SQItems)
+	} else if sq.SQItems[0].EventID != ev2.TenantID() ||
+		sq.SQItems[1].EventID != ev1.TenantID() {
+		t.Errorf("wrong items: %+v", sq.SQItems)
+	}
+	if v := asr.GetFloat64Value(); v != 100 {
+		t.Errorf("received ASR: %v", v)
+	} else if asrMetric := asr.(*StatASR); asrMetric.Count != 2 {
+		t.Errorf("ASR: %+v", asrMetric)
+	}
+	if v := acd.GetFloat64Value(); v != 25 {
+		t.Errorf("received ACD: %v", v)
+	}
+}
+
+func TestStatAddBucketedEvent(t *testing.T) {
+	asr, _ := NewASR(0, "")
+	sq = &StatQueue{
+		sqPrfl: &StatQueueProfile{
+			QueueLength:    2,
+			BucketInterval: time.Duration(time.Hour),
+		},
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr},
+		ttl:       utils.DurationPointer(time.Duration(2 * time.Hour)),
+	}
+	asrMetric := sq.SQMetrics[utils.MetaASR].(*StatASR)
+	sq.ProcessEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatAddBucketedEvent_1"})
+	sq.ProcessEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatAddBucketedEvent_2",
+		Event: map[string]interface{}{
+			utils.AnswerTime: time.Now()}})
+	if len(sq.SQItems) != 1 {
+		t.Fatalf("wrong items: %+v", sq.SQItems)
+	} else if len(asrMetric.Events) != 1 {
+		t.Errorf("unexpected Events in asrMetric: %+v", asrMetric.Events)
+	} else if asr := asrMetric.GetFloat64Value(); asr != 50 {
+		t.Errorf("received ASR: %v", asr)
+	}
+	// processed again within the same bucket, counted once
+	sq.ProcessEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatAddBucketedEvent_2",
+		Event: map[string]interface{}{
+			utils.AnswerTime: time.Now()}})
+	if asrMetric.Answered != 1 || asrMetric.Count != 2 {
+		t.Errorf("ASR: %+v", asrMetric)
+	}
+	bktStart := time.Now().Truncate(time.Hour)
+	if sq.SQItems[0].EventID != bktStart.UTC().Format(time.RFC3339Nano) {
+		t.Errorf("wrong bucket ID: %s", sq.SQItems[0].EventID)
+	} else if !sq.SQItems[0].ExpiryTime.Equal(bktStart.Add(2 * time.Hour)) {
+		t.Errorf("wrong bucket expiry: %v", sq.SQItems[0].ExpiryTime)
+	}
+	// simulate an older bucket which is expired by now
+	sq.SQItems[0].ExpiryTime = utils.TimePointer(time.Now())
+	sq.ProcessEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "TestStatAddBucketedEvent_3",
+		Event: map[string]interface{}{
+			utils.AnswerTime: time.Now()}})
+	if len(sq.SQItems) != 1 {
+		t.Errorf("wrong items: %+v", sq.SQItems)
+	} else if asr := asrMetric.GetFloat64Value(); asr != 100 {
+		t.Errorf("received ASR: %v", asr)
+	} else if asrMetric.Answered != 1 || asrMetric.Count != 1 {
+		t.Errorf("ASR: %+v", asrMetric)
+	}
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -79,12 +80,166 @@ func (sSpls *SortedSuppliers) SortCost() {
 	})
 }
 
+// SortHighestCost is part of sort interface,
+// sort based on Cost, most expensive first, with fallback on Weight
+func (sSpls *SortedSuppliers) SortHighestCost() {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		if sSpls.SortedSuppliers[i].SortingData[utils.Cost].(float64) == sSpls.SortedSuppliers[j].SortingData[utils.Cost].(float64) {
+			return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+		}
+		return sSpls.SortedSuppliers[i].SortingData[utils.Cost].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Cost].(float64)
+	})
+}
+
+// SortLoadDistribution is part of sort interface,
+// sort based on Load, least loaded first, with fallback on Weight
+func (sSpls *SortedSuppliers) SortLoadDistribution() {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		if sSpls.SortedSuppliers[i].SortingData[utils.Load].(float64) == sSpls.SortedSuppliers[j].SortingData[utils.Load].(float64) {
+			return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+		}
+		return sSpls.SortedSuppliers[i].SortingData[utils.Load].(float64) < sSpls.SortedSuppliers[j].SortingData[utils.Load].(float64)
+	})
+}
+
+// SortResourceAvailability is part of sort interface,
+// sort based on ResourceAvailability, most available first, with fallback on Weight
+func (sSpls *SortedSuppliers) SortResourceAvailability() {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		if sSpls.SortedSuppliers[i].SortingData[utils.ResourceAvailability].(float64) == sSpls.SortedSuppliers[j].SortingData[utils.ResourceAvailability].(float64) {
+			return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+		}
+		return sSpls.SortedSuppliers[i].SortingData[utils.ResourceAvailability].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.ResourceAvailability].(float64)
+	})
+}
+
+// qosAscendingMetrics are the metrics where a lower value means better quality,
+// used when the sorting parameter does not specify the direction
+var qosAscendingMetrics = utils.StringMap{utils.MetaPDD: true}
+
+// qosSortingParam is one of the metrics the *qos strategy sorts on
+type qosSortingParam struct {
+	MetricID  string
+	Ascending bool // lower value means better quality
+}
+
+// newQOSSortingParams parses the *qos sorting parameters in the form MetricID[:*asc|*desc], eg: *asr;*acd:*asc
+func newQOSSortingParams(params []string) (qosParams []*qosSortingParam, err error) {
+	qosParams = make([]*qosSortingParam, len(params))
+	for i, param := range params {
+		paramSplt := strings.SplitN(param, utils.InInFieldSep, 2)
+		qosParams[i] = &qosSortingParam{MetricID: paramSplt[0],
+			Ascending: qosAscendingMetrics.HasKey(paramSplt[0])}
+		if len(paramSplt) == 1 {
+			continue
+		}
+		switch paramSplt[1] {
+		case utils.MetaAsc:
+			qosParams[i].Ascending = true
+		case utils.MetaDesc:
+			qosParams[i].Ascending = false
+		default:
+			return nil, fmt.Errorf("unsupported sorting direction: %s for metric: %s",
+				paramSplt[1], paramSplt[0])
+		}
+	}
+	return
+}
+
+// SortQOS is part of sort interface,
+// sort based on Stats metrics in the order of params with fallback on Weight
+// suppliers missing a metric value are considered worse than the ones having it
+func (sSpls *SortedSuppliers) SortQOS(params []*qosSortingParam) {
+	sort.Slice(sSpls.SortedSuppliers, func(i, j int) bool {
+		for _, param := range params {
+			val1 := sSpls.SortedSuppliers[i].SortingData[param.MetricID].(float64)
+			val2 := sSpls.SortedSuppliers[j].SortingData[param.MetricID].(float64)
+			if val1 == val2 {
+				continue
+			}
+			if val1 == STATS_NA {
+				return false
+			}
+			if val2 == STATS_NA {
+				return true
+			}
+			if param.Ascending {
+				return val1 < val2
+			}
+			return val1 > val2
+		}
+		return sSpls.SortedSuppliers[i].SortingData[utils.Weight].(float64) > sSpls.SortedSuppliers[j].SortingData[utils.Weight].(float64)
+	})
+}
+
+// FilterErrors removes the suppliers which failed computing their SortingData
+// returns the first error found unless ignoreErrors is set
+func (sSpls *SortedSuppliers) FilterErrors(ignoreErrors bool) (err error) {
+	var filtered []*SortedSupplier
+	for _, sSpl := range sSpls.SortedSuppliers {
+		if errStr, has := sSpl.SortingData[utils.Error]; has {
+			if !ignoreErrors {
+				return errors.New(errStr.(string))
+			}
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> profile: %s ignoring supplier with ID: %s, err: %s",
+					utils.SupplierS, sSpls.ProfileID, sSpl.SupplierID, errStr))
+			continue
+		}
+		filtered = append(filtered, sSpl)
+	}
+	sSpls.SortedSuppliers = filtered
+	return
+}
+
+// FilterMaxCost removes the suppliers with a Cost higher than maxCost
+// suppliers without Cost in their SortingData are kept
+func (sSpls *SortedSuppliers) FilterMaxCost(maxCost float64) {
+	var filtered []*SortedSupplier
+	for _, sSpl := range sSpls.SortedSuppliers {
+		if cost, has := sSpl.SortingData[utils.Cost]; has &&
+			cost.(float64) > maxCost {
+			continue
+		}
+		filtered = append(filtered, sSpl)
+	}
+	sSpls.SortedSuppliers = filtered
+}
+
+// Paginate limits the suppliers returned based on Offset and Limit
+func (sSpls *SortedSuppliers) Paginate(pag utils.Paginator) {
+	if pag.Offset != nil {
+		if *pag.Offset <= len(sSpls.SortedSuppliers) {
+			sSpls.SortedSuppliers = sSpls.SortedSuppliers[*pag.Offset:]
+		} else {
+			sSpls.SortedSuppliers = sSpls.SortedSuppliers[:0]
+		}
+	}
+	if pag.Limit != nil {
+		if *pag.Limit <= len(sSpls.SortedSuppliers) {
+			sSpls.SortedSuppliers = sSpls.SortedSuppliers[:*pag.Limit]
+		}
+	}
+}
+
 // Digest returns list of supplierIDs + parameters for easier outside access
 // format suppl1:suppl1params,suppl2:suppl2params
 func (sSpls *SortedSuppliers) Digest() string {
 	return strings.Join(sSpls.SuppliersWithParams(), utils.FIELDS_SEP)
 }
 
+// newErroredSortedSupplier builds the SortedSupplier of a supplier failing to compute its SortingData
+// errored suppliers are filtered out after sorting, based on IgnoreErrors
+func newErroredSortedSupplier(s *Supplier, err error) *SortedSupplier {
+	return &SortedSupplier{
+		SupplierID: s.ID,
+		SortingData: map[string]interface{}{
+			utils.Weight: s.Weight,
+			utils.Error:  err.Error(),
+		},
+		SupplierParameters: s.SupplierParameters}
+}
+
 type SupplierWithParams struct {
 	SupplierName   string
 	SupplierParams string
@@ -100,6 +255,10 @@ func NewSupplierSortDispatcher(lcrS *SupplierService) (ssd SupplierSortDispatche
 	ssd = make(map[string]SuppliersSorter)
 	ssd[utils.MetaWeight] = NewWeightSorter()
 	ssd[utils.MetaLeastCost] = NewLeastCostSorter(lcrS)
+	ssd[utils.MetaQOS] = NewQOSSupplierSorter(lcrS)
+	ssd[utils.MetaHighestCost] = NewHighestCostSorter(lcrS)
+	ssd[utils.MetaLoadDistribution] = NewLoadDistributionSorter(lcrS)
+	ssd[utils.MetaReas] = NewResourceAvailabilitySorter(lcrS)
 	return
 }
 
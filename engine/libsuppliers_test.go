@@ -20,6 +20,7 @@ package engine
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -218,3 +219,440 @@ func TestSortedSuppliersDigest3(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
 	}
 }
+
+func TestLibSuppliersSortQOS(t *testing.T) {
+	sSpls := &SortedSuppliers{
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 50.0,
+					utils.MetaPDD: 3.0,
+					utils.Weight:  10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 50.0,
+					utils.MetaPDD: 2.0,
+					utils.Weight:  10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: STATS_NA,
+					utils.MetaPDD: 1.0,
+					utils.Weight:  30.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier4",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 70.0,
+					utils.MetaPDD: STATS_NA,
+					utils.Weight:  5.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier5",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: STATS_NA,
+					utils.MetaPDD: 1.0,
+					utils.Weight:  40.0,
+				},
+			},
+		},
+	}
+	qosParams, err := newQOSSortingParams([]string{utils.MetaASR, utils.MetaPDD})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sSpls.SortQOS(qosParams)
+	eIDs := []string{"supplier4", "supplier2", "supplier1", "supplier5", "supplier3"}
+	if rcv := sSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	// lowest *asr first, highest *pdd on equal *asr
+	if qosParams, err = newQOSSortingParams([]string{utils.MetaASR + ":" + utils.MetaAsc,
+		utils.MetaPDD + ":" + utils.MetaDesc}); err != nil {
+		t.Fatal(err)
+	}
+	sSpls.SortQOS(qosParams)
+	eIDs = []string{"supplier1", "supplier2", "supplier4", "supplier5", "supplier3"}
+	if rcv := sSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+	if _, err = newQOSSortingParams([]string{utils.MetaACD + ":*lowest"}); err == nil {
+		t.Error("expecting error on unsupported sorting direction")
+	}
+}
+
+// testQOSStatS mocks StatS returning float metrics per queue
+type testQOSStatS map[string]map[string]float64
+
+func (tqs testQOSStatS) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.StatSv1GetQueueFloatMetrics {
+		return utils.ErrNotImplemented
+	}
+	metrics, has := tqs[args.(*utils.TenantID).ID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	*reply.(*map[string]float64) = metrics
+	return nil
+}
+
+func TestLibSuppliersQOSSorter(t *testing.T) {
+	spS := &SupplierService{statS: testQOSStatS{
+		"Stat1": map[string]float64{utils.MetaASR: 40.0, utils.MetaPDD: 2.0},
+		"Stat2": map[string]float64{utils.MetaASR: STATS_NA, utils.MetaPDD: STATS_NA},
+		"Stat3": map[string]float64{utils.MetaASR: 60.0, utils.MetaPDD: 4.0},
+	}}
+	spls := []*Supplier{
+		&Supplier{
+			ID:      "supplier1",
+			StatIDs: []string{"Stat1"},
+			Weight:  10.0,
+		},
+		&Supplier{
+			ID:      "supplier2",
+			StatIDs: []string{"Stat2", "Stat3"},
+			Weight:  20.0,
+		},
+		&Supplier{
+			ID:      "supplier3",
+			StatIDs: []string{"StatMissing"},
+			Weight:  30.0,
+		},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "supplierevent1",
+		Event:  make(map[string]interface{}),
+	}
+	qos := NewQOSSupplierSorter(spS)
+	if _, err := qos.SortSuppliers("SPL_QOS_1", spls, ev,
+		&optsGetSuppliers{}); err == nil {
+		t.Error("expecting error on missing sorting parameters")
+	}
+	eSpls := &SortedSuppliers{
+		ProfileID: "SPL_QOS_1",
+		Sorting:   utils.MetaQOS,
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 60.0,
+					utils.MetaPDD: 4.0,
+					utils.Weight:  20.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 40.0,
+					utils.MetaPDD: 2.0,
+					utils.Weight:  10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: STATS_NA,
+					utils.MetaPDD: STATS_NA,
+					utils.Weight:  30.0,
+				},
+			},
+		},
+	}
+	if rcv, err := qos.SortSuppliers("SPL_QOS_1", spls, ev,
+		&optsGetSuppliers{sortingParameters: []string{utils.MetaASR, utils.MetaPDD}}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSpls, rcv) {
+		t.Errorf("Expecting: %s, received: %s",
+			utils.ToJSON(eSpls), utils.ToJSON(rcv))
+	}
+}
+
+func TestLibSuppliersSortHighestCost(t *testing.T) {
+	sSpls := &SortedSuppliers{
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Cost:   0.1,
+					utils.Weight: 10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Cost:   0.2,
+					utils.Weight: 10.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Cost:   0.1,
+					utils.Weight: 20.0,
+				},
+			},
+		},
+	}
+	sSpls.SortHighestCost()
+	eIDs := []string{"supplier2", "supplier3", "supplier1"}
+	if rcv := sSpls.SupplierIDs(); !reflect.DeepEqual(eIDs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, rcv)
+	}
+}
+
+func TestLibSuppliersResourceSorters(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmSplRes := NewDataManager(data)
+	for _, rPrf := range []*ResourceProfile{
+		&ResourceProfile{Tenant: "cgrates.org", ID: "RES_SPL_1", Limit: 10},
+		&ResourceProfile{Tenant: "cgrates.org", ID: "RES_SPL_2", Limit: 10},
+		&ResourceProfile{Tenant: "cgrates.org", ID: "RES_SPL_3", Limit: 5},
+	} {
+		if err := dmSplRes.SetResourceProfile(rPrf, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range []*Resource{
+		&Resource{Tenant: "cgrates.org", ID: "RES_SPL_1",
+			Usages: map[string]*ResourceUsage{
+				"RU1": &ResourceUsage{Tenant: "cgrates.org", ID: "RU1", Units: 4},
+				"RU2": &ResourceUsage{Tenant: "cgrates.org", ID: "RU2", Units: 2},
+			}},
+		&Resource{Tenant: "cgrates.org", ID: "RES_SPL_2",
+			Usages: map[string]*ResourceUsage{
+				"RU3": &ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1},
+			}},
+		&Resource{Tenant: "cgrates.org", ID: "RES_SPL_3",
+			Usages: map[string]*ResourceUsage{
+				"RU4": &ResourceUsage{Tenant: "cgrates.org", ID: "RU4", Units: 1},
+				"RU5": &ResourceUsage{Tenant: "cgrates.org", ID: "RU5", Units: 3,
+					ExpiryTime: time.Now().Add(-time.Minute)}, // expired, not counted
+			}},
+	} {
+		if err := dmSplRes.SetResource(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spS := &SupplierService{dm: dmSplRes,
+		resourceS: &ResourceService{dm: dmSplRes}}
+	spls := []*Supplier{
+		&Supplier{
+			ID:          "supplier1",
+			ResourceIDs: []string{"RES_SPL_1"},
+			Weight:      10.0,
+		},
+		&Supplier{
+			ID:          "supplier2",
+			ResourceIDs: []string{"RES_SPL_2", "RES_SPL_MISSING"},
+			Weight:      10.0,
+		},
+		&Supplier{
+			ID:          "supplier3",
+			ResourceIDs: []string{"RES_SPL_3"},
+			Weight:      30.0,
+		},
+		&Supplier{
+			ID:     "supplier4",
+			Weight: 40.0,
+		},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "supplierevent1",
+		Event:  make(map[string]interface{}),
+	}
+	lds := NewLoadDistributionSorter(spS)
+	eSpls := &SortedSuppliers{
+		ProfileID: "SPL_LOAD_1",
+		Sorting:   utils.MetaLoadDistribution,
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Weight:        10.0,
+					utils.ResourceUsage: 1.0,
+					utils.Ratio:         3.0,
+					utils.Load:          1.0 / 3.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Weight:        30.0,
+					utils.ResourceUsage: 1.0,
+					utils.Ratio:         1.0,
+					utils.Load:          1.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Weight:        10.0,
+					utils.ResourceUsage: 6.0,
+					utils.Ratio:         6.0,
+					utils.Load:          1.0,
+				},
+			},
+		},
+	}
+	if rcv, err := lds.SortSuppliers("SPL_LOAD_1", spls, ev,
+		&optsGetSuppliers{sortingParameters: []string{"supplier1:6",
+			"supplier2:3", "supplier3:1", "*default:0"}}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSpls, rcv) {
+		t.Errorf("Expecting: %s, received: %s",
+			utils.ToJSON(eSpls), utils.ToJSON(rcv))
+	}
+	if _, err := lds.SortSuppliers("SPL_LOAD_1", spls, ev,
+		&optsGetSuppliers{sortingParameters: []string{"supplier1"}}); err == nil {
+		t.Error("expecting error on invalid ratio")
+	}
+	ras := NewResourceAvailabilitySorter(spS)
+	eSpls = &SortedSuppliers{
+		ProfileID: "SPL_REAS_1",
+		Sorting:   utils.MetaReas,
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Weight:               10.0,
+					utils.ResourceAvailability: 9.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Weight:               30.0,
+					utils.ResourceAvailability: 4.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Weight:               10.0,
+					utils.ResourceAvailability: 4.0,
+				},
+			},
+			&SortedSupplier{
+				SupplierID: "supplier4",
+				SortingData: map[string]interface{}{
+					utils.Weight:               40.0,
+					utils.ResourceAvailability: 0.0,
+				},
+			},
+		},
+	}
+	if rcv, err := ras.SortSuppliers("SPL_REAS_1", spls, ev,
+		&optsGetSuppliers{}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSpls, rcv) {
+		t.Errorf("Expecting: %s, received: %s",
+			utils.ToJSON(eSpls), utils.ToJSON(rcv))
+	}
+}
+
+func TestLibSuppliersFilters(t *testing.T) {
+	newSSpls := func() *SortedSuppliers {
+		return &SortedSuppliers{
+			ProfileID: "SPL_1",
+			SortedSuppliers: []*SortedSupplier{
+				&SortedSupplier{
+					SupplierID: "supplier1",
+					SortingData: map[string]interface{}{
+						utils.Cost:   0.1,
+						utils.Weight: 10.0,
+					},
+				},
+				&SortedSupplier{
+					SupplierID: "supplier2",
+					SortingData: map[string]interface{}{
+						utils.Cost:   0.3,
+						utils.Weight: 10.0,
+					},
+				},
+				&SortedSupplier{
+					SupplierID: "supplier3",
+					SortingData: map[string]interface{}{
+						utils.Weight: 10.0,
+						utils.Error:  "NOT_CONNECTED: RALs",
+					},
+				},
+			},
+		}
+	}
+	sSpls := newSSpls()
+	if err := sSpls.FilterErrors(false); err == nil ||
+		err.Error() != "NOT_CONNECTED: RALs" {
+		t.Errorf("received: %v", err)
+	}
+	if err := sSpls.FilterErrors(true); err != nil {
+		t.Error(err)
+	} else if eIDs := []string{"supplier1", "supplier2"}; !reflect.DeepEqual(eIDs, sSpls.SupplierIDs()) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, sSpls.SupplierIDs())
+	}
+	sSpls = newSSpls()
+	sSpls.FilterMaxCost(0.2)
+	if eIDs := []string{"supplier1", "supplier3"}; !reflect.DeepEqual(eIDs, sSpls.SupplierIDs()) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, sSpls.SupplierIDs())
+	}
+	sSpls = newSSpls()
+	sSpls.FilterMaxCost(0) // only free suppliers or the ones without cost
+	if eIDs := []string{"supplier3"}; !reflect.DeepEqual(eIDs, sSpls.SupplierIDs()) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, sSpls.SupplierIDs())
+	}
+	sSpls = newSSpls()
+	sSpls.Paginate(utils.Paginator{Offset: utils.IntPointer(1), Limit: utils.IntPointer(1)})
+	if eIDs := []string{"supplier2"}; !reflect.DeepEqual(eIDs, sSpls.SupplierIDs()) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, sSpls.SupplierIDs())
+	}
+	sSpls = newSSpls()
+	sSpls.Paginate(utils.Paginator{Offset: utils.IntPointer(5)})
+	if len(sSpls.SortedSuppliers) != 0 {
+		t.Errorf("received: %s", utils.ToJSON(sSpls))
+	}
+}
+
+func TestLibSuppliersErroredSuppliersLast(t *testing.T) {
+	spls := []*Supplier{
+		&Supplier{
+			ID:          "supplier1",
+			ResourceIDs: []string{"RES_SPL_1"},
+			Weight:      20.0,
+		},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "supplierevent1",
+		Event:  make(map[string]interface{}),
+	}
+	ras := NewResourceAvailabilitySorter(&SupplierService{}) // no ResourceS connection
+	eSpls := &SortedSuppliers{
+		ProfileID: "SPL_REAS_1",
+		Sorting:   utils.MetaReas,
+		SortedSuppliers: []*SortedSupplier{
+			&SortedSupplier{
+				SupplierID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Weight: 20.0,
+					utils.Error:  utils.NewErrNotConnected(utils.ResourceS).Error(),
+				},
+			},
+		},
+	}
+	if rcv, err := ras.SortSuppliers("SPL_REAS_1", spls, ev,
+		&optsGetSuppliers{}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSpls, rcv) {
+		t.Errorf("Expecting: %s, received: %s",
+			utils.ToJSON(eSpls), utils.ToJSON(rcv))
+	}
+}
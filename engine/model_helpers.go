@@ -1844,6 +1844,21 @@ func (tps TpResources) AsTPResources() (result []*utils.TPResource) {
 		if tp.AllocationMessage != "" {
 			rl.AllocationMessage = tp.AllocationMessage
 		}
+		if tp.AllocationStrategy != "" {
+			rl.AllocationStrategy = tp.AllocationStrategy
+		}
+		if tp.MaxUsageUnits != "" {
+			rl.MaxUsageUnits = tp.MaxUsageUnits
+		}
+		if tp.QueueTimeout != "" {
+			rl.QueueTimeout = tp.QueueTimeout
+		}
+		if tp.QueueLength != 0 {
+			rl.QueueLength = tp.QueueLength
+		}
+		if tp.RateInterval != "" {
+			rl.RateInterval = tp.RateInterval
+		}
 		rl.Blocker = tp.Blocker
 		rl.Stored = tp.Stored
 		if len(tp.ActivationInterval) != 0 {
@@ -1890,6 +1905,11 @@ func APItoModelResource(rl *utils.TPResource) (mdls TpResources) {
 				mdl.Weight = rl.Weight
 				mdl.Limit = rl.Limit
 				mdl.AllocationMessage = rl.AllocationMessage
+				mdl.AllocationStrategy = rl.AllocationStrategy
+				mdl.MaxUsageUnits = rl.MaxUsageUnits
+				mdl.QueueTimeout = rl.QueueTimeout
+				mdl.QueueLength = rl.QueueLength
+				mdl.RateInterval = rl.RateInterval
 				if rl.ActivationInterval != nil {
 					if rl.ActivationInterval.ActivationTime != "" {
 						mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1914,18 +1934,35 @@ func APItoModelResource(rl *utils.TPResource) (mdls TpResources) {
 
 func APItoResource(tpRL *utils.TPResource, timezone string) (rp *ResourceProfile, err error) {
 	rp = &ResourceProfile{
-		Tenant:            tpRL.Tenant,
-		ID:                tpRL.ID,
-		Weight:            tpRL.Weight,
-		Blocker:           tpRL.Blocker,
-		Stored:            tpRL.Stored,
-		AllocationMessage: tpRL.AllocationMessage,
+		Tenant:             tpRL.Tenant,
+		ID:                 tpRL.ID,
+		Weight:             tpRL.Weight,
+		Blocker:            tpRL.Blocker,
+		Stored:             tpRL.Stored,
+		AllocationMessage:  tpRL.AllocationMessage,
+		AllocationStrategy: tpRL.AllocationStrategy,
+		QueueLength:        tpRL.QueueLength,
 	}
 	if tpRL.UsageTTL != "" {
 		if rp.UsageTTL, err = utils.ParseDurationWithNanosecs(tpRL.UsageTTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpRL.QueueTimeout != "" {
+		if rp.QueueTimeout, err = utils.ParseDurationWithNanosecs(tpRL.QueueTimeout); err != nil {
+			return nil, err
+		}
+	}
+	if tpRL.RateInterval != "" {
+		if rp.RateInterval, err = utils.ParseDurationWithNanosecs(tpRL.RateInterval); err != nil {
+			return nil, err
+		}
+	}
+	if tpRL.MaxUsageUnits != "" {
+		if rp.MaxUsageUnits, err = strconv.ParseFloat(tpRL.MaxUsageUnits, 64); err != nil {
+			return nil, err
+		}
+	}
 	for _, fltr := range tpRL.FilterIDs {
 		rp.FilterIDs = append(rp.FilterIDs, fltr)
 	}
@@ -1982,6 +2019,9 @@ func (tps TpStatsS) AsTPStats() (result []*utils.TPStats) {
 		if tp.TTL != "" {
 			st.TTL = tp.TTL
 		}
+		if tp.BucketInterval != "" {
+			st.BucketInterval = tp.BucketInterval
+		}
 		if tp.Metrics != "" {
 			if _, has := metricmap[tp.Tenant]; !has {
 				metricmap[tp.Tenant] = make(map[string]map[string]*utils.MetricWithParams)
@@ -2093,6 +2133,7 @@ func APItoModelStats(st *utils.TPStats) (mdls TpStatsS) {
 				mdl.Weight = st.Weight
 				mdl.QueueLength = st.QueueLength
 				mdl.MinItems = st.MinItems
+				mdl.BucketInterval = st.BucketInterval
 				for i, val := range st.Metrics {
 					if i != 0 {
 						mdl.Metrics += utils.INFIELD_SEP
@@ -2139,6 +2180,11 @@ func APItoStats(tpST *utils.TPStats, timezone string) (st *StatQueueProfile, err
 			return nil, err
 		}
 	}
+	if tpST.BucketInterval != "" {
+		if st.BucketInterval, err = utils.ParseDurationWithNanosecs(tpST.BucketInterval); err != nil {
+			return nil, err
+		}
+	}
 	for _, trh := range tpST.ThresholdIDs {
 		st.ThresholdIDs = append(st.ThresholdIDs, trh)
 	}
@@ -2171,10 +2217,17 @@ func (tps TpThresholdS) AsTPThreshold() (result []*utils.TPThreshold) {
 				Async:    tp.Async,
 			}
 		}
+		if tp.ResetInterval != "" {
+			th.ResetInterval = tp.ResetInterval
+		}
 		if tp.ActionIDs != "" {
 			actionSplit := strings.Split(tp.ActionIDs, utils.INFIELD_SEP)
 			th.ActionIDs = append(th.ActionIDs, actionSplit...)
 		}
+		if tp.RecoveryFilterIDs != "" {
+			th.RecoveryFilterIDs = append(th.RecoveryFilterIDs,
+				strings.Split(tp.RecoveryFilterIDs, utils.INFIELD_SEP)...)
+		}
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
@@ -2228,6 +2281,8 @@ func APItoModelTPThreshold(th *utils.TPThreshold) (mdls TpThresholdS) {
 					mdl.MinHits = th.MinHits
 					mdl.MinSleep = th.MinSleep
 					mdl.Async = th.Async
+					mdl.ResetInterval = th.ResetInterval
+					mdl.RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.INFIELD_SEP)
 					if th.ActivationInterval != nil {
 						if th.ActivationInterval.ActivationTime != "" {
 							mdl.ActivationInterval = th.ActivationInterval.ActivationTime
@@ -2266,6 +2321,8 @@ func APItoModelTPThreshold(th *utils.TPThreshold) (mdls TpThresholdS) {
 					mdl.MinHits = th.MinHits
 					mdl.MinSleep = th.MinSleep
 					mdl.Async = th.Async
+					mdl.ResetInterval = th.ResetInterval
+					mdl.RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.INFIELD_SEP)
 					if th.ActivationInterval != nil {
 						if th.ActivationInterval.ActivationTime != "" {
 							mdl.ActivationInterval = th.ActivationInterval.ActivationTime
@@ -2304,6 +2361,8 @@ func APItoModelTPThreshold(th *utils.TPThreshold) (mdls TpThresholdS) {
 					mdl.MinHits = th.MinHits
 					mdl.MinSleep = th.MinSleep
 					mdl.Async = th.Async
+					mdl.ResetInterval = th.ResetInterval
+					mdl.RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.INFIELD_SEP)
 					if th.ActivationInterval != nil {
 						if th.ActivationInterval.ActivationTime != "" {
 							mdl.ActivationInterval = th.ActivationInterval.ActivationTime
@@ -2336,6 +2395,14 @@ func APItoThresholdProfile(tpTH *utils.TPThreshold, timezone string) (th *Thresh
 			return nil, err
 		}
 	}
+	if tpTH.ResetInterval != "" {
+		if th.ResetInterval, err = utils.ParseDurationWithNanosecs(tpTH.ResetInterval); err != nil {
+			return nil, err
+		}
+	}
+	for _, fli := range tpTH.RecoveryFilterIDs {
+		th.RecoveryFilterIDs = append(th.RecoveryFilterIDs, fli)
+	}
 	for _, ati := range tpTH.ActionIDs {
 		th.ActionIDs = append(th.ActionIDs, ati)
 
@@ -2720,6 +2787,7 @@ func (tps TPAttributes) AsTPAttributes() (result []*utils.TPAttributeProfile) {
 			th.Attributes = append(th.Attributes, &utils.TPAttribute{
 				FieldName:  tp.FieldName,
 				Initial:    tp.Initial,
+				Type:       tp.Type,
 				Substitute: tp.Substitute,
 				Append:     tp.Append,
 			})
@@ -2772,6 +2840,7 @@ func APItoModelTPAttribute(th *utils.TPAttributeProfile) (mdls TPAttributes) {
 		}
 		mdl.FieldName = reqAttribute.FieldName
 		mdl.Initial = reqAttribute.Initial
+		mdl.Type = reqAttribute.Type
 		mdl.Substitute = reqAttribute.Substitute
 		mdl.Append = reqAttribute.Append
 		mdls = append(mdls, mdl)
@@ -2793,21 +2862,17 @@ func APItoAttributeProfile(tpTH *utils.TPAttributeProfile, timezone string) (th
 	for _, context := range tpTH.Contexts {
 		th.Contexts = append(th.Contexts, context)
 	}
-	th.attributes = make(map[string]map[interface{}]*Attribute)
 	for _, reqAttr := range tpTH.Attributes {
 		th.Attributes = append(th.Attributes, &Attribute{
 			Append:     reqAttr.Append,
 			FieldName:  reqAttr.FieldName,
 			Initial:    reqAttr.Initial,
+			Type:       reqAttr.Type,
 			Substitute: reqAttr.Substitute,
 		})
-		th.attributes[reqAttr.FieldName] = make(map[interface{}]*Attribute)
-		th.attributes[reqAttr.FieldName][reqAttr.Initial] = &Attribute{
-			FieldName:  reqAttr.FieldName,
-			Initial:    reqAttr.Initial,
-			Substitute: reqAttr.Substitute,
-			Append:     reqAttr.Append,
-		}
+	}
+	if err = th.compile(); err != nil {
+		return nil, err
 	}
 	if tpTH.ActivationInterval != nil {
 		if th.ActivationInterval, err = tpTH.ActivationInterval.AsActivationInterval(timezone); err != nil {
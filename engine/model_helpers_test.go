@@ -949,11 +949,12 @@ func TestAPItoTPStats(t *testing.T) {
 			&utils.MetricWithParams{MetricID: "*acd", Parameters: ""},
 			&utils.MetricWithParams{MetricID: "*acc", Parameters: ""},
 		},
-		MinItems:     1,
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		Stored:       false,
-		Blocker:      false,
-		Weight:       20.0,
+		MinItems:       1,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		Stored:         false,
+		Blocker:        false,
+		Weight:         20.0,
+		BucketInterval: "1h",
 	}
 
 	eTPs := &StatQueueProfile{ID: tps.ID,
@@ -963,12 +964,13 @@ func TestAPItoTPStats(t *testing.T) {
 			&utils.MetricWithParams{MetricID: "*acd", Parameters: ""},
 			&utils.MetricWithParams{MetricID: "*acc", Parameters: ""},
 		},
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		FilterIDs:    []string{"FLTR_1"},
-		Stored:       tps.Stored,
-		Blocker:      tps.Blocker,
-		Weight:       20.0,
-		MinItems:     tps.MinItems,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		FilterIDs:      []string{"FLTR_1"},
+		Stored:         tps.Stored,
+		Blocker:        tps.Blocker,
+		Weight:         20.0,
+		MinItems:       tps.MinItems,
+		BucketInterval: time.Hour,
 	}
 	if eTPs.TTL, err = utils.ParseDurationWithNanosecs(tps.TTL); err != nil {
 		t.Errorf("Got error: %+v", err)
@@ -412,6 +412,11 @@ type TpResource struct {
 	Stored             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"10" re:""`
+	AllocationStrategy string  `index:"11" re:""`
+	MaxUsageUnits      string  `index:"12" re:""`
+	QueueTimeout       string  `index:"13" re:""`
+	QueueLength        int     `index:"14" re:""`
+	RateInterval       string  `index:"15" re:""`
 	CreatedAt          time.Time
 }
 
@@ -431,6 +436,7 @@ type TpStats struct {
 	Weight             float64 `index:"10" re:"\d+\.?\d*"`
 	MinItems           int     `index:"11" re:""`
 	ThresholdIDs       string  `index:"12" re:""`
+	BucketInterval     string  `index:"13" re:""`
 	CreatedAt          time.Time
 }
 
@@ -448,6 +454,8 @@ type TpThreshold struct {
 	Weight             float64 `index:"8" re:"\d+\.?\d*"`
 	ActionIDs          string  `index:"9" re:""`
 	Async              bool    `index:"10" re:""`
+	ResetInterval      string  `index:"11" re:""`
+	RecoveryFilterIDs  string  `index:"12" re:""`
 	CreatedAt          time.Time
 }
 
@@ -556,5 +564,6 @@ type TPAttribute struct {
 	Substitute         string  `index:"7" re:""`
 	Append             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
+	Type               string  `index:"10" re:""`
 	CreatedAt          time.Time
 }
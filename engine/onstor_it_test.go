@@ -2019,10 +2019,10 @@ func testOnStorITStatQueue(t *testing.T) {
 			utils.MetaASR: &StatASR{
 				Answered: 2,
 				Count:    3,
-				Events: map[string]bool{
-					"cgrates.org:ev1": true,
-					"cgrates.org:ev2": true,
-					"cgrates.org:ev3": false,
+				Events: map[string]*StatWithCount{
+					"cgrates.org:ev1": &StatWithCount{Stat: 1, Count: 1},
+					"cgrates.org:ev2": &StatWithCount{Stat: 1, Count: 1},
+					"cgrates.org:ev3": &StatWithCount{Stat: 0, Count: 1},
 				},
 			},
 		},
@@ -2059,10 +2059,10 @@ func testOnStorITStatQueue(t *testing.T) {
 		utils.MetaASR: &StatASR{
 			Answered: 3,
 			Count:    3,
-			Events: map[string]bool{
-				"cgrates.org:ev1": true,
-				"cgrates.org:ev2": true,
-				"cgrates.org:ev3": true,
+			Events: map[string]*StatWithCount{
+				"cgrates.org:ev1": &StatWithCount{Stat: 1, Count: 1},
+				"cgrates.org:ev2": &StatWithCount{Stat: 1, Count: 1},
+				"cgrates.org:ev3": &StatWithCount{Stat: 1, Count: 1},
 			},
 		},
 	}
@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -47,8 +48,14 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Disabled           bool          // not matching events, ie: disabled by *disable_profile action
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	AllocationStrategy string        // <*any|*all|*first_fit>, considered on the resource with the highest weight
+	MaxUsageUnits      float64       // maximum units allowed for one usage, 0 for no cap
+	QueueTimeout       time.Duration // wait up to this for capacity to free up on allocation, 0 to fail right away
+	QueueLength        int           // maximum number of allocations waiting for capacity, 0 for no limit
+	RateInterval       time.Duration // rate limiting: Limit applies to the units allocated within this sliding window
 }
 
 // TenantID returns unique identifier of the ResourceProfile in a multi-tenant environment
@@ -58,10 +65,11 @@ func (rp *ResourceProfile) TenantID() string {
 
 // ResourceUsage represents an usage counted
 type ResourceUsage struct {
-	Tenant     string
-	ID         string // Unique identifier of this ResourceUsage, Eg: FreeSWITCH UUID
-	ExpiryTime time.Time
-	Units      float64 // Number of units used
+	Tenant         string
+	ID             string // Unique identifier of this ResourceUsage, Eg: FreeSWITCH UUID
+	ExpiryTime     time.Time
+	Units          float64   // Number of units used
+	AllocationTime time.Time // used to compute the age of the usage
 }
 
 func (ru *ResourceUsage) TenantID() string {
@@ -80,17 +88,24 @@ func (ru *ResourceUsage) Clone() (cln *ResourceUsage) {
 	return
 }
 
+// RateUsage is one allocation counted by a rate limiting Resource
+type RateUsage struct {
+	Time  time.Time // allocation time
+	Units float64
+}
+
 // Resource represents a resource in the system
 // not thread safe, needs locking at process level
 type Resource struct {
-	Tenant string
-	ID     string
-	Usages map[string]*ResourceUsage
-	TTLIdx []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disabled
-	ttl    *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
-	tUsage *float64         // sum of all usages
-	dirty  *bool            // the usages were modified, needs save, *bool so we only save if enabled in config
-	rPrf   *ResourceProfile // for ordering purposes
+	Tenant     string
+	ID         string
+	Usages     map[string]*ResourceUsage
+	RateUsages []*RateUsage     // allocations within the RateInterval, ordered by Time
+	TTLIdx     []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disabled
+	ttl        *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
+	tUsage     *float64         // sum of all usages
+	dirty      *bool            // the usages were modified, needs save, *bool so we only save if enabled in config
+	rPrf       *ResourceProfile // for ordering purposes
 }
 
 // TenantID returns the unique ID in a multi-tenant environment
@@ -142,8 +157,70 @@ func (r *Resource) totalUsage() (tU float64) {
 	return
 }
 
+// rateLimited returns true if the resource counts the allocations within a RateInterval instead of the concurrent usages
+func (r *Resource) rateLimited() bool {
+	return r.rPrf != nil && r.rPrf.RateInterval > 0
+}
+
+// removeExpiredRates removes the allocations out of the RateInterval
+func (r *Resource) removeExpiredRates(atTime time.Time) {
+	if !r.rateLimited() {
+		return
+	}
+	winStart := atTime.Add(-r.rPrf.RateInterval)
+	var firstActive int
+	for _, rtU := range r.RateUsages {
+		if rtU.Time.After(winStart) {
+			break
+		}
+		firstActive += 1
+	}
+	if firstActive != 0 {
+		r.RateUsages = r.RateUsages[firstActive:]
+	}
+}
+
+// rateUsage returns the sum of the units allocated within the RateInterval
+func (r *Resource) rateUsage(atTime time.Time) (tU float64) {
+	winStart := atTime.Add(-r.rPrf.RateInterval)
+	for _, rtU := range r.RateUsages {
+		if rtU.Time.After(winStart) {
+			tU += rtU.Units
+		}
+	}
+	return
+}
+
+// usage returns the rate usage for rate limiting resources, the total usage otherwise
+func (r *Resource) usage() float64 {
+	if r.rateLimited() {
+		return r.rateUsage(time.Now())
+	}
+	return r.totalUsage()
+}
+
+// fits checks if the units of one usage can be allocated on the resource
+func (r *Resource) fits(units float64) bool {
+	if r.rPrf.MaxUsageUnits > 0 && units > r.rPrf.MaxUsageUnits {
+		return false
+	}
+	return r.rPrf.Limit >= r.usage()+units
+}
+
+// allocationMessage returns the message of the resource winning the allocation
+func (r *Resource) allocationMessage() string {
+	if r.rPrf.AllocationMessage != "" {
+		return r.rPrf.AllocationMessage
+	}
+	return r.rPrf.ID
+}
+
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
+	if r.rateLimited() { // counted within the window, not released
+		r.RateUsages = append(r.RateUsages, &RateUsage{Time: time.Now(), Units: ru.Units})
+		return
+	}
 	if _, hasID := r.Usages[ru.ID]; hasID {
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
@@ -205,15 +282,34 @@ func (rs Resources) recordUsage(ru *ResourceUsage) (err error) {
 	}
 	if err != nil {
 		for _, r := range rs[:nonReservedIdx] {
+			if r.rateLimited() {
+				r.RateUsages = r.RateUsages[:len(r.RateUsages)-1]
+				continue
+			}
 			r.clearUsage(ru.ID) // best effort
 		}
 	}
 	return
 }
 
+// allocationStrategy returns the AllocationStrategy of the resource with the highest weight, *any by default
+func (rs Resources) allocationStrategy() string {
+	if len(rs) == 0 || rs[0].rPrf == nil || rs[0].rPrf.AllocationStrategy == "" {
+		return utils.META_ANY
+	}
+	return rs[0].rPrf.AllocationStrategy
+}
+
 // clearUsage gives back the units to the pool
 func (rs Resources) clearUsage(ruTntID string) (err error) {
+	firstFit := rs.allocationStrategy() == utils.MetaFirstFit
 	for _, r := range rs {
+		if r.rateLimited() { // allocations expire with the RateInterval
+			continue
+		}
+		if _, has := r.Usages[ruTntID]; !has && firstFit { // usage recorded only on the winning resource
+			continue
+		}
 		if errClear := r.clearUsage(ruTntID); errClear != nil &&
 			r.ttl != nil && *r.ttl != 0 { // we only consider not found error in case of ttl different than 0
 			utils.Logger.Warning(fmt.Sprintf("<ResourceLimits>, clear ruID: %s, err: %s", ruTntID, errClear.Error()))
@@ -242,30 +338,49 @@ func (rs Resources) tenatIDsStr() []string {
 
 // allocateResource attempts allocating resources for a *ResourceUsage
 // simulates on dryRun
+// based on the allocation strategy, the usage is recorded on:
+// *any: all the resources, if at least one of them has capacity
+// *all: all the resources, if each of them has capacity
+// *first_fit: the first resource, ordered by weight, which has capacity
 // returns utils.ErrResourceUnavailable if allocation is not possible
 func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage string, err error) {
 	if len(rs) == 0 {
 		return "", utils.ErrResourceUnavailable
 	}
+	strategy := rs.allocationStrategy()
+	switch strategy {
+	case utils.META_ANY, utils.MetaAll, utils.MetaFirstFit:
+	default:
+		return "", fmt.Errorf("unsupported allocation strategy: %s", strategy)
+	}
 	lockIDs := utils.PrefixSliceItems(rs.tenatIDsStr(), utils.ResourcesPrefix)
 	guardian.Guardian.GuardIDs(config.CgrConfig().LockingTimeout, lockIDs...)
 	defer guardian.Guardian.UnguardIDs(lockIDs...)
-	// Simulate resource usage
-	for _, r := range rs {
+	alcRs := rs // resources recording the usage
+	now := time.Now()
+	for _, r := range rs { // clear all the resources before fitting so an update will not be recorded twice
 		r.removeExpiredUnits()
+		r.removeExpiredRates(now)
 		if _, hasID := r.Usages[ru.ID]; hasID { // update
 			r.clearUsage(ru.ID)
 		}
+	}
+	// Simulate resource usage
+	for _, r := range rs {
 		if r.rPrf == nil {
 			return "", fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
 		}
-		if r.rPrf.Limit >= r.totalUsage()+ru.Units {
-			if alcMessage == "" {
-				if r.rPrf.AllocationMessage != "" {
-					alcMessage = r.rPrf.AllocationMessage
-				} else {
-					alcMessage = r.rPrf.ID
-				}
+		if !r.fits(ru.Units) {
+			if strategy == utils.MetaAll {
+				return "", utils.ErrResourceUnavailable
+			}
+			continue
+		}
+		if alcMessage == "" {
+			alcMessage = r.allocationMessage()
+			if strategy == utils.MetaFirstFit {
+				alcRs = Resources{r}
+				break
 			}
 		}
 	}
@@ -275,7 +390,7 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 	if dryRun {
 		return
 	}
-	err = rs.recordUsage(ru)
+	err = alcRs.recordUsage(ru)
 	if err != nil {
 		return
 	}
@@ -285,7 +400,7 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 // Pas the config as a whole so we can ask access concurrently
 func NewResourceService(dm *DataManager, storeInterval time.Duration,
 	thdS rpcclient.RpcClientConnection, filterS *FilterS,
-	stringIndexedFields, prefixIndexedFields *[]string) (*ResourceService, error) {
+	stringIndexedFields, prefixIndexedFields, suffixIndexedFields *[]string) (*ResourceService, error) {
 	if thdS != nil && reflect.ValueOf(thdS).IsNil() {
 		thdS = nil
 	}
@@ -296,6 +411,8 @@ func NewResourceService(dm *DataManager, storeInterval time.Duration,
 		filterS:             filterS,
 		stringIndexedFields: stringIndexedFields,
 		prefixIndexedFields: prefixIndexedFields,
+		suffixIndexedFields: suffixIndexedFields,
+		waiters:             make(map[string][]chan struct{}),
 		stopBackup:          make(chan struct{})}, nil
 }
 
@@ -306,14 +423,23 @@ type ResourceService struct {
 	filterS             *FilterS
 	stringIndexedFields *[]string // speed up query on indexes
 	prefixIndexedFields *[]string
+	suffixIndexedFields *[]string
 	lcEventResources    map[string][]*utils.TenantID // cache recording resources for events in alocation phase
 	lcERMux             sync.RWMutex                 // protects the lcEventResources
 	storedResources     utils.StringMap              // keep a record of resources which need saving, map[resID]bool
 	srMux               sync.RWMutex                 // protects storedResources
+	waiters             map[string][]chan struct{}   // allocations queued for capacity, map[resourceTenantID]
+	wMux                sync.Mutex                   // protects waiters
 	storeInterval       time.Duration                // interval to dump data on
 	stopBackup          chan struct{}                // control storing process
 }
 
+// Call implements rpcclient.RpcClientConnection interface for internal RPC
+// here for cases when passing ResourceService as rpcclient.RpcClientConnection (ie. in SupplierS)
+func (rS *ResourceService) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(rS, serviceMethod, args, reply)
+}
+
 // Called to start the service
 func (rS *ResourceService) ListenAndServe(exitChan chan bool) error {
 	go rS.runBackup() // start backup loop
@@ -347,6 +473,21 @@ func (rS *ResourceService) StoreResource(r *Resource) (err error) {
 	return
 }
 
+// storeResource saves the resource right away or schedules it for the next backup, based on storeInterval
+func (rS *ResourceService) storeResource(r *Resource) {
+	if rS.storeInterval == 0 || r.dirty == nil {
+		return
+	}
+	*r.dirty = true // mark it to be saved
+	if rS.storeInterval == -1 {
+		rS.StoreResource(r)
+		return
+	}
+	rS.srMux.Lock()
+	rS.storedResources[r.TenantID()] = true
+	rS.srMux.Unlock()
+}
+
 // storeResources represents one task of complete backup
 func (rS *ResourceService) storeResources() {
 	var failedRIDs []string
@@ -443,7 +584,7 @@ func (rS *ResourceService) cachedResourcesForEvent(evUUID string) (rs Resources)
 // matchingResourcesForEvent returns ordered list of matching resources which are active by the time of the call
 func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent, usageTTL *time.Duration) (rs Resources, err error) {
 	matchingResources := make(map[string]*Resource)
-	rIDs, err := matchingItemIDsForEvent(ev.Event, rS.stringIndexedFields, rS.prefixIndexedFields,
+	rIDs, err := matchingItemIDsForEvent(ev.Event, rS.stringIndexedFields, rS.prefixIndexedFields, rS.suffixIndexedFields,
 		rS.dm, utils.CacheResourceFilterIndexes, ev.Tenant)
 	if err != nil {
 		return nil, err
@@ -459,8 +600,9 @@ func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent, usageTT
 			}
 			return nil, err
 		}
-		if rPrf.ActivationInterval != nil && ev.Time != nil &&
-			!rPrf.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
+		if rPrf.Disabled ||
+			(rPrf.ActivationInterval != nil && ev.Time != nil &&
+				!rPrf.ActivationInterval.IsActiveAtTime(*ev.Time)) { // not active
 			continue
 		}
 		if pass, err := rS.filterS.Pass(ev.Tenant, rPrf.FilterIDs,
@@ -503,6 +645,80 @@ func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent, usageTT
 	return
 }
 
+// addWaiter queues an allocation on the resources, returns nil if the queue of the first one is full
+func (rS *ResourceService) addWaiter(rs Resources) (wakeup chan struct{}) {
+	rS.wMux.Lock()
+	defer rS.wMux.Unlock()
+	if rS.waiters == nil {
+		rS.waiters = make(map[string][]chan struct{})
+	}
+	if qLen := rs[0].rPrf.QueueLength; qLen > 0 &&
+		len(rS.waiters[rs[0].TenantID()]) >= qLen {
+		return
+	}
+	wakeup = make(chan struct{}, 1)
+	for _, r := range rs {
+		rS.waiters[r.TenantID()] = append(rS.waiters[r.TenantID()], wakeup)
+	}
+	return
+}
+
+// removeWaiter takes the allocation out of the queues of the resources
+func (rS *ResourceService) removeWaiter(rs Resources, wakeup chan struct{}) {
+	rS.wMux.Lock()
+	for _, r := range rs {
+		rID := r.TenantID()
+		for i, w := range rS.waiters[rID] {
+			if w == wakeup {
+				rS.waiters[rID] = append(rS.waiters[rID][:i], rS.waiters[rID][i+1:]...)
+				break
+			}
+		}
+		if len(rS.waiters[rID]) == 0 {
+			delete(rS.waiters, rID)
+		}
+	}
+	rS.wMux.Unlock()
+}
+
+// wakeupWaiters notifies the allocations queued on the resource that capacity was released
+func (rS *ResourceService) wakeupWaiters(r *Resource) {
+	rS.wMux.Lock()
+	for _, wakeup := range rS.waiters[r.TenantID()] {
+		select {
+		case wakeup <- struct{}{}:
+		default: // already notified
+		}
+	}
+	rS.wMux.Unlock()
+}
+
+// queueAllocation waits for capacity to free up on the resources, up to QueueTimeout of the first one
+// returns utils.ErrResourceUnavailable if the queue is full or the timeout was reached
+func (rS *ResourceService) queueAllocation(rs Resources, ru *ResourceUsage) (alcMessage string, err error) {
+	if len(rs) == 0 || rs[0].rPrf == nil || rs[0].rPrf.QueueTimeout <= 0 {
+		return "", utils.ErrResourceUnavailable
+	}
+	deadline := time.Now().Add(rs[0].rPrf.QueueTimeout)
+	for {
+		wakeup := rS.addWaiter(rs)
+		if wakeup == nil {
+			return "", utils.ErrResourceUnavailable
+		}
+		timer := time.NewTimer(deadline.Sub(time.Now()))
+		select {
+		case <-wakeup:
+			timer.Stop()
+		case <-timer.C: // last try since expired usages are not notified
+		}
+		rS.removeWaiter(rs, wakeup)
+		if alcMessage, err = rs.allocateResource(ru, false); err != utils.ErrResourceUnavailable ||
+			!time.Now().Before(deadline) {
+			return
+		}
+	}
+}
+
 // processThresholds will pass the event for resource to ThresholdS
 func (rS *ResourceService) processThresholds(r *Resource) (err error) {
 	if rS.thdS == nil {
@@ -519,7 +735,7 @@ func (rS *ResourceService) processThresholds(r *Resource) (err error) {
 			Event: map[string]interface{}{
 				utils.EventType:  utils.ResourceUpdate,
 				utils.ResourceID: r.ID,
-				utils.Usage:      r.totalUsage()}}}
+				utils.Usage:      r.usage()}}}
 	var tIDs []string
 	if err = rS.thdS.Call(utils.ThresholdSv1ProcessEvent, thEv, &tIDs); err != nil &&
 		err.Error() != utils.ErrNotFound.Error() {
@@ -607,8 +823,12 @@ func (rS *ResourceService) V1AllocateResource(args utils.ArgRSv1ResourceUsage, r
 	} else {
 		wasCached = true
 	}
-	alcMsg, err := mtcRLs.allocateResource(
-		&ResourceUsage{Tenant: args.CGREvent.Tenant, ID: args.UsageID, Units: args.Units}, false)
+	ru := &ResourceUsage{Tenant: args.CGREvent.Tenant, ID: args.UsageID,
+		Units: args.Units, AllocationTime: time.Now()}
+	alcMsg, err := mtcRLs.allocateResource(ru, false)
+	if err == utils.ErrResourceUnavailable {
+		alcMsg, err = rS.queueAllocation(mtcRLs, ru)
+	}
 	if err != nil {
 		return
 	}
@@ -679,6 +899,7 @@ func (rS *ResourceService) V1ReleaseResource(args utils.ArgRSv1ResourceUsage, re
 				rS.storedResources[r.TenantID()] = true
 			}
 		}
+		rS.wakeupWaiters(r)
 		rS.processThresholds(r)
 	}
 	if rS.storeInterval != -1 {
@@ -687,3 +908,254 @@ func (rS *ResourceService) V1ReleaseResource(args utils.ArgRSv1ResourceUsage, re
 	*reply = utils.OK
 	return nil
 }
+
+// ResourceSummary is a reporting view over a Resource's usage versus its limit
+type ResourceSummary struct {
+	Tenant     string
+	ID         string
+	TotalUsage float64 // sum of the active usage units
+	Limit      float64 // limit out of the ResourceProfile
+	Usages     int     // number of active usages
+}
+
+// V1GetResourceIDs returns list of resourceIDs registered for a tenant
+func (rS *ResourceService) V1GetResourceIDs(tenant string, rIDs *[]string) (err error) {
+	prfx := utils.ResourcesPrefix + tenant + ":"
+	keys, err := rS.dm.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*rIDs = retIDs
+	return
+}
+
+// V1GetResourceSummary returns the active usage of a Resource together with its limit
+func (rS *ResourceService) V1GetResourceSummary(tntID *utils.TenantID, reply *ResourceSummary) (err error) {
+	if missing := utils.MissingStructFields(tntID, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	rPrf, err := rS.dm.GetResourceProfile(tntID.Tenant, tntID.ID, false, utils.NonTransactional)
+	if err != nil {
+		return err
+	}
+	r, err := rS.dm.GetResource(tntID.Tenant, tntID.ID, false, "")
+	if err != nil {
+		return err
+	}
+	rSum := &ResourceSummary{Tenant: r.Tenant, ID: r.ID, Limit: rPrf.Limit}
+	now := time.Now()
+	for _, ru := range r.Usages {
+		if !ru.isActive(now) {
+			continue
+		}
+		rSum.TotalUsage += ru.Units
+		rSum.Usages += 1
+	}
+	if rPrf.RateInterval > 0 { // allocations within the window
+		winStart := now.Add(-rPrf.RateInterval)
+		for _, rtU := range r.RateUsages {
+			if !rtU.Time.After(winStart) {
+				continue
+			}
+			rSum.TotalUsage += rtU.Units
+			rSum.Usages += 1
+		}
+	}
+	*reply = *rSum
+	return
+}
+
+// ResourceUsageReport describes one usage holding units of a Resource
+type ResourceUsageReport struct {
+	ID         string
+	Units      float64
+	ExpiryTime time.Time
+	Age        time.Duration // time since allocation, 0 if not known
+}
+
+// usageReports returns the active usages of a Resource, ordered by ID
+func (rS *ResourceService) usageReports(tenant, id string) (ruRprts []*ResourceUsageReport, err error) {
+	lockID := utils.ResourcesPrefix + utils.ConcatenatedKey(tenant, id)
+	guardian.Guardian.GuardIDs(config.CgrConfig().LockingTimeout, lockID)
+	defer guardian.Guardian.UnguardIDs(lockID)
+	r, err := rS.dm.GetResource(tenant, id, false, "")
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, ru := range r.Usages {
+		if !ru.isActive(now) {
+			continue
+		}
+		ruRprt := &ResourceUsageReport{ID: ru.ID, Units: ru.Units, ExpiryTime: ru.ExpiryTime}
+		if !ru.AllocationTime.IsZero() {
+			ruRprt.Age = now.Sub(ru.AllocationTime)
+		}
+		ruRprts = append(ruRprts, ruRprt)
+	}
+	sort.Slice(ruRprts, func(i, j int) bool { return ruRprts[i].ID < ruRprts[j].ID })
+	return
+}
+
+// releaseUsages force-releases out of a Resource the usages with the given IDs or older than minAge
+func (rS *ResourceService) releaseUsages(tenant, id string, ruIDs utils.StringMap,
+	minAge time.Duration) (released []string, err error) {
+	rPrf, err := rS.dm.GetResourceProfile(tenant, id, false, utils.NonTransactional)
+	if err != nil {
+		return nil, err
+	}
+	lockID := utils.ResourcesPrefix + utils.ConcatenatedKey(tenant, id)
+	guardian.Guardian.GuardIDs(config.CgrConfig().LockingTimeout, lockID)
+	r, err := rS.dm.GetResource(tenant, id, false, "")
+	if err != nil {
+		guardian.Guardian.UnguardIDs(lockID)
+		return nil, err
+	}
+	now := time.Now()
+	for ruID, ru := range r.Usages {
+		if !ruIDs.HasKey(ruID) &&
+			(minAge <= 0 || ru.AllocationTime.IsZero() || now.Sub(ru.AllocationTime) < minAge) {
+			continue
+		}
+		if err = r.clearUsage(ruID); err != nil {
+			break
+		}
+		released = append(released, ruID)
+	}
+	if len(released) != 0 {
+		r.rPrf = rPrf
+		if rPrf.Stored && r.dirty == nil {
+			r.dirty = utils.BoolPointer(false)
+		}
+		rS.storeResource(r)
+	}
+	guardian.Guardian.UnguardIDs(lockID)
+	if len(released) == 0 {
+		if err == nil {
+			err = utils.ErrNotFound
+		}
+		return nil, err
+	}
+	rS.lcERMux.Lock()
+	for _, ruID := range released {
+		delete(rS.lcEventResources, ruID)
+	}
+	rS.lcERMux.Unlock()
+	rS.wakeupWaiters(r)
+	rS.processThresholds(r)
+	sort.Strings(released)
+	return
+}
+
+// RunReconcile regularly releases the usages older than interval whose sessions are not active in SessionS anymore
+func (rS *ResourceService) RunReconcile(sSConn rpcclient.RpcClientConnection, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	for {
+		select {
+		case <-rS.stopBackup:
+			return
+		case <-time.After(interval):
+		}
+		rS.reconcileUsages(sSConn, interval)
+	}
+}
+
+// reconcileUsages releases the usages older than minAge which have no active session with the same OriginID,
+// SessionS is queried once per resource; the usages without AllocationTime (ie: migrated) are considered old
+func (rS *ResourceService) reconcileUsages(sSConn rpcclient.RpcClientConnection, minAge time.Duration) {
+	keys, err := rS.dm.DataDB().GetKeysForPrefix(utils.ResourcesPrefix)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<ResourceS> failed querying resources for reconciliation, error: %s", err.Error()))
+		return
+	}
+	for _, key := range keys {
+		tntID := strings.SplitN(key[len(utils.ResourcesPrefix):], utils.CONCATENATED_KEY_SEP, 2)
+		if len(tntID) != 2 {
+			continue
+		}
+		ruRprts, err := rS.usageReports(tntID[0], tntID[1])
+		if err != nil {
+			continue
+		}
+		var oldIDs []string
+		for _, ruRprt := range ruRprts {
+			if ruRprt.Age != 0 && ruRprt.Age < minAge { // 0 for unknown age
+				continue
+			}
+			oldIDs = append(oldIDs, ruRprt.ID)
+		}
+		if len(oldIDs) == 0 {
+			continue
+		}
+		var activeIDs []string
+		if err := sSConn.Call(utils.SMGenericV1GetActiveOriginIDs, oldIDs, &activeIDs); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ResourceS> failed querying SessionS for usages of resource: %s, error: %s",
+					key[len(utils.ResourcesPrefix):], err.Error()))
+			continue
+		}
+		leakedIDs := utils.NewStringMap(oldIDs...)
+		for _, activeID := range activeIDs {
+			delete(leakedIDs, activeID)
+		}
+		if len(leakedIDs) == 0 {
+			continue
+		}
+		if released, err := rS.releaseUsages(tntID[0], tntID[1], leakedIDs, 0); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ResourceS> failed releasing usages without session on resource: %s, error: %s",
+					key[len(utils.ResourcesPrefix):], err.Error()))
+		} else {
+			utils.Logger.Info(
+				fmt.Sprintf("<ResourceS> released usages without session: %+v on resource: %s",
+					released, key[len(utils.ResourcesPrefix):]))
+		}
+	}
+}
+
+// V1GetResourceUsages returns the usages holding a Resource
+func (rS *ResourceService) V1GetResourceUsages(tntID *utils.TenantID, reply *[]*ResourceUsageReport) (err error) {
+	if missing := utils.MissingStructFields(tntID, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	ruRprts, err := rS.usageReports(tntID.Tenant, tntID.ID)
+	if err != nil {
+		return err
+	}
+	if len(ruRprts) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = ruRprts
+	return
+}
+
+// ArgsReleaseResourceUsages selects the usages to be force-released out of a Resource
+type ArgsReleaseResourceUsages struct {
+	utils.TenantID
+	UsageIDs []string      // release these usages
+	MinAge   time.Duration // release also the usages allocated earlier than this, 0 to disable
+}
+
+// V1ReleaseResourceUsages force-releases usages out of a Resource, ie: leaked after an agent crash
+// replies with the IDs of the released usages
+func (rS *ResourceService) V1ReleaseResourceUsages(args *ArgsReleaseResourceUsages, reply *[]string) (err error) {
+	if missing := utils.MissingStructFields(&args.TenantID, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if len(args.UsageIDs) == 0 && args.MinAge <= 0 {
+		return utils.NewErrMandatoryIeMissing("UsageIDs")
+	}
+	released, err := rS.releaseUsages(args.Tenant, args.ID, utils.NewStringMap(args.UsageIDs...), args.MinAge)
+	if err != nil {
+		return err
+	}
+	*reply = released
+	return
+}
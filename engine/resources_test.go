@@ -856,3 +856,258 @@ func TestRSUsageTTLCase4(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", res.ttl, mres[0].ttl)
 	}
 }
+
+func TestRSAllocationStrategies(t *testing.T) {
+	rA := &Resource{Tenant: "cgrates.org", ID: "RES_A",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_A",
+			Limit: 1, Weight: 20, AllocationStrategy: utils.MetaFirstFit}}
+	rB := &Resource{Tenant: "cgrates.org", ID: "RES_B",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_B",
+			Limit: 2, Weight: 10}}
+	rsStrtg := Resources{rA, rB}
+	if alcMsg, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_1", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if alcMsg != "RES_A" {
+		t.Errorf("received: %s", alcMsg)
+	} else if rA.totalUsage() != 1 || rB.totalUsage() != 0 {
+		t.Errorf("usage recorded on: %+v, %+v", rA.Usages, rB.Usages)
+	}
+	if alcMsg, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_2", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if alcMsg != "RES_B" {
+		t.Errorf("received: %s", alcMsg)
+	} else if rA.totalUsage() != 1 || rB.totalUsage() != 1 {
+		t.Errorf("usage recorded on: %+v, %+v", rA.Usages, rB.Usages)
+	}
+	if err := rsStrtg.clearUsage("RU_1"); err != nil {
+		t.Error(err)
+	}
+	rB.rPrf.Limit = 3
+	rB.rPrf.MaxUsageUnits = 1
+	if _, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 1.5}, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	rA.rPrf.AllocationStrategy = utils.MetaAll
+	if _, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 2}, false); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if err := rsStrtg.clearUsage("RU_2"); err != nil {
+		t.Error(err)
+	}
+	if alcMsg, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if alcMsg != "RES_A" {
+		t.Errorf("received: %s", alcMsg)
+	} else if rA.totalUsage() != 1 || rB.totalUsage() != 1 {
+		t.Errorf("usage recorded on: %+v, %+v", rA.Usages, rB.Usages)
+	}
+	rA.rPrf.AllocationStrategy = "*unsupported"
+	if _, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_4", Units: 1}, true); err == nil {
+		t.Error("expecting error for unsupported strategy")
+	}
+}
+
+func TestRSAllocateFirstFitUpdate(t *testing.T) {
+	rA := &Resource{Tenant: "cgrates.org", ID: "RES_A",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_A",
+			Limit: 1, Weight: 20, AllocationStrategy: utils.MetaFirstFit}}
+	rB := &Resource{Tenant: "cgrates.org", ID: "RES_B",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_B",
+			Limit: 2, Weight: 10}}
+	rsStrtg := Resources{rA, rB}
+	for _, ruID := range []string{"RU_1", "RU_2"} {
+		if _, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+			ID: ruID, Units: 1}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rA.clearUsage("RU_1"); err != nil {
+		t.Fatal(err)
+	}
+	// RU_2 recorded on RES_B is updated while RES_A has capacity again
+	if alcMsg, err := rsStrtg.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_2", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if alcMsg != "RES_A" {
+		t.Errorf("received: %s", alcMsg)
+	} else if _, has := rA.Usages["RU_2"]; !has || rA.totalUsage() != 1 {
+		t.Errorf("usage not recorded on RES_A: %+v", rA.Usages)
+	} else if _, has := rB.Usages["RU_2"]; has || rB.totalUsage() != 0 {
+		t.Errorf("usage not cleared on RES_B: %+v", rB.Usages)
+	}
+}
+
+func TestRSQueueAllocation(t *testing.T) {
+	rQ := &Resource{Tenant: "cgrates.org", ID: "RES_QUEUE",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_QUEUE",
+			Limit: 1, QueueTimeout: time.Second, QueueLength: 1}}
+	rsQ := Resources{rQ}
+	rSQ := &ResourceService{}
+	if _, err := rsQ.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_1", Units: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+	// queue is full
+	wakeup := rSQ.addWaiter(rsQ)
+	if _, err := rSQ.queueAllocation(rsQ, &ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_2", Units: 1}); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	rSQ.removeWaiter(rsQ, wakeup)
+	alcErr := make(chan error)
+	go func() {
+		_, err := rSQ.queueAllocation(rsQ, &ResourceUsage{Tenant: "cgrates.org",
+			ID: "RU_2", Units: 1})
+		alcErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := rsQ.clearUsage("RU_1"); err != nil {
+		t.Error(err)
+	}
+	rSQ.wakeupWaiters(rQ)
+	select {
+	case err := <-alcErr:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("queued allocation not woken up on release")
+	}
+	rQ.rPrf.QueueTimeout = 10 * time.Millisecond
+	if _, err := rSQ.queueAllocation(rsQ, &ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 1}); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+}
+
+func TestRSRateLimiting(t *testing.T) {
+	rRate := &Resource{Tenant: "cgrates.org", ID: "RES_RATE",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RES_RATE",
+			Limit: 2, RateInterval: time.Hour}}
+	rsRate := Resources{rRate}
+	for _, ruID := range []string{"RU_1", "RU_2"} {
+		if _, err := rsRate.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+			ID: ruID, Units: 1}, false); err != nil {
+			t.Error(err)
+		}
+	}
+	// releasing does not give back the units counted within the window
+	if err := rsRate.clearUsage("RU_1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := rsRate.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 1}, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if len(rRate.Usages) != 0 || len(rRate.RateUsages) != 2 {
+		t.Errorf("received: %s", utils.ToJSON(rRate))
+	}
+	// first allocation out of the window
+	rRate.RateUsages[0].Time = time.Now().Add(-2 * time.Hour)
+	if _, err := rsRate.allocateResource(&ResourceUsage{Tenant: "cgrates.org",
+		ID: "RU_3", Units: 1}, false); err != nil {
+		t.Error(err)
+	} else if len(rRate.RateUsages) != 2 || rRate.usage() != 2 {
+		t.Errorf("received: %s", utils.ToJSON(rRate))
+	}
+}
+
+// testSessionS mocks SessionS knowing only the sessions with the given OriginIDs
+type testSessionS struct {
+	activeIDs utils.StringMap
+	calls     int
+}
+
+func (tss *testSessionS) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.SMGenericV1GetActiveOriginIDs {
+		return utils.ErrNotImplemented
+	}
+	tss.calls++
+	activeIDs := make([]string, 0)
+	for _, originID := range args.([]string) {
+		if tss.activeIDs[originID] {
+			activeIDs = append(activeIDs, originID)
+		}
+	}
+	*reply.(*[]string) = activeIDs
+	return nil
+}
+
+func TestRSReleaseUsages(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmRU := NewDataManager(data)
+	rPrf := &ResourceProfile{Tenant: "cgrates.org", ID: "RES_LEAK", Limit: 10}
+	if err := dmRU.SetResourceProfile(rPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := dmRU.SetResource(&Resource{Tenant: "cgrates.org", ID: "RES_LEAK",
+		Usages: map[string]*ResourceUsage{
+			"RU_1": &ResourceUsage{Tenant: "cgrates.org", ID: "RU_1", Units: 1,
+				AllocationTime: now.Add(-2 * time.Hour)},
+			"RU_2": &ResourceUsage{Tenant: "cgrates.org", ID: "RU_2", Units: 2,
+				AllocationTime: now.Add(-2 * time.Hour)},
+			"RU_3": &ResourceUsage{Tenant: "cgrates.org", ID: "RU_3", Units: 3,
+				AllocationTime: now},
+			"RU_4": &ResourceUsage{Tenant: "cgrates.org", ID: "RU_4", Units: 4,
+				AllocationTime: now},
+			"RU_5": &ResourceUsage{Tenant: "cgrates.org", ID: "RU_5", Units: 5}, // no AllocationTime, ie: migrated
+		}}); err != nil {
+		t.Fatal(err)
+	}
+	rSRU, _ := NewResourceService(dmRU, 0, nil, nil, nil, nil, nil)
+	tntID := &utils.TenantID{Tenant: "cgrates.org", ID: "RES_LEAK"}
+	var ruRprts []*ResourceUsageReport
+	if err := rSRU.V1GetResourceUsages(tntID, &ruRprts); err != nil {
+		t.Fatal(err)
+	} else if len(ruRprts) != 5 || ruRprts[0].ID != "RU_1" || ruRprts[0].Units != 1 ||
+		ruRprts[0].Age < 2*time.Hour || ruRprts[3].ID != "RU_4" || ruRprts[4].Age != 0 {
+		t.Errorf("received: %s", utils.ToJSON(ruRprts))
+	}
+	var released []string
+	if err := rSRU.V1ReleaseResourceUsages(&ArgsReleaseResourceUsages{TenantID: *tntID},
+		&released); err == nil || err.Error() != utils.NewErrMandatoryIeMissing("UsageIDs").Error() {
+		t.Errorf("received error: %v", err)
+	}
+	if err := rSRU.V1ReleaseResourceUsages(&ArgsReleaseResourceUsages{TenantID: *tntID,
+		UsageIDs: []string{"RU_4"}}, &released); err != nil {
+		t.Error(err)
+	} else if eRel := []string{"RU_4"}; !reflect.DeepEqual(eRel, released) {
+		t.Errorf("expecting: %+v, received: %+v", eRel, released)
+	}
+	// RU_2 has an active session in SessionS
+	sS := &testSessionS{activeIDs: utils.StringMap{"RU_2": true}}
+	rSRU.reconcileUsages(sS, time.Hour)
+	if sS.calls != 1 {
+		t.Errorf("expecting one SessionS query, received: %d", sS.calls)
+	}
+	ruRprts = nil
+	if err := rSRU.V1GetResourceUsages(tntID, &ruRprts); err != nil {
+		t.Fatal(err)
+	} else if len(ruRprts) != 2 || ruRprts[0].ID != "RU_2" || ruRprts[1].ID != "RU_3" {
+		t.Errorf("received: %s", utils.ToJSON(ruRprts))
+	}
+	released = nil
+	if err := rSRU.V1ReleaseResourceUsages(&ArgsReleaseResourceUsages{TenantID: *tntID,
+		MinAge: time.Hour}, &released); err != nil {
+		t.Error(err)
+	} else if eRel := []string{"RU_2"}; !reflect.DeepEqual(eRel, released) {
+		t.Errorf("expecting: %+v, received: %+v", eRel, released)
+	}
+	if err := rSRU.V1ReleaseResourceUsages(&ArgsReleaseResourceUsages{TenantID: *tntID,
+		UsageIDs: []string{"RU_NONE"}}, &released); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
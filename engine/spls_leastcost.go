@@ -40,26 +40,18 @@ func (lcs *LeastCostSorter) SortSuppliers(prflID string, suppls []*Supplier,
 	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
 		Sorting:         lcs.sorting,
 		SortedSuppliers: make([]*SortedSupplier, 0)}
+	var errSuppls []*SortedSupplier // suppliers failing to compute their sorting data
 	for _, s := range suppls {
 		costData, err := lcs.spS.costForEvent(ev, s.AccountIDs, s.RatingPlanIDs)
 		if err != nil {
-			if extraOpts.ignoreErrors {
-				utils.Logger.Warning(
-					fmt.Sprintf("<%s> profile: %s ignoring supplier with ID: %s, err: %s",
-						utils.SupplierS, prflID, s.ID, err.Error()))
-				continue
-			}
-			return nil, err
+			errSuppls = append(errSuppls, newErroredSortedSupplier(s, err))
+			continue
 		} else if len(costData) == 0 {
 			utils.Logger.Warning(
 				fmt.Sprintf("<%s> profile: %s ignoring supplier with ID: %s, missing cost information",
 					utils.SupplierS, prflID, s.ID))
 			continue
 		}
-		if extraOpts.maxCost != 0 &&
-			costData[utils.Cost].(float64) > extraOpts.maxCost {
-			continue
-		}
 		srtData := map[string]interface{}{
 			utils.Weight: s.Weight,
 		}
@@ -72,9 +64,10 @@ func (lcs *LeastCostSorter) SortSuppliers(prflID string, suppls []*Supplier,
 				SortingData:        srtData,
 				SupplierParameters: s.SupplierParameters})
 	}
-	if len(sortedSuppls.SortedSuppliers) == 0 {
+	if len(sortedSuppls.SortedSuppliers) == 0 && len(errSuppls) == 0 {
 		return nil, utils.ErrNotFound
 	}
 	sortedSuppls.SortCost()
+	sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, errSuppls...)
 	return
 }
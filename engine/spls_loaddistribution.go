@@ -0,0 +1,97 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewLoadDistributionSorter(spS *SupplierService) *LoadDistributionSorter {
+	return &LoadDistributionSorter{spS: spS,
+		sorting: utils.MetaLoadDistribution}
+}
+
+// LoadDistributionSorter orders suppliers based on their resource usage
+// relative to the ratios defined in SortingParameters, eg: supplier1:6;supplier2:3;*default:1
+type LoadDistributionSorter struct {
+	sorting string
+	spS     *SupplierService
+}
+
+// loadRatios parses the sorting parameters into ratios per supplier
+func loadRatios(params []string) (ratios map[string]float64, err error) {
+	ratios = map[string]float64{utils.MetaDefault: 1}
+	for _, param := range params {
+		splRatio := strings.Split(param, utils.InInFieldSep)
+		if len(splRatio) != 2 {
+			return nil, fmt.Errorf("invalid ratio parameter: %s", param)
+		}
+		if ratios[splRatio[0]], err = strconv.ParseFloat(splRatio[1], 64); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (lds *LoadDistributionSorter) SortSuppliers(prflID string, suppls []*Supplier,
+	ev *utils.CGREvent, extraOpts *optsGetSuppliers) (sortedSuppls *SortedSuppliers, err error) {
+	ratios, err := loadRatios(extraOpts.sortingParameters)
+	if err != nil {
+		return nil, fmt.Errorf("<%s> profile: %s, err: %s",
+			utils.SupplierS, prflID, err.Error())
+	}
+	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
+		Sorting:         lds.sorting,
+		SortedSuppliers: make([]*SortedSupplier, 0)}
+	var errSuppls []*SortedSupplier // suppliers failing to compute their sorting data
+	for _, s := range suppls {
+		ratio, has := ratios[s.ID]
+		if !has {
+			ratio = ratios[utils.MetaDefault]
+		}
+		if ratio <= 0 { // supplier should not receive traffic
+			continue
+		}
+		usage, err := lds.spS.resourceUsage(ev.Tenant, s.ResourceIDs)
+		if err != nil {
+			errSuppls = append(errSuppls, newErroredSortedSupplier(s, err))
+			continue
+		}
+		sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers,
+			&SortedSupplier{
+				SupplierID: s.ID,
+				SortingData: map[string]interface{}{
+					utils.Weight:        s.Weight,
+					utils.ResourceUsage: usage,
+					utils.Ratio:         ratio,
+					utils.Load:          usage / ratio,
+				},
+				SupplierParameters: s.SupplierParameters})
+	}
+	if len(sortedSuppls.SortedSuppliers) == 0 && len(errSuppls) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	sortedSuppls.SortLoadDistribution()
+	sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, errSuppls...)
+	return
+}
@@ -0,0 +1,81 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewQOSSupplierSorter(spS *SupplierService) *QOSSupplierSorter {
+	return &QOSSupplierSorter{spS: spS,
+		sorting: utils.MetaQOS}
+}
+
+// QOSSupplierSorter sorts suppliers based on their StatS metrics,
+// metrics to sort on are given in the SortingParameters of the profile, optionally with the direction, eg: *pdd:*asc
+type QOSSupplierSorter struct {
+	sorting string
+	spS     *SupplierService
+}
+
+func (qos *QOSSupplierSorter) SortSuppliers(prflID string, suppls []*Supplier,
+	ev *utils.CGREvent, extraOpts *optsGetSuppliers) (sortedSuppls *SortedSuppliers, err error) {
+	if len(extraOpts.sortingParameters) == 0 {
+		return nil, fmt.Errorf("<%s> profile: %s missing sorting parameters for %s strategy",
+			utils.SupplierS, prflID, qos.sorting)
+	}
+	qosParams, err := newQOSSortingParams(extraOpts.sortingParameters)
+	if err != nil {
+		return nil, fmt.Errorf("<%s> profile: %s %s", utils.SupplierS, prflID, err.Error())
+	}
+	metricIDs := make([]string, len(qosParams))
+	for i, qosParam := range qosParams {
+		metricIDs[i] = qosParam.MetricID
+	}
+	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
+		Sorting:         qos.sorting,
+		SortedSuppliers: make([]*SortedSupplier, 0)}
+	var errSuppls []*SortedSupplier // suppliers failing to compute their sorting data
+	for _, s := range suppls {
+		metrics, err := qos.spS.statMetrics(ev.Tenant, s.StatIDs, metricIDs)
+		if err != nil {
+			errSuppls = append(errSuppls, newErroredSortedSupplier(s, err))
+			continue
+		}
+		srtData := map[string]interface{}{
+			utils.Weight: s.Weight,
+		}
+		for k, v := range metrics {
+			srtData[k] = v
+		}
+		sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers,
+			&SortedSupplier{
+				SupplierID:         s.ID,
+				SortingData:        srtData,
+				SupplierParameters: s.SupplierParameters})
+	}
+	if len(sortedSuppls.SortedSuppliers) == 0 && len(errSuppls) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	sortedSuppls.SortQOS(qosParams)
+	sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, errSuppls...)
+	return
+}
@@ -0,0 +1,64 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewResourceAvailabilitySorter(spS *SupplierService) *ResourceAvailabilitySorter {
+	return &ResourceAvailabilitySorter{spS: spS,
+		sorting: utils.MetaReas}
+}
+
+// ResourceAvailabilitySorter orders suppliers based on the units still available
+// out of their resources, suppliers without resources are considered unavailable
+type ResourceAvailabilitySorter struct {
+	sorting string
+	spS     *SupplierService
+}
+
+func (ras *ResourceAvailabilitySorter) SortSuppliers(prflID string, suppls []*Supplier,
+	ev *utils.CGREvent, extraOpts *optsGetSuppliers) (sortedSuppls *SortedSuppliers, err error) {
+	sortedSuppls = &SortedSuppliers{ProfileID: prflID,
+		Sorting:         ras.sorting,
+		SortedSuppliers: make([]*SortedSupplier, 0)}
+	var errSuppls []*SortedSupplier // suppliers failing to compute their sorting data
+	for _, s := range suppls {
+		avail, err := ras.spS.resourceAvailability(ev.Tenant, s.ResourceIDs)
+		if err != nil {
+			errSuppls = append(errSuppls, newErroredSortedSupplier(s, err))
+			continue
+		}
+		sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers,
+			&SortedSupplier{
+				SupplierID: s.ID,
+				SortingData: map[string]interface{}{
+					utils.Weight:               s.Weight,
+					utils.ResourceAvailability: avail,
+				},
+				SupplierParameters: s.SupplierParameters})
+	}
+	if len(sortedSuppls.SortedSuppliers) == 0 && len(errSuppls) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	sortedSuppls.SortResourceAvailability()
+	sortedSuppls.SortedSuppliers = append(sortedSuppls.SortedSuppliers, errSuppls...)
+	return
+}
@@ -22,7 +22,10 @@ import (
 	"fmt"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -30,15 +33,23 @@ import (
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, extraParams string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string) (StatMetric, error){
-		utils.MetaASR:     NewASR,
-		utils.MetaACD:     NewACD,
-		utils.MetaTCD:     NewTCD,
-		utils.MetaACC:     NewACC,
-		utils.MetaTCC:     NewTCC,
-		utils.MetaPDD:     NewPDD,
-		utils.MetaDDC:     NewDCC,
-		utils.MetaSum:     NewStatSum,
-		utils.MetaAverage: NewStatAverage,
+		utils.MetaASR:       NewASR,
+		utils.MetaACD:       NewACD,
+		utils.MetaTCD:       NewTCD,
+		utils.MetaACC:       NewACC,
+		utils.MetaTCC:       NewTCC,
+		utils.MetaPDD:       NewPDD,
+		utils.MetaDDC:       NewDCC,
+		utils.MetaSum:       NewStatSum,
+		utils.MetaAverage:   NewStatAverage,
+		utils.MetaP50:       NewStatPercentile(50),
+		utils.MetaP95:       NewStatPercentile(95),
+		utils.MetaP99:       NewStatPercentile(99),
+		utils.MetaHistogram: NewStatHistogram,
+		utils.MetaStdDev:    NewStatStdDev,
+		utils.MetaMin:       NewStatMin,
+		utils.MetaMax:       NewStatMax,
+		utils.MetaDistinct:  NewStatDistinct,
 	}
 	if _, has := metrics[metricID]; !has {
 		return nil, fmt.Errorf("unsupported metric: %s", metricID)
@@ -47,25 +58,40 @@ func NewStatMetric(metricID string, minItems int, extraParams string) (sm StatMe
 }
 
 // StatMetric is the interface which a metric should implement
+// the metric values are recorded per item, an item being either one event (AddEvent)
+// or a time bucket aggregating all the events received within it (AddEventToBucket)
 type StatMetric interface {
 	GetValue() interface{}
 	GetStringValue(fmtOpts string) (val string)
 	GetFloat64Value() (val float64)
 	AddEvent(ev *utils.CGREvent) error
-	RemEvent(evTenantID string) error
+	AddEventToBucket(bucketID string, ev *utils.CGREvent) error
+	RemEvent(itemID string) error
 	Marshal(ms Marshaler) (marshaled []byte, err error)
 	LoadMarshaled(ms Marshaler, marshaled []byte) (err error)
 }
 
+// StatWithCount is the aggregated value of the events recorded under one item
+type StatWithCount struct {
+	Stat  float64
+	Count int64
+}
+
+// DurationWithCount is the aggregated duration of the events recorded under one item
+type DurationWithCount struct {
+	Duration time.Duration
+	Count    int64
+}
+
 func NewASR(minItems int, extraParams string) (StatMetric, error) {
-	return &StatASR{Events: make(map[string]bool), MinItems: minItems}, nil
+	return &StatASR{Events: make(map[string]*StatWithCount), MinItems: minItems}, nil
 }
 
 // ASR implements AverageSuccessRatio metric
 type StatASR struct {
 	Answered float64
 	Count    float64
-	Events   map[string]bool // map[EventTenantID]Answered
+	Events   map[string]*StatWithCount // map[ItemID]AnsweredEvents
 	MinItems int
 	val      *float64 // cached ASR value
 }
@@ -73,7 +99,7 @@ type StatASR struct {
 // getValue returns asr.val
 func (asr *StatASR) getValue() float64 {
 	if asr.val == nil {
-		if (asr.MinItems > 0 && len(asr.Events) < asr.MinItems) || (asr.Count == 0) {
+		if (asr.MinItems > 0 && asr.Count < float64(asr.MinItems)) || (asr.Count == 0) {
 			asr.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			asr.val = utils.Float64Pointer(utils.Round((asr.Answered / asr.Count * 100),
@@ -103,7 +129,14 @@ func (asr *StatASR) GetFloat64Value() (val float64) {
 }
 
 // AddEvent is part of StatMetric interface
+// an event processed again replaces its previous value
 func (asr *StatASR) AddEvent(ev *utils.CGREvent) (err error) {
+	asr.RemEvent(ev.TenantID())
+	return asr.AddEventToBucket(ev.TenantID(), ev)
+}
+
+// AddEventToBucket is part of StatMetric interface
+func (asr *StatASR) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var answered bool
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil &&
 		err != utils.ErrNotFound {
@@ -111,25 +144,27 @@ func (asr *StatASR) AddEvent(ev *utils.CGREvent) (err error) {
 	} else if !at.IsZero() {
 		answered = true
 	}
-	asr.Events[ev.TenantID()] = answered
+	if _, has := asr.Events[bucketID]; !has {
+		asr.Events[bucketID] = new(StatWithCount)
+	}
+	asr.Events[bucketID].Count += 1
 	asr.Count += 1
 	if answered {
+		asr.Events[bucketID].Stat += 1
 		asr.Answered += 1
 	}
 	asr.val = nil
 	return
 }
 
-func (asr *StatASR) RemEvent(evTenantID string) (err error) {
-	answered, has := asr.Events[evTenantID]
+func (asr *StatASR) RemEvent(itemID string) (err error) {
+	itm, has := asr.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if answered {
-		asr.Answered -= 1
-	}
-	asr.Count -= 1
-	delete(asr.Events, evTenantID)
+	asr.Answered -= itm.Stat
+	asr.Count -= float64(itm.Count)
+	delete(asr.Events, itemID)
 	asr.val = nil
 	return
 }
@@ -145,14 +180,14 @@ func (asr *StatASR) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewACD(minItems int, extraParams string) (StatMetric, error) {
-	return &StatACD{Events: make(map[string]time.Duration), MinItems: minItems}, nil
+	return &StatACD{Events: make(map[string]*DurationWithCount), MinItems: minItems}, nil
 }
 
 // ACD implements AverageCallDuration metric
 type StatACD struct {
 	Sum      time.Duration
 	Count    int64
-	Events   map[string]time.Duration // map[EventTenantID]Duration
+	Events   map[string]*DurationWithCount // map[ItemID]Duration
 	MinItems int
 	val      *time.Duration // cached ACD value
 }
@@ -160,7 +195,7 @@ type StatACD struct {
 // getValue returns acr.val
 func (acd *StatACD) getValue() time.Duration {
 	if acd.val == nil {
-		if (acd.MinItems > 0 && len(acd.Events) < acd.MinItems) || (acd.Count == 0) {
+		if (acd.MinItems > 0 && acd.Count < int64(acd.MinItems)) || (acd.Count == 0) {
 			acd.val = utils.DurationPointer(time.Duration((-1) * time.Nanosecond))
 		} else {
 			acd.val = utils.DurationPointer(time.Duration(acd.Sum.Nanoseconds() / acd.Count))
@@ -191,7 +226,13 @@ func (acd *StatACD) GetFloat64Value() (v float64) {
 	return
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (acd *StatACD) AddEvent(ev *utils.CGREvent) (err error) {
+	acd.RemEvent(ev.TenantID())
+	return acd.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (acd *StatACD) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value time.Duration
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil {
 		return err
@@ -204,22 +245,24 @@ func (acd *StatACD) AddEvent(ev *utils.CGREvent) (err error) {
 			acd.Sum += duration
 		}
 	}
-	acd.Events[ev.TenantID()] = value
+	if _, has := acd.Events[bucketID]; !has {
+		acd.Events[bucketID] = new(DurationWithCount)
+	}
+	acd.Events[bucketID].Duration += value
+	acd.Events[bucketID].Count += 1
 	acd.Count += 1
 	acd.val = nil
 	return
 }
 
-func (acd *StatACD) RemEvent(evTenantID string) (err error) {
-	duration, has := acd.Events[evTenantID]
+func (acd *StatACD) RemEvent(itemID string) (err error) {
+	itm, has := acd.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if duration != 0 {
-		acd.Sum -= duration
-	}
-	acd.Count -= 1
-	delete(acd.Events, evTenantID)
+	acd.Sum -= itm.Duration
+	acd.Count -= itm.Count
+	delete(acd.Events, itemID)
 	acd.val = nil
 	return
 }
@@ -232,14 +275,14 @@ func (acd *StatACD) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewTCD(minItems int, extraParams string) (StatMetric, error) {
-	return &StatTCD{Events: make(map[string]time.Duration), MinItems: minItems}, nil
+	return &StatTCD{Events: make(map[string]*DurationWithCount), MinItems: minItems}, nil
 }
 
 // TCD implements TotalCallDuration metric
 type StatTCD struct {
 	Sum      time.Duration
 	Count    int64
-	Events   map[string]time.Duration // map[EventTenantID]Duration
+	Events   map[string]*DurationWithCount // map[ItemID]Duration
 	MinItems int
 	val      *time.Duration // cached TCD value
 }
@@ -247,7 +290,7 @@ type StatTCD struct {
 // getValue returns tcd.val
 func (tcd *StatTCD) getValue() time.Duration {
 	if tcd.val == nil {
-		if (tcd.MinItems > 0 && len(tcd.Events) < tcd.MinItems) || (tcd.Count == 0) {
+		if (tcd.MinItems > 0 && tcd.Count < int64(tcd.MinItems)) || (tcd.Count == 0) {
 			tcd.val = utils.DurationPointer(time.Duration((-1) * time.Nanosecond))
 		} else {
 			tcd.val = utils.DurationPointer(time.Duration(tcd.Sum.Nanoseconds()))
@@ -278,7 +321,13 @@ func (tcd *StatTCD) GetFloat64Value() (v float64) {
 	return
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (tcd *StatTCD) AddEvent(ev *utils.CGREvent) (err error) {
+	tcd.RemEvent(ev.TenantID())
+	return tcd.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (tcd *StatTCD) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value time.Duration
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil {
 		return err
@@ -292,22 +341,24 @@ func (tcd *StatTCD) AddEvent(ev *utils.CGREvent) (err error) {
 		}
 
 	}
-	tcd.Events[ev.TenantID()] = value
+	if _, has := tcd.Events[bucketID]; !has {
+		tcd.Events[bucketID] = new(DurationWithCount)
+	}
+	tcd.Events[bucketID].Duration += value
+	tcd.Events[bucketID].Count += 1
 	tcd.Count += 1
 	tcd.val = nil
 	return
 }
 
-func (tcd *StatTCD) RemEvent(evTenantID string) (err error) {
-	duration, has := tcd.Events[evTenantID]
+func (tcd *StatTCD) RemEvent(itemID string) (err error) {
+	itm, has := tcd.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if duration != 0 {
-		tcd.Sum -= duration
-	}
-	tcd.Count -= 1
-	delete(tcd.Events, evTenantID)
+	tcd.Sum -= itm.Duration
+	tcd.Count -= itm.Count
+	delete(tcd.Events, itemID)
 	tcd.val = nil
 	return
 }
@@ -321,14 +372,14 @@ func (tcd *StatTCD) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewACC(minItems int, extraParams string) (StatMetric, error) {
-	return &StatACC{Events: make(map[string]float64), MinItems: minItems}, nil
+	return &StatACC{Events: make(map[string]*StatWithCount), MinItems: minItems}, nil
 }
 
 // ACC implements AverageCallCost metric
 type StatACC struct {
 	Sum      float64
 	Count    float64
-	Events   map[string]float64 // map[EventTenantID]Cost
+	Events   map[string]*StatWithCount // map[ItemID]Cost
 	MinItems int
 	val      *float64 // cached ACC value
 }
@@ -336,7 +387,7 @@ type StatACC struct {
 // getValue returns tcd.val
 func (acc *StatACC) getValue() float64 {
 	if acc.val == nil {
-		if (acc.MinItems > 0 && len(acc.Events) < acc.MinItems) || (acc.Count == 0) {
+		if (acc.MinItems > 0 && acc.Count < float64(acc.MinItems)) || (acc.Count == 0) {
 			acc.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			acc.val = utils.Float64Pointer(utils.Round((acc.Sum / acc.Count),
@@ -364,7 +415,13 @@ func (acc *StatACC) GetFloat64Value() (v float64) {
 	return acc.getValue()
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (acc *StatACC) AddEvent(ev *utils.CGREvent) (err error) {
+	acc.RemEvent(ev.TenantID())
+	return acc.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (acc *StatACC) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value float64
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil {
 		return err
@@ -377,22 +434,24 @@ func (acc *StatACC) AddEvent(ev *utils.CGREvent) (err error) {
 			acc.Sum += cost
 		}
 	}
-	acc.Events[ev.TenantID()] = value
+	if _, has := acc.Events[bucketID]; !has {
+		acc.Events[bucketID] = new(StatWithCount)
+	}
+	acc.Events[bucketID].Stat += value
+	acc.Events[bucketID].Count += 1
 	acc.Count += 1
 	acc.val = nil
 	return
 }
 
-func (acc *StatACC) RemEvent(evTenantID string) (err error) {
-	cost, has := acc.Events[evTenantID]
+func (acc *StatACC) RemEvent(itemID string) (err error) {
+	itm, has := acc.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if cost >= 0 {
-		acc.Sum -= cost
-	}
-	acc.Count -= 1
-	delete(acc.Events, evTenantID)
+	acc.Sum -= itm.Stat
+	acc.Count -= float64(itm.Count)
+	delete(acc.Events, itemID)
 	acc.val = nil
 	return
 }
@@ -406,14 +465,14 @@ func (acc *StatACC) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewTCC(minItems int, extraParams string) (StatMetric, error) {
-	return &StatTCC{Events: make(map[string]float64), MinItems: minItems}, nil
+	return &StatTCC{Events: make(map[string]*StatWithCount), MinItems: minItems}, nil
 }
 
 // TCC implements TotalCallCost metric
 type StatTCC struct {
 	Sum      float64
 	Count    float64
-	Events   map[string]float64 // map[EventTenantID]Cost
+	Events   map[string]*StatWithCount // map[ItemID]Cost
 	MinItems int
 	val      *float64 // cached TCC value
 }
@@ -421,7 +480,7 @@ type StatTCC struct {
 // getValue returns tcd.val
 func (tcc *StatTCC) getValue() float64 {
 	if tcc.val == nil {
-		if (tcc.MinItems > 0 && len(tcc.Events) < tcc.MinItems) || (tcc.Count == 0) {
+		if (tcc.MinItems > 0 && tcc.Count < float64(tcc.MinItems)) || (tcc.Count == 0) {
 			tcc.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			tcc.val = utils.Float64Pointer(utils.Round(tcc.Sum,
@@ -448,7 +507,13 @@ func (tcc *StatTCC) GetFloat64Value() (v float64) {
 	return tcc.getValue()
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (tcc *StatTCC) AddEvent(ev *utils.CGREvent) (err error) {
+	tcc.RemEvent(ev.TenantID())
+	return tcc.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (tcc *StatTCC) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value float64
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil {
 		return err
@@ -461,22 +526,24 @@ func (tcc *StatTCC) AddEvent(ev *utils.CGREvent) (err error) {
 			tcc.Sum += cost
 		}
 	}
-	tcc.Events[ev.TenantID()] = value
+	if _, has := tcc.Events[bucketID]; !has {
+		tcc.Events[bucketID] = new(StatWithCount)
+	}
+	tcc.Events[bucketID].Stat += value
+	tcc.Events[bucketID].Count += 1
 	tcc.Count += 1
 	tcc.val = nil
 	return
 }
 
-func (tcc *StatTCC) RemEvent(evTenantID string) (err error) {
-	cost, has := tcc.Events[evTenantID]
+func (tcc *StatTCC) RemEvent(itemID string) (err error) {
+	itm, has := tcc.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if cost != 0 {
-		tcc.Sum -= cost
-	}
-	tcc.Count -= 1
-	delete(tcc.Events, evTenantID)
+	tcc.Sum -= itm.Stat
+	tcc.Count -= float64(itm.Count)
+	delete(tcc.Events, itemID)
 	tcc.val = nil
 	return
 }
@@ -490,14 +557,14 @@ func (tcc *StatTCC) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewPDD(minItems int, extraParams string) (StatMetric, error) {
-	return &StatPDD{Events: make(map[string]time.Duration), MinItems: minItems}, nil
+	return &StatPDD{Events: make(map[string]*DurationWithCount), MinItems: minItems}, nil
 }
 
 // PDD implements Post Dial Delay (average) metric
 type StatPDD struct {
 	Sum      time.Duration
 	Count    int64
-	Events   map[string]time.Duration // map[EventTenantID]Duration
+	Events   map[string]*DurationWithCount // map[ItemID]Duration
 	MinItems int
 	val      *time.Duration // cached PDD value
 }
@@ -505,7 +572,7 @@ type StatPDD struct {
 // getValue returns pdd.val
 func (pdd *StatPDD) getValue() time.Duration {
 	if pdd.val == nil {
-		if (pdd.MinItems > 0 && len(pdd.Events) < pdd.MinItems) || (pdd.Count == 0) {
+		if (pdd.MinItems > 0 && pdd.Count < int64(pdd.MinItems)) || (pdd.Count == 0) {
 			pdd.val = utils.DurationPointer(time.Duration((-1) * time.Nanosecond))
 		} else {
 			pdd.val = utils.DurationPointer(time.Duration(pdd.Sum.Nanoseconds() / pdd.Count))
@@ -536,7 +603,13 @@ func (pdd *StatPDD) GetFloat64Value() (v float64) {
 	return
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (pdd *StatPDD) AddEvent(ev *utils.CGREvent) (err error) {
+	pdd.RemEvent(ev.TenantID())
+	return pdd.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (pdd *StatPDD) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value time.Duration
 	if at, err := ev.FieldAsTime(utils.AnswerTime, config.CgrConfig().DefaultTimezone); err != nil &&
 		err != utils.ErrNotFound {
@@ -550,22 +623,24 @@ func (pdd *StatPDD) AddEvent(ev *utils.CGREvent) (err error) {
 			pdd.Sum += duration
 		}
 	}
-	pdd.Events[ev.TenantID()] = value
+	if _, has := pdd.Events[bucketID]; !has {
+		pdd.Events[bucketID] = new(DurationWithCount)
+	}
+	pdd.Events[bucketID].Duration += value
+	pdd.Events[bucketID].Count += 1
 	pdd.Count += 1
 	pdd.val = nil
 	return
 }
 
-func (pdd *StatPDD) RemEvent(evTenantID string) (err error) {
-	duration, has := pdd.Events[evTenantID]
+func (pdd *StatPDD) RemEvent(itemID string) (err error) {
+	itm, has := pdd.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if duration != 0 {
-		pdd.Sum -= duration
-	}
-	pdd.Count -= 1
-	delete(pdd.Events, evTenantID)
+	pdd.Sum -= itm.Duration
+	pdd.Count -= itm.Count
+	delete(pdd.Events, itemID)
 	pdd.val = nil
 	return
 }
@@ -578,18 +653,20 @@ func (pdd *StatPDD) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewDCC(minItems int, extraParams string) (StatMetric, error) {
-	return &StatDDC{Destinations: make(map[string]utils.StringMap), Events: make(map[string]string), MinItems: minItems}, nil
+	return &StatDDC{Destinations: make(map[string]utils.StringMap),
+		Events: make(map[string]map[string]int64), MinItems: minItems}, nil
 }
 
 // DDC implements Destination Distinct Count metric
 type StatDDC struct {
-	Destinations map[string]utils.StringMap
-	Events       map[string]string // map[EventTenantID]Destination
+	Destinations map[string]utils.StringMap  // map[Destination]map[ItemID]bool
+	Events       map[string]map[string]int64 // map[ItemID]map[Destination]NumberOfEvents
+	Count        int64
 	MinItems     int
 }
 
 func (ddc *StatDDC) GetStringValue(fmtOpts string) (valStr string) {
-	if val := len(ddc.Destinations); (val == 0) || (ddc.MinItems > 0 && len(ddc.Events) < ddc.MinItems) {
+	if val := len(ddc.Destinations); (val == 0) || (ddc.MinItems > 0 && ddc.Count < int64(ddc.MinItems)) {
 		valStr = utils.NOT_AVAILABLE
 	} else {
 		valStr = fmt.Sprintf("%+v", len(ddc.Destinations))
@@ -602,7 +679,7 @@ func (ddc *StatDDC) GetValue() (v interface{}) {
 }
 
 func (ddc *StatDDC) GetFloat64Value() (v float64) {
-	if val := len(ddc.Destinations); (val == 0) || (ddc.MinItems > 0 && len(ddc.Events) < ddc.MinItems) {
+	if val := len(ddc.Destinations); (val == 0) || (ddc.MinItems > 0 && ddc.Count < int64(ddc.MinItems)) {
 		v = -1.0
 	} else {
 		v = float64(len(ddc.Destinations))
@@ -610,46 +687,59 @@ func (ddc *StatDDC) GetFloat64Value() (v float64) {
 	return
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (ddc *StatDDC) AddEvent(ev *utils.CGREvent) (err error) {
+	ddc.RemEvent(ev.TenantID())
+	return ddc.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (ddc *StatDDC) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var dest string
 	if dest, err = ev.FieldAsString(utils.Destination); err != nil {
 		return err
 	}
 	if _, has := ddc.Destinations[dest]; !has {
-		ddc.Destinations[dest] = make(map[string]bool)
+		ddc.Destinations[dest] = make(utils.StringMap)
+	}
+	ddc.Destinations[dest][bucketID] = true
+	if _, has := ddc.Events[bucketID]; !has {
+		ddc.Events[bucketID] = make(map[string]int64)
 	}
-	ddc.Destinations[dest][ev.TenantID()] = true
-	ddc.Events[ev.TenantID()] = dest
+	ddc.Events[bucketID][dest] += 1
+	ddc.Count += 1
 	return
 }
 
-func (ddc *StatDDC) RemEvent(evTenantID string) (err error) {
-	destination, has := ddc.Events[evTenantID]
+func (ddc *StatDDC) RemEvent(itemID string) (err error) {
+	itm, has := ddc.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	delete(ddc.Events, evTenantID)
-	if len(ddc.Destinations[destination]) == 1 {
-		delete(ddc.Destinations, destination)
-		return
+	delete(ddc.Events, itemID)
+	for dest, cnt := range itm {
+		ddc.Count -= cnt
+		delete(ddc.Destinations[dest], itemID)
+		if len(ddc.Destinations[dest]) == 0 {
+			delete(ddc.Destinations, dest)
+		}
 	}
-	delete(ddc.Destinations[destination], evTenantID)
 	return
 }
 
 func (ddc *StatDDC) Marshal(ms Marshaler) (marshaled []byte, err error) {
-	return ms.Marshal(DDC)
+	return ms.Marshal(ddc)
 }
 func (ddc *StatDDC) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 	return ms.Unmarshal(marshaled, ddc)
 }
 func NewStatSum(minItems int, extraParams string) (StatMetric, error) {
-	return &StatSum{Events: make(map[string]float64), MinItems: minItems, FieldName: extraParams}, nil
+	return &StatSum{Events: make(map[string]*StatWithCount), MinItems: minItems, FieldName: extraParams}, nil
 }
 
 type StatSum struct {
 	Sum       float64
-	Events    map[string]float64 // map[EventTenantID]Cost
+	Count     int64
+	Events    map[string]*StatWithCount // map[ItemID]Value
 	MinItems  int
 	FieldName string
 	val       *float64 // cached sum value
@@ -658,7 +748,7 @@ type StatSum struct {
 // getValue returns tcd.val
 func (sum *StatSum) getValue() float64 {
 	if sum.val == nil {
-		if len(sum.Events) == 0 || len(sum.Events) < sum.MinItems {
+		if sum.Count == 0 || sum.Count < int64(sum.MinItems) {
 			sum.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			sum.val = utils.Float64Pointer(utils.Round(sum.Sum,
@@ -685,7 +775,13 @@ func (sum *StatSum) GetFloat64Value() (v float64) {
 	return sum.getValue()
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (sum *StatSum) AddEvent(ev *utils.CGREvent) (err error) {
+	sum.RemEvent(ev.TenantID())
+	return sum.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (sum *StatSum) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	var value float64
 	if val, err := ev.FieldAsFloat64(sum.FieldName); err != nil &&
 		err != utils.ErrNotFound {
@@ -694,20 +790,24 @@ func (sum *StatSum) AddEvent(ev *utils.CGREvent) (err error) {
 		value = val
 		sum.Sum += val
 	}
-	sum.Events[ev.TenantID()] = value
+	if _, has := sum.Events[bucketID]; !has {
+		sum.Events[bucketID] = new(StatWithCount)
+	}
+	sum.Events[bucketID].Stat += value
+	sum.Events[bucketID].Count += 1
+	sum.Count += 1
 	sum.val = nil
 	return
 }
 
-func (sum *StatSum) RemEvent(evTenantID string) (err error) {
-	val, has := sum.Events[evTenantID]
+func (sum *StatSum) RemEvent(itemID string) (err error) {
+	itm, has := sum.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if val != 0 {
-		sum.Sum -= val
-	}
-	delete(sum.Events, evTenantID)
+	sum.Sum -= itm.Stat
+	sum.Count -= itm.Count
+	delete(sum.Events, itemID)
 	sum.val = nil
 	return
 }
@@ -721,14 +821,14 @@ func (sum *StatSum) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 }
 
 func NewStatAverage(minItems int, extraParams string) (StatMetric, error) {
-	return &StatAverage{Events: make(map[string]float64), MinItems: minItems, FieldName: extraParams}, nil
+	return &StatAverage{Events: make(map[string]*StatWithCount), MinItems: minItems, FieldName: extraParams}, nil
 }
 
 // StatAverage implements TotalCallCost metric
 type StatAverage struct {
 	Sum       float64
 	Count     float64
-	Events    map[string]float64 // map[EventTenantID]Cost
+	Events    map[string]*StatWithCount // map[ItemID]Value
 	MinItems  int
 	FieldName string
 	val       *float64 // cached avg value
@@ -737,7 +837,7 @@ type StatAverage struct {
 // getValue returns tcd.val
 func (avg *StatAverage) getValue() float64 {
 	if avg.val == nil {
-		if (avg.MinItems > 0 && len(avg.Events) < avg.MinItems) || (avg.Count == 0) {
+		if (avg.MinItems > 0 && avg.Count < float64(avg.MinItems)) || (avg.Count == 0) {
 			avg.val = utils.Float64Pointer(STATS_NA)
 		} else {
 			avg.val = utils.Float64Pointer(utils.Round((avg.Sum / avg.Count),
@@ -765,31 +865,37 @@ func (avg *StatAverage) GetFloat64Value() (v float64) {
 	return avg.getValue()
 }
 
+// AddEvent is part of StatMetric interface, an event processed again replaces its previous value
 func (avg *StatAverage) AddEvent(ev *utils.CGREvent) (err error) {
-	var value float64
+	avg.RemEvent(ev.TenantID())
+	return avg.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (avg *StatAverage) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
 	if val, err := ev.FieldAsFloat64(avg.FieldName); err != nil &&
 		err != utils.ErrNotFound {
 		return err
 	} else if val > 0 {
-		value = val
 		avg.Sum += val
-		avg.Events[ev.TenantID()] = value
+		if _, has := avg.Events[bucketID]; !has {
+			avg.Events[bucketID] = new(StatWithCount)
+		}
+		avg.Events[bucketID].Stat += val
+		avg.Events[bucketID].Count += 1
 		avg.Count += 1
 		avg.val = nil
 	}
 	return
 }
 
-func (avg *StatAverage) RemEvent(evTenantID string) (err error) {
-	val, has := avg.Events[evTenantID]
+func (avg *StatAverage) RemEvent(itemID string) (err error) {
+	itm, has := avg.Events[itemID]
 	if !has {
 		return utils.ErrNotFound
 	}
-	if avg.Events[avg.FieldName] >= 0 {
-		avg.Sum -= val
-	}
-	avg.Count -= 1
-	delete(avg.Events, evTenantID)
+	avg.Sum -= itm.Stat
+	avg.Count -= float64(itm.Count)
+	delete(avg.Events, itemID)
 	avg.val = nil
 	return
 }
@@ -801,3 +907,701 @@ func (avg *StatAverage) Marshal(ms Marshaler) (marshaled []byte, err error) {
 func (avg *StatAverage) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
 	return ms.Unmarshal(marshaled, avg)
 }
+
+// NewStatPercentile returns the constructor of a StatPercentile computing the given percentile
+func NewStatPercentile(percentile float64) func(int, string) (StatMetric, error) {
+	return func(minItems int, extraParams string) (StatMetric, error) {
+		return &StatPercentile{Events: make(map[string][]*StatCentroid), MinItems: minItems,
+			FieldName: extraParams, Percentile: percentile}, nil
+	}
+}
+
+// statCentroidsLimit is the maximum number of centroids kept for one item,
+// bounding the memory of a bucket no matter how many events it aggregates
+const statCentroidsLimit = 100
+
+// StatCentroid summarizes Count values close to Mean
+type StatCentroid struct {
+	Mean  float64
+	Count int64
+}
+
+// statCentroids is a list of centroids ordered by Mean
+type statCentroids []*StatCentroid
+
+// add inserts the value, merging the closest centroids once over statCentroidsLimit
+// the merge cost grows towards the median so the tails, relevant for high percentiles, stay accurate
+func (scs statCentroids) add(val float64) statCentroids {
+	idx := sort.Search(len(scs), func(i int) bool { return scs[i].Mean >= val })
+	if idx < len(scs) && scs[idx].Mean == val {
+		scs[idx].Count += 1
+		return scs
+	}
+	scs = append(scs, nil)
+	copy(scs[idx+1:], scs[idx:])
+	scs[idx] = &StatCentroid{Mean: val, Count: 1}
+	if len(scs) <= statCentroidsLimit {
+		return scs
+	}
+	var total, cumulated int64
+	for _, sc := range scs {
+		total += sc.Count
+	}
+	mrgIdx := 0
+	minCost := math.Inf(1)
+	for i := 0; i < len(scs)-1; i++ {
+		cnt := scs[i].Count + scs[i+1].Count
+		q := (float64(cumulated) + float64(cnt)/2) / float64(total)
+		if cost := (scs[i+1].Mean - scs[i].Mean) * float64(cnt) / (q * (1 - q)); cost < minCost {
+			mrgIdx, minCost = i, cost
+		}
+		cumulated += scs[i].Count
+	}
+	sc1, sc2 := scs[mrgIdx], scs[mrgIdx+1]
+	cnt := sc1.Count + sc2.Count
+	sc1.Mean = (sc1.Mean*float64(sc1.Count) + sc2.Mean*float64(sc2.Count)) / float64(cnt)
+	sc1.Count = cnt
+	return append(scs[:mrgIdx+1], scs[mrgIdx+2:]...)
+}
+
+// valueAt returns the value found at idx when expanding the centroids
+func (scs statCentroids) valueAt(idx int64) float64 {
+	for _, sc := range scs {
+		if idx < sc.Count {
+			return sc.Mean
+		}
+		idx -= sc.Count
+	}
+	return scs[len(scs)-1].Mean
+}
+
+// StatPercentile implements the percentile metric (*p50, *p95, *p99) over an event field
+// the values of an item are summarized as centroids, exact as long as they are not merged
+type StatPercentile struct {
+	Percentile float64
+	Count      int64
+	Events     map[string][]*StatCentroid // map[ItemID]Centroids
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns pct.val, interpolating linearly between the closest ranks
+func (pct *StatPercentile) getValue() float64 {
+	if pct.val == nil {
+		if (pct.MinItems > 0 && pct.Count < int64(pct.MinItems)) || (pct.Count == 0) {
+			pct.val = utils.Float64Pointer(STATS_NA)
+		} else {
+			var scs statCentroids
+			for _, itmScs := range pct.Events {
+				scs = append(scs, itmScs...)
+			}
+			sort.Slice(scs, func(i, j int) bool { return scs[i].Mean < scs[j].Mean })
+			rank := pct.Percentile / 100 * float64(pct.Count-1)
+			lowIdx := int64(math.Floor(rank))
+			lowVal, highVal := scs.valueAt(lowIdx), scs.valueAt(int64(math.Ceil(rank)))
+			val := lowVal + (highVal-lowVal)*(rank-float64(lowIdx))
+			pct.val = utils.Float64Pointer(utils.Round(val,
+				config.CgrConfig().RoundingDecimals, utils.ROUNDING_MIDDLE))
+		}
+	}
+	return *pct.val
+}
+
+func (pct *StatPercentile) GetStringValue(fmtOpts string) (valStr string) {
+	if val := pct.getValue(); val == STATS_NA {
+		valStr = utils.NOT_AVAILABLE
+	} else {
+		valStr = strconv.FormatFloat(pct.getValue(), 'f', -1, 64)
+	}
+	return
+}
+
+func (pct *StatPercentile) GetValue() (v interface{}) {
+	return pct.getValue()
+}
+
+func (pct *StatPercentile) GetFloat64Value() (v float64) {
+	return pct.getValue()
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (pct *StatPercentile) AddEvent(ev *utils.CGREvent) (err error) {
+	pct.RemEvent(ev.TenantID())
+	return pct.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (pct *StatPercentile) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsFloat64(pct.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	pct.Events[bucketID] = statCentroids(pct.Events[bucketID]).add(val)
+	pct.Count += 1
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) RemEvent(itemID string) (err error) {
+	itmScs, has := pct.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	for _, sc := range itmScs {
+		pct.Count -= sc.Count
+	}
+	delete(pct.Events, itemID)
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(pct)
+}
+
+func (pct *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, pct)
+}
+
+// NewStatHistogram instantiates a StatHistogram
+// extraParams are in the form FieldName:bound1;bound2;boundN with bounds as numbers or durations
+func NewStatHistogram(minItems int, extraParams string) (StatMetric, error) {
+	hst := &StatHistogram{Events: make(map[string][]int64), MinItems: minItems}
+	if extraParams != "" {
+		paramsSplt := strings.SplitN(extraParams, utils.InInFieldSep, 2)
+		hst.FieldName = paramsSplt[0]
+		if len(paramsSplt) == 2 {
+			for _, boundStr := range strings.Split(paramsSplt[1], utils.INFIELD_SEP) {
+				bound, err := strconv.ParseFloat(boundStr, 64)
+				if err != nil {
+					dur, errDur := time.ParseDuration(boundStr)
+					if errDur != nil {
+						return nil, fmt.Errorf("invalid histogram bound: %s", boundStr)
+					}
+					bound = float64(dur.Nanoseconds())
+				}
+				hst.Bounds = append(hst.Bounds, bound)
+			}
+			sort.Float64s(hst.Bounds)
+		}
+	}
+	hst.Counts = make([]int64, len(hst.Bounds)+1)
+	return hst, nil
+}
+
+// StatHistogram implements a bucketed distribution metric over an event field
+// the last bucket counts the values above the highest bound
+type StatHistogram struct {
+	Bounds    []float64          // upper bounds of the buckets, ascending
+	Counts    []int64            // number of events in each bucket, len(Bounds)+1
+	Count     int64              // number of events considered
+	Events    map[string][]int64 // map[ItemID]Counts
+	MinItems  int
+	FieldName string
+}
+
+// isNA returns true if there are not enough events to report the histogram
+func (hst *StatHistogram) isNA() bool {
+	return hst.Count == 0 || (hst.MinItems > 0 && hst.Count < int64(hst.MinItems))
+}
+
+// bucketLabel returns the label of the bucket with index idx
+func (hst *StatHistogram) bucketLabel(idx int) string {
+	if idx == len(hst.Bounds) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(hst.Bounds[idx], 'f', -1, 64)
+}
+
+// GetValue returns the number of events per bucket, indexed on the bucket upper bound
+func (hst *StatHistogram) GetValue() (v interface{}) {
+	if hst.isNA() {
+		return utils.NOT_AVAILABLE
+	}
+	buckets := make(map[string]int64, len(hst.Counts))
+	for i, cnt := range hst.Counts {
+		buckets[hst.bucketLabel(i)] = cnt
+	}
+	return buckets
+}
+
+// GetStringValue returns the buckets as bound:count pairs, ie: 10:3;30:5;+Inf:1
+func (hst *StatHistogram) GetStringValue(fmtOpts string) (valStr string) {
+	if hst.isNA() {
+		return utils.NOT_AVAILABLE
+	}
+	buckets := make([]string, len(hst.Counts))
+	for i, cnt := range hst.Counts {
+		buckets[i] = hst.bucketLabel(i) + utils.InInFieldSep + strconv.FormatInt(cnt, 10)
+	}
+	return strings.Join(buckets, utils.INFIELD_SEP)
+}
+
+// GetFloat64Value returns the number of events considered by the histogram, -1 if not available
+// the buckets cannot be represented as one number, thresholds can only check the events counted
+func (hst *StatHistogram) GetFloat64Value() (v float64) {
+	if hst.isNA() {
+		return STATS_NA
+	}
+	return float64(hst.Count)
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (hst *StatHistogram) AddEvent(ev *utils.CGREvent) (err error) {
+	hst.RemEvent(ev.TenantID())
+	return hst.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (hst *StatHistogram) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsFloat64(hst.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	idx := sort.SearchFloat64s(hst.Bounds, val) // first bound >= val
+	if _, has := hst.Events[bucketID]; !has {
+		hst.Events[bucketID] = make([]int64, len(hst.Counts))
+	}
+	hst.Events[bucketID][idx] += 1
+	hst.Counts[idx] += 1
+	hst.Count += 1
+	return
+}
+
+func (hst *StatHistogram) RemEvent(itemID string) (err error) {
+	itmCounts, has := hst.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	for idx, cnt := range itmCounts {
+		hst.Counts[idx] -= cnt
+		hst.Count -= cnt
+	}
+	delete(hst.Events, itemID)
+	return
+}
+
+func (hst *StatHistogram) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(hst)
+}
+
+func (hst *StatHistogram) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, hst)
+}
+
+func NewStatStdDev(minItems int, extraParams string) (StatMetric, error) {
+	return &StatStdDev{Events: make(map[string]*StatWithSquares), MinItems: minItems, FieldName: extraParams}, nil
+}
+
+// StatWithSquares is the aggregated value of the events recorded under one item, including the sum of squares
+type StatWithSquares struct {
+	Stat    float64
+	Squares float64
+	Count   int64
+}
+
+// StatStdDev implements the (population) standard deviation metric over an event field
+type StatStdDev struct {
+	Sum        float64
+	SumSquares float64
+	Count      float64
+	Events     map[string]*StatWithSquares // map[ItemID]Values
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached stddev value
+}
+
+// getValue returns sd.val
+func (sd *StatStdDev) getValue() float64 {
+	if sd.val == nil {
+		if (sd.MinItems > 0 && sd.Count < float64(sd.MinItems)) || (sd.Count == 0) {
+			sd.val = utils.Float64Pointer(STATS_NA)
+		} else {
+			mean := sd.Sum / sd.Count
+			variance := sd.SumSquares/sd.Count - mean*mean
+			if variance < 0 { // floating point errors after removals
+				variance = 0
+			}
+			sd.val = utils.Float64Pointer(utils.Round(math.Sqrt(variance),
+				config.CgrConfig().RoundingDecimals, utils.ROUNDING_MIDDLE))
+		}
+	}
+	return *sd.val
+}
+
+func (sd *StatStdDev) GetStringValue(fmtOpts string) (valStr string) {
+	if val := sd.getValue(); val == STATS_NA {
+		valStr = utils.NOT_AVAILABLE
+	} else {
+		valStr = strconv.FormatFloat(sd.getValue(), 'f', -1, 64)
+	}
+	return
+}
+
+func (sd *StatStdDev) GetValue() (v interface{}) {
+	return sd.getValue()
+}
+
+func (sd *StatStdDev) GetFloat64Value() (v float64) {
+	return sd.getValue()
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (sd *StatStdDev) AddEvent(ev *utils.CGREvent) (err error) {
+	sd.RemEvent(ev.TenantID())
+	return sd.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (sd *StatStdDev) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsFloat64(sd.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if _, has := sd.Events[bucketID]; !has {
+		sd.Events[bucketID] = new(StatWithSquares)
+	}
+	sd.Events[bucketID].Stat += val
+	sd.Events[bucketID].Squares += val * val
+	sd.Events[bucketID].Count += 1
+	sd.Sum += val
+	sd.SumSquares += val * val
+	sd.Count += 1
+	sd.val = nil
+	return
+}
+
+func (sd *StatStdDev) RemEvent(itemID string) (err error) {
+	itm, has := sd.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	sd.Sum -= itm.Stat
+	sd.SumSquares -= itm.Squares
+	sd.Count -= float64(itm.Count)
+	delete(sd.Events, itemID)
+	sd.val = nil
+	return
+}
+
+func (sd *StatStdDev) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(sd)
+}
+
+func (sd *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, sd)
+}
+
+// sortedFloat64s is a multiset of values kept in ascending order,
+// allowing min/max to be maintained when values are removed
+type sortedFloat64s []float64
+
+// add inserts the value keeping the order
+func (sf *sortedFloat64s) add(val float64) {
+	idx := sort.SearchFloat64s(*sf, val)
+	*sf = append(*sf, 0)
+	copy((*sf)[idx+1:], (*sf)[idx:])
+	(*sf)[idx] = val
+}
+
+// rem removes one occurrence of the value
+func (sf *sortedFloat64s) rem(val float64) {
+	idx := sort.SearchFloat64s(*sf, val)
+	if idx == len(*sf) || (*sf)[idx] != val {
+		return
+	}
+	*sf = append((*sf)[:idx], (*sf)[idx+1:]...)
+}
+
+// newSortedFloat64s builds the multiset out of the item values
+func newSortedFloat64s(itms map[string]*StatWithCount) (sf sortedFloat64s) {
+	sf = make(sortedFloat64s, 0, len(itms))
+	for _, itm := range itms {
+		sf = append(sf, itm.Stat)
+	}
+	sort.Float64s(sf)
+	return
+}
+
+func NewStatMin(minItems int, extraParams string) (StatMetric, error) {
+	return &StatMin{Events: make(map[string]*StatWithCount), MinItems: minItems, FieldName: extraParams}, nil
+}
+
+// StatMin implements the minimum value metric over an event field
+type StatMin struct {
+	Count     int64
+	Events    map[string]*StatWithCount // map[ItemID]MinimumValue
+	MinItems  int
+	FieldName string
+	values    sortedFloat64s // ordered item values, rebuilt on load
+}
+
+// getValue returns the smallest value in the queue
+func (sMin *StatMin) getValue() float64 {
+	if (sMin.MinItems > 0 && sMin.Count < int64(sMin.MinItems)) || len(sMin.values) == 0 {
+		return STATS_NA
+	}
+	return sMin.values[0]
+}
+
+func (sMin *StatMin) GetStringValue(fmtOpts string) (valStr string) {
+	if val := sMin.getValue(); val == STATS_NA {
+		valStr = utils.NOT_AVAILABLE
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (sMin *StatMin) GetValue() (v interface{}) {
+	return sMin.getValue()
+}
+
+func (sMin *StatMin) GetFloat64Value() (v float64) {
+	return sMin.getValue()
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (sMin *StatMin) AddEvent(ev *utils.CGREvent) (err error) {
+	sMin.RemEvent(ev.TenantID())
+	return sMin.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (sMin *StatMin) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsFloat64(sMin.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if itm, has := sMin.Events[bucketID]; !has {
+		sMin.Events[bucketID] = &StatWithCount{Stat: val, Count: 1}
+		sMin.values.add(val)
+	} else {
+		if val < itm.Stat {
+			sMin.values.rem(itm.Stat)
+			sMin.values.add(val)
+			itm.Stat = val
+		}
+		itm.Count += 1
+	}
+	sMin.Count += 1
+	return
+}
+
+func (sMin *StatMin) RemEvent(itemID string) (err error) {
+	itm, has := sMin.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	sMin.values.rem(itm.Stat)
+	sMin.Count -= itm.Count
+	delete(sMin.Events, itemID)
+	return
+}
+
+func (sMin *StatMin) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(sMin)
+}
+
+func (sMin *StatMin) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	if err = ms.Unmarshal(marshaled, sMin); err != nil {
+		return
+	}
+	sMin.values = newSortedFloat64s(sMin.Events)
+	return
+}
+
+func NewStatMax(minItems int, extraParams string) (StatMetric, error) {
+	return &StatMax{Events: make(map[string]*StatWithCount), MinItems: minItems, FieldName: extraParams}, nil
+}
+
+// StatMax implements the maximum value metric over an event field
+type StatMax struct {
+	Count     int64
+	Events    map[string]*StatWithCount // map[ItemID]MaximumValue
+	MinItems  int
+	FieldName string
+	values    sortedFloat64s // ordered item values, rebuilt on load
+}
+
+// getValue returns the biggest value in the queue
+func (sMax *StatMax) getValue() float64 {
+	if (sMax.MinItems > 0 && sMax.Count < int64(sMax.MinItems)) || len(sMax.values) == 0 {
+		return STATS_NA
+	}
+	return sMax.values[len(sMax.values)-1]
+}
+
+func (sMax *StatMax) GetStringValue(fmtOpts string) (valStr string) {
+	if val := sMax.getValue(); val == STATS_NA {
+		valStr = utils.NOT_AVAILABLE
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (sMax *StatMax) GetValue() (v interface{}) {
+	return sMax.getValue()
+}
+
+func (sMax *StatMax) GetFloat64Value() (v float64) {
+	return sMax.getValue()
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (sMax *StatMax) AddEvent(ev *utils.CGREvent) (err error) {
+	sMax.RemEvent(ev.TenantID())
+	return sMax.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (sMax *StatMax) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsFloat64(sMax.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if itm, has := sMax.Events[bucketID]; !has {
+		sMax.Events[bucketID] = &StatWithCount{Stat: val, Count: 1}
+		sMax.values.add(val)
+	} else {
+		if val > itm.Stat {
+			sMax.values.rem(itm.Stat)
+			sMax.values.add(val)
+			itm.Stat = val
+		}
+		itm.Count += 1
+	}
+	sMax.Count += 1
+	return
+}
+
+func (sMax *StatMax) RemEvent(itemID string) (err error) {
+	itm, has := sMax.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	sMax.values.rem(itm.Stat)
+	sMax.Count -= itm.Count
+	delete(sMax.Events, itemID)
+	return
+}
+
+func (sMax *StatMax) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(sMax)
+}
+
+func (sMax *StatMax) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	if err = ms.Unmarshal(marshaled, sMax); err != nil {
+		return
+	}
+	sMax.values = newSortedFloat64s(sMax.Events)
+	return
+}
+
+func NewStatDistinct(minItems int, extraParams string) (StatMetric, error) {
+	return &StatDistinct{FieldValues: make(map[string]utils.StringMap),
+		Events: make(map[string]map[string]int64), MinItems: minItems, FieldName: extraParams}, nil
+}
+
+// StatDistinct implements the distinct count metric over the values of an event field
+type StatDistinct struct {
+	FieldValues map[string]utils.StringMap  // map[FieldValue]map[ItemID]bool
+	Events      map[string]map[string]int64 // map[ItemID]map[FieldValue]NumberOfEvents
+	Count       int64
+	MinItems    int
+	FieldName   string
+}
+
+func (dst *StatDistinct) isNA() bool {
+	return len(dst.FieldValues) == 0 || (dst.MinItems > 0 && dst.Count < int64(dst.MinItems))
+}
+
+func (dst *StatDistinct) GetStringValue(fmtOpts string) (valStr string) {
+	if dst.isNA() {
+		valStr = utils.NOT_AVAILABLE
+	} else {
+		valStr = strconv.Itoa(len(dst.FieldValues))
+	}
+	return
+}
+
+func (dst *StatDistinct) GetValue() (v interface{}) {
+	return len(dst.FieldValues)
+}
+
+func (dst *StatDistinct) GetFloat64Value() (v float64) {
+	if dst.isNA() {
+		v = STATS_NA
+	} else {
+		v = float64(len(dst.FieldValues))
+	}
+	return
+}
+
+// AddEvent is part of StatMetric interface, events without FieldName are not considered
+// an event processed again replaces its previous value
+func (dst *StatDistinct) AddEvent(ev *utils.CGREvent) (err error) {
+	dst.RemEvent(ev.TenantID())
+	return dst.AddEventToBucket(ev.TenantID(), ev)
+}
+
+func (dst *StatDistinct) AddEventToBucket(bucketID string, ev *utils.CGREvent) (err error) {
+	val, err := ev.FieldAsString(dst.FieldName)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if _, has := dst.FieldValues[val]; !has {
+		dst.FieldValues[val] = make(utils.StringMap)
+	}
+	dst.FieldValues[val][bucketID] = true
+	if _, has := dst.Events[bucketID]; !has {
+		dst.Events[bucketID] = make(map[string]int64)
+	}
+	dst.Events[bucketID][val] += 1
+	dst.Count += 1
+	return
+}
+
+func (dst *StatDistinct) RemEvent(itemID string) (err error) {
+	itm, has := dst.Events[itemID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	delete(dst.Events, itemID)
+	for val, cnt := range itm {
+		dst.Count -= cnt
+		delete(dst.FieldValues[val], itemID)
+		if len(dst.FieldValues[val]) == 0 {
+			delete(dst.FieldValues, val)
+		}
+	}
+	return
+}
+
+func (dst *StatDistinct) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(dst)
+}
+
+func (dst *StatDistinct) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, dst)
+}
@@ -18,6 +18,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
+	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -1150,3 +1153,273 @@ func TestStatAverageGetStringValue(t *testing.T) {
 		t.Errorf("wrong statAvg value: %s", strVal)
 	}
 }
+
+func TestStatPercentileGetFloat64Value(t *testing.T) {
+	p95, _ := NewStatMetric(utils.MetaP95, 2, "Cost")
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			"Cost": 10.0}}
+	p95.AddEvent(ev)
+	if v := p95.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2"}
+	p95.AddEvent(ev2)
+	if v := p95.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	for i := 2; i <= 5; i++ {
+		p95.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: fmt.Sprintf("EVENT_%d", i+1),
+			Event: map[string]interface{}{
+				"Cost": float64(i * 10)}})
+	}
+	if v := p95.GetFloat64Value(); v != 48.0 {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	if strVal := p95.GetStringValue(""); strVal != "48" {
+		t.Errorf("wrong p95 value: %s", strVal)
+	}
+	p95.RemEvent("cgrates.org:EVENT_6")
+	if v := p95.GetFloat64Value(); v != 38.5 {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	if err := p95.RemEvent(ev2.TenantID()); err != utils.ErrNotFound {
+		t.Error(err)
+	}
+	p50, _ := NewStatMetric(utils.MetaP50, 0, "Cost")
+	p50.AddEvent(ev)
+	if v := p50.GetFloat64Value(); v != 10.0 {
+		t.Errorf("wrong p50 value: %v", v)
+	}
+	p50.RemEvent(ev.TenantID())
+	if strVal := p50.GetStringValue(""); strVal != utils.NOT_AVAILABLE {
+		t.Errorf("wrong p50 value: %s", strVal)
+	}
+}
+
+func TestStatPercentileBucketed(t *testing.T) {
+	p95, _ := NewStatMetric(utils.MetaP95, 0, "Cost")
+	for i := 1; i <= 1000; i++ {
+		p95.AddEventToBucket("BUCKET_1", &utils.CGREvent{Tenant: "cgrates.org",
+			ID: fmt.Sprintf("EVENT_%d", i), Event: map[string]interface{}{
+				"Cost": float64(i)}})
+	}
+	if scs := p95.(*StatPercentile).Events["BUCKET_1"]; len(scs) > statCentroidsLimit {
+		t.Errorf("bucket keeping %d centroids", len(scs))
+	}
+	if v := p95.GetFloat64Value(); math.Abs(v-950.05) > 9.5 { // 1% error
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	p95.AddEventToBucket("BUCKET_2", &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1001",
+		Event: map[string]interface{}{"Cost": 2000.0}})
+	if err := p95.RemEvent("BUCKET_1"); err != nil {
+		t.Error(err)
+	}
+	if v := p95.GetFloat64Value(); v != 2000.0 {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+}
+
+func TestStatHistogram(t *testing.T) {
+	if _, err := NewStatHistogram(0, "Usage:10s;a"); err == nil {
+		t.Error("expecting error on invalid bound")
+	}
+	hst, err := NewStatHistogram(2, "Usage:1m;10s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			"Usage": time.Duration(5 * time.Second)}}
+	hst.AddEvent(ev)
+	if strVal := hst.GetStringValue(""); strVal != utils.NOT_AVAILABLE {
+		t.Errorf("wrong histogram value: %s", strVal)
+	}
+	if v := hst.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	if v := hst.GetValue(); v != utils.NOT_AVAILABLE {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{
+			"Usage": time.Duration(10 * time.Second)}}
+	ev3 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_3",
+		Event: map[string]interface{}{
+			"Usage": time.Duration(2 * time.Minute)}}
+	hst.AddEvent(ev2)
+	hst.AddEvent(ev3)
+	if strVal := hst.GetStringValue(""); strVal != "10000000000:2;60000000000:0;+Inf:1" {
+		t.Errorf("wrong histogram value: %s", strVal)
+	}
+	if v := hst.GetFloat64Value(); v != 3 {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	eVal := map[string]int64{"10000000000": 1, "60000000000": 0, "+Inf": 1}
+	hst.RemEvent(ev.TenantID())
+	if v := hst.GetValue(); !reflect.DeepEqual(eVal, v) {
+		t.Errorf("expecting: %+v, received: %+v", eVal, v)
+	}
+}
+
+func TestStatStdDevGetFloat64Value(t *testing.T) {
+	sd, _ := NewStatStdDev(2, "Cost")
+	for i, cost := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		sd.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: fmt.Sprintf("EVENT_%d", i+1),
+			Event: map[string]interface{}{
+				"Cost": cost}})
+	}
+	if v := sd.GetFloat64Value(); v != 2.0 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	sd.RemEvent("cgrates.org:EVENT_1")
+	sd.RemEvent("cgrates.org:EVENT_8")
+	if strVal := sd.GetStringValue(""); strVal != "1.06719" {
+		t.Errorf("wrong stddev value: %s", strVal)
+	}
+	for i := 2; i <= 6; i++ {
+		sd.RemEvent(fmt.Sprintf("cgrates.org:EVENT_%d", i))
+	}
+	if v := sd.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+}
+
+func TestStatMinMax(t *testing.T) {
+	sMin, _ := NewStatMin(2, "Cost")
+	sMax, _ := NewStatMax(2, "Cost")
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			"Cost": 10.0}}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{
+			"Cost": 2.5}}
+	ev3 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_3",
+		Event: map[string]interface{}{
+			"Cost": 7.0}}
+	ev4 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_4"}
+	for _, sm := range []StatMetric{sMin, sMax} {
+		sm.AddEvent(ev)
+		if v := sm.GetFloat64Value(); v != -1.0 {
+			t.Errorf("wrong value: %v", v)
+		}
+		sm.AddEvent(ev2)
+		sm.AddEvent(ev3)
+		sm.AddEvent(ev4)
+	}
+	if strVal := sMin.GetStringValue(""); strVal != "2.5" {
+		t.Errorf("wrong min value: %s", strVal)
+	}
+	if strVal := sMax.GetStringValue(""); strVal != "10" {
+		t.Errorf("wrong max value: %s", strVal)
+	}
+	sMin.RemEvent(ev2.TenantID()) // queue expiry removes the current minimum
+	sMax.RemEvent(ev.TenantID())  // queue expiry removes the current maximum
+	if v := sMin.GetFloat64Value(); v != 7.0 {
+		t.Errorf("wrong min value: %v", v)
+	}
+	if v := sMax.GetFloat64Value(); v != 7.0 {
+		t.Errorf("wrong max value: %v", v)
+	}
+	ms := new(JSONMarshaler)
+	marshaled, err := sMin.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, _ := NewStatMin(2, "")
+	if err := loaded.LoadMarshaled(ms, marshaled); err != nil {
+		t.Fatal(err)
+	}
+	if v := loaded.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong min value: %v", v)
+	}
+	loaded.AddEvent(ev2)
+	if v := loaded.GetFloat64Value(); v != 2.5 {
+		t.Errorf("wrong min value: %v", v)
+	}
+}
+
+func TestStatDistinctGetValue(t *testing.T) {
+	dst, _ := NewStatDistinct(2, utils.Destination)
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			utils.Destination: "1002"}}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{
+			utils.Destination: "1002"}}
+	ev3 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_3",
+		Event: map[string]interface{}{
+			utils.Destination: "1003"}}
+	dst.AddEvent(ev)
+	if strVal := dst.GetStringValue(""); strVal != utils.NOT_AVAILABLE {
+		t.Errorf("wrong distinct value: %s", strVal)
+	}
+	dst.AddEvent(ev2)
+	dst.AddEvent(ev3)
+	if v := dst.GetFloat64Value(); v != 2 {
+		t.Errorf("wrong distinct value: %v", v)
+	}
+	dst.RemEvent(ev3.TenantID())
+	if strVal := dst.GetStringValue(""); strVal != "1" {
+		t.Errorf("wrong distinct value: %s", strVal)
+	}
+	dst.RemEvent(ev.TenantID())
+	if v := dst.GetFloat64Value(); v != -1.0 {
+		t.Errorf("wrong distinct value: %v", v)
+	}
+}
+
+func TestStatMetricsReprocessedEvent(t *testing.T) {
+	sd, _ := NewStatStdDev(0, "Cost")
+	hst, _ := NewStatHistogram(0, "Cost:5;10")
+	sMax, _ := NewStatMax(0, "Cost")
+	acd, _ := NewACD(0, "")
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			"Cost":           2.0,
+			utils.AnswerTime: time.Now(),
+			utils.Usage:      time.Duration(10 * time.Second)}}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{
+			"Cost":           6.0,
+			utils.AnswerTime: time.Now(),
+			utils.Usage:      time.Duration(20 * time.Second)}}
+	evUpdated := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{
+			"Cost":           8.0,
+			utils.AnswerTime: time.Now(),
+			utils.Usage:      time.Duration(40 * time.Second)}}
+	for _, sm := range []StatMetric{sd, hst, sMax, acd} {
+		sm.AddEvent(ev)
+		sm.AddEvent(ev2)
+		sm.AddEvent(ev)
+		sm.AddEvent(evUpdated)
+	}
+	if v := sd.GetFloat64Value(); v != 1.0 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	if strVal := hst.GetStringValue(""); strVal != "5:0;10:2;+Inf:0" {
+		t.Errorf("wrong histogram value: %s", strVal)
+	}
+	if v := sMax.GetFloat64Value(); v != 8.0 {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if v := acd.GetFloat64Value(); v != 30 {
+		t.Errorf("wrong acd value: %v", v)
+	}
+	for _, sm := range []StatMetric{sd, hst, sMax, acd} {
+		sm.RemEvent(evUpdated.TenantID())
+	}
+	if v := sd.GetFloat64Value(); v != 0 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	if v := hst.GetFloat64Value(); v != 1 {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	if v := sMax.GetFloat64Value(); v != 6.0 {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if v := acd.GetFloat64Value(); v != 20 {
+		t.Errorf("wrong acd value: %v", v)
+	}
+}
@@ -33,7 +33,7 @@ import (
 
 // NewStatService initializes a StatService
 func NewStatService(dm *DataManager, storeInterval time.Duration,
-	thdS rpcclient.RpcClientConnection, filterS *FilterS, stringIndexedFields, prefixIndexedFields *[]string) (ss *StatService, err error) {
+	thdS rpcclient.RpcClientConnection, filterS *FilterS, stringIndexedFields, prefixIndexedFields, suffixIndexedFields *[]string) (ss *StatService, err error) {
 	if thdS != nil && reflect.ValueOf(thdS).IsNil() { // fix nil value in interface
 		thdS = nil
 	}
@@ -44,6 +44,7 @@ func NewStatService(dm *DataManager, storeInterval time.Duration,
 		filterS:             filterS,
 		stringIndexedFields: stringIndexedFields,
 		prefixIndexedFields: prefixIndexedFields,
+		suffixIndexedFields: suffixIndexedFields,
 		storedStatQueues:    make(utils.StringMap),
 		stopBackup:          make(chan struct{})}, nil
 }
@@ -56,6 +57,7 @@ type StatService struct {
 	filterS             *FilterS
 	stringIndexedFields *[]string
 	prefixIndexedFields *[]string
+	suffixIndexedFields *[]string
 	stopBackup          chan struct{}
 	storedStatQueues    utils.StringMap // keep a record of stats which need saving, map[statsTenantID]bool
 	ssqMux              sync.RWMutex    // protects storedStatQueues
@@ -147,7 +149,7 @@ func (sS *StatService) StoreStatQueue(sq *StatQueue) (err error) {
 // matchingStatQueuesForEvent returns ordered list of matching resources which are active by the time of the call
 func (sS *StatService) matchingStatQueuesForEvent(ev *utils.CGREvent) (sqs StatQueues, err error) {
 	matchingSQs := make(map[string]*StatQueue)
-	sqIDs, err := matchingItemIDsForEvent(ev.Event, sS.stringIndexedFields, sS.prefixIndexedFields,
+	sqIDs, err := matchingItemIDsForEvent(ev.Event, sS.stringIndexedFields, sS.prefixIndexedFields, sS.suffixIndexedFields,
 		sS.dm, utils.CacheStatFilterIndexes, ev.Tenant)
 	if err != nil {
 		return nil, err
@@ -164,8 +166,9 @@ func (sS *StatService) matchingStatQueuesForEvent(ev *utils.CGREvent) (sqs StatQ
 			}
 			return nil, err
 		}
-		if sqPrfl.ActivationInterval != nil && ev.Time != nil &&
-			!sqPrfl.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
+		if sqPrfl.Disabled ||
+			(sqPrfl.ActivationInterval != nil && ev.Time != nil &&
+				!sqPrfl.ActivationInterval.IsActiveAtTime(*ev.Time)) { // not active
 			continue
 		}
 		if pass, err := sS.filterS.Pass(ev.Tenant, sqPrfl.FilterIDs,
@@ -151,6 +151,12 @@ type DataDB interface {
 	GetAttributeProfileDrv(string, string) (*AttributeProfile, error)
 	SetAttributeProfileDrv(*AttributeProfile) error
 	RemoveAttributeProfileDrv(string, string) error
+	GetDispatcherProfileDrv(string, string) (*DispatcherProfile, error)
+	SetDispatcherProfileDrv(*DispatcherProfile) error
+	RemoveDispatcherProfileDrv(string, string) error
+	GetSessionsDrv(string) ([]*StoredSession, error)
+	SetSessionsDrv(string, []*StoredSession) error
+	RemoveSessionsDrv(string) error
 }
 
 type StorDB interface {
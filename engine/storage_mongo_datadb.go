@@ -67,6 +67,8 @@ const (
 	colFlt   = "filters"
 	colSpp   = "supplier_profiles"
 	colAttr  = "attribute_profiles"
+	colDpp   = "dispatcher_profiles"
+	colSes   = "sessions"
 	ColCDRs  = "cdrs"
 )
 
@@ -159,7 +161,7 @@ func (ms *MongoStorage) EnsureIndexes() (err error) {
 			Background: false, // Build index in background and return immediately
 			Sparse:     false, // Only index documents containing the Key fields
 		}
-		for _, col := range []string{colAct, colApl, colAAp, colAtr, colDcs, colRpl, colLcr, colDst, colRds, colAls, colUsr, colLht} {
+		for _, col := range []string{colAct, colApl, colAAp, colAtr, colDcs, colRpl, colLcr, colDst, colRds, colAls, colUsr, colLht, colSes} {
 			if err = db.C(col).EnsureIndex(idx); err != nil {
 				return
 			}
@@ -171,7 +173,7 @@ func (ms *MongoStorage) EnsureIndexes() (err error) {
 			Background: false,
 			Sparse:     false,
 		}
-		for _, col := range []string{colRsP, colRes, colSqs, colSqp, colTps, colThs, colSpp, colAttr, colFlt} {
+		for _, col := range []string{colRsP, colRes, colSqs, colSqp, colTps, colThs, colSpp, colAttr, colFlt, colDpp} {
 			if err = db.C(col).EnsureIndex(idx); err != nil {
 				return
 			}
@@ -343,15 +345,17 @@ func (ms *MongoStorage) getColNameForPrefix(prefix string) (name string, ok bool
 		utils.LOADINST_KEY:               colLht,
 		utils.VERSION_PREFIX:             colVer,
 		//utils.CDR_STATS_QUEUE_PREFIX:            colStq,
-		utils.TimingsPrefix:          colTmg,
-		utils.ResourcesPrefix:        colRes,
-		utils.ResourceProfilesPrefix: colRsP,
-		utils.ThresholdProfilePrefix: colTps,
-		utils.StatQueueProfilePrefix: colSqp,
-		utils.ThresholdPrefix:        colThs,
-		utils.FilterPrefix:           colFlt,
-		utils.SupplierProfilePrefix:  colSpp,
-		utils.AttributeProfilePrefix: colAttr,
+		utils.TimingsPrefix:           colTmg,
+		utils.ResourcesPrefix:         colRes,
+		utils.ResourceProfilesPrefix:  colRsP,
+		utils.ThresholdProfilePrefix:  colTps,
+		utils.StatQueueProfilePrefix:  colSqp,
+		utils.ThresholdPrefix:         colThs,
+		utils.FilterPrefix:            colFlt,
+		utils.SupplierProfilePrefix:   colSpp,
+		utils.AttributeProfilePrefix:  colAttr,
+		utils.DispatcherProfilePrefix: colDpp,
+		utils.SessionsPrefix:          colSes,
 	}
 	name, ok = colMap[prefix]
 	return
@@ -661,6 +665,16 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 		for iter.Next(&idResult) {
 			result = append(result, utils.AttributeProfilePrefix+utils.ConcatenatedKey(idResult.Tenant, idResult.Id))
 		}
+	case utils.DispatcherProfilePrefix:
+		iter := db.C(colDpp).Find(bson.M{"id": bson.M{"$regex": bson.RegEx{Pattern: subject}}}).Select(bson.M{"tenant": 1, "id": 1}).Iter()
+		for iter.Next(&idResult) {
+			result = append(result, utils.DispatcherProfilePrefix+utils.ConcatenatedKey(idResult.Tenant, idResult.Id))
+		}
+	case utils.SessionsPrefix:
+		iter := db.C(colSes).Find(bson.M{"key": bson.M{"$regex": bson.RegEx{Pattern: subject}}}).Select(bson.M{"key": 1}).Iter()
+		for iter.Next(&keyResult) {
+			result = append(result, utils.SessionsPrefix+keyResult.Key)
+		}
 	default:
 		err = fmt.Errorf("unsupported prefix in GetKeysForPrefix: %s", prefix)
 	}
@@ -715,6 +729,9 @@ func (ms *MongoStorage) HasDataDrv(category, subject, tenant string) (has bool,
 	case utils.AttributeProfilePrefix:
 		count, err = db.C(colAttr).Find(bson.M{"tenant": tenant, "id": subject}).Count()
 		has = count > 0
+	case utils.DispatcherProfilePrefix:
+		count, err = db.C(colDpp).Find(bson.M{"tenant": tenant, "id": subject}).Count()
+		has = count > 0
 	default:
 		err = fmt.Errorf("unsupported category in HasData: %s", category)
 	}
@@ -2380,3 +2397,66 @@ func (ms *MongoStorage) RemoveAttributeProfileDrv(tenant, id string) (err error)
 	}
 	return nil
 }
+
+func (ms *MongoStorage) GetDispatcherProfileDrv(tenant, id string) (r *DispatcherProfile, err error) {
+	session, col := ms.conn(colDpp)
+	defer session.Close()
+	if err = col.Find(bson.M{"tenant": tenant, "id": id}).One(&r); err != nil {
+		if err == mgo.ErrNotFound {
+			err = utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return
+}
+
+func (ms *MongoStorage) SetDispatcherProfileDrv(r *DispatcherProfile) (err error) {
+	session, col := ms.conn(colDpp)
+	defer session.Close()
+	_, err = col.Upsert(bson.M{"tenant": r.Tenant, "id": r.ID}, r)
+	return
+}
+
+func (ms *MongoStorage) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
+	session, col := ms.conn(colDpp)
+	defer session.Close()
+	if err = col.Remove(bson.M{"tenant": tenant, "id": id}); err != nil {
+		return
+	}
+	return nil
+}
+
+func (ms *MongoStorage) GetSessionsDrv(cgrID string) (ss []*StoredSession, err error) {
+	var result struct {
+		Key   string
+		Value []*StoredSession
+	}
+	session, col := ms.conn(colSes)
+	defer session.Close()
+	if err = col.Find(bson.M{"key": cgrID}).One(&result); err != nil {
+		if err == mgo.ErrNotFound {
+			err = utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+func (ms *MongoStorage) SetSessionsDrv(cgrID string, ss []*StoredSession) (err error) {
+	session, col := ms.conn(colSes)
+	defer session.Close()
+	_, err = col.Upsert(bson.M{"key": cgrID}, &struct {
+		Key   string
+		Value []*StoredSession
+	}{cgrID, ss})
+	return
+}
+
+func (ms *MongoStorage) RemoveSessionsDrv(cgrID string) (err error) {
+	session, col := ms.conn(colSes)
+	defer session.Close()
+	if err = col.Remove(bson.M{"key": cgrID}); err != nil && err != mgo.ErrNotFound {
+		return
+	}
+	return nil
+}
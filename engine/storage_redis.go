@@ -274,7 +274,8 @@ func (rs *RedisStorage) HasDataDrv(category, subject, tenant string) (bool, erro
 		return i == 1, err
 	case utils.ResourcesPrefix, utils.ResourceProfilesPrefix, utils.StatQueuePrefix,
 		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
-		utils.FilterPrefix, utils.SupplierProfilePrefix, utils.AttributeProfilePrefix:
+		utils.FilterPrefix, utils.SupplierProfilePrefix, utils.AttributeProfilePrefix,
+		utils.DispatcherProfilePrefix:
 		i, err := rs.Cmd("EXISTS", category+utils.ConcatenatedKey(tenant, subject)).Int()
 		return i == 1, err
 	}
@@ -1824,6 +1825,63 @@ func (rs *RedisStorage) RemoveAttributeProfileDrv(tenant, id string) (err error)
 	return
 }
 
+func (rs *RedisStorage) GetDispatcherProfileDrv(tenant, id string) (r *DispatcherProfile, err error) {
+	key := utils.DispatcherProfilePrefix + utils.ConcatenatedKey(tenant, id)
+	var values []byte
+	if values, err = rs.Cmd("GET", key).Bytes(); err != nil {
+		if err == redis.ErrRespNil { // did not find the destination
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	if err = rs.ms.Unmarshal(values, &r); err != nil {
+		return
+	}
+	return
+}
+
+func (rs *RedisStorage) SetDispatcherProfileDrv(r *DispatcherProfile) (err error) {
+	result, err := rs.ms.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd("SET", utils.DispatcherProfilePrefix+utils.ConcatenatedKey(r.Tenant, r.ID), result).Err
+}
+
+func (rs *RedisStorage) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
+	key := utils.DispatcherProfilePrefix + utils.ConcatenatedKey(tenant, id)
+	if err = rs.Cmd("DEL", key).Err; err != nil {
+		return
+	}
+	return
+}
+
+func (rs *RedisStorage) GetSessionsDrv(cgrID string) (ss []*StoredSession, err error) {
+	var values []byte
+	if values, err = rs.Cmd("GET", utils.SessionsPrefix+cgrID).Bytes(); err != nil {
+		if err == redis.ErrRespNil { // did not find the sessions
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	if err = rs.ms.Unmarshal(values, &ss); err != nil {
+		return
+	}
+	return
+}
+
+func (rs *RedisStorage) SetSessionsDrv(cgrID string, ss []*StoredSession) (err error) {
+	result, err := rs.ms.Marshal(ss)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd("SET", utils.SessionsPrefix+cgrID, result).Err
+}
+
+func (rs *RedisStorage) RemoveSessionsDrv(cgrID string) (err error) {
+	return rs.Cmd("DEL", utils.SessionsPrefix+cgrID).Err
+}
+
 func (rs *RedisStorage) GetStorageType() string {
 	return utils.REDIS
 }
@@ -0,0 +1,39 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+)
+
+// StoredSession is the charging state of one run of an active session,
+// snapshotted in DataDB so SessionS can recover it after a restart.
+// The OriginHost in EventStart identifies the agent owning the session
+type StoredSession struct {
+	CGRID         string
+	RunID         string
+	EventStart    map[string]interface{} // event which started the session
+	CD            *CallDescriptor        // CallDescriptor used for debits, as updated by the last debit
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	LastDebit     time.Duration
+	TotalUsage    time.Duration
+	LastUpdate    time.Time // time of the last update or debit on the session
+}
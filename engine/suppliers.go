@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"time"
@@ -53,6 +54,7 @@ type SupplierProfile struct {
 	SortingParameters  []string
 	Suppliers          []*Supplier
 	Weight             float64
+	Disabled           bool // not matching events, ie: disabled by *disable_profile action
 }
 
 // TenantID returns unique identifier of the LCRProfile in a multi-tenant environment
@@ -70,8 +72,14 @@ func (lps SupplierProfiles) Sort() {
 
 // NewLCRService initializes a LCRService
 func NewSupplierService(dm *DataManager, timezone string,
-	filterS *FilterS, stringIndexedFields, prefixIndexedFields *[]string, resourceS,
+	filterS *FilterS, stringIndexedFields, prefixIndexedFields, suffixIndexedFields *[]string, resourceS,
 	statS rpcclient.RpcClientConnection) (spS *SupplierService, err error) {
+	if resourceS != nil && reflect.ValueOf(resourceS).IsNil() { // fix nil value in interface
+		resourceS = nil
+	}
+	if statS != nil && reflect.ValueOf(statS).IsNil() {
+		statS = nil
+	}
 	spS = &SupplierService{
 		dm:                  dm,
 		timezone:            timezone,
@@ -79,7 +87,8 @@ func NewSupplierService(dm *DataManager, timezone string,
 		resourceS:           resourceS,
 		statS:               statS,
 		stringIndexedFields: stringIndexedFields,
-		prefixIndexedFields: prefixIndexedFields}
+		prefixIndexedFields: prefixIndexedFields,
+		suffixIndexedFields: suffixIndexedFields}
 	if spS.sorter, err = NewSupplierSortDispatcher(spS); err != nil {
 		return nil, err
 	}
@@ -93,6 +102,7 @@ type SupplierService struct {
 	filterS             *FilterS
 	stringIndexedFields *[]string
 	prefixIndexedFields *[]string
+	suffixIndexedFields *[]string
 	resourceS,
 	statS rpcclient.RpcClientConnection
 	sorter SupplierSortDispatcher
@@ -116,7 +126,7 @@ func (spS *SupplierService) Shutdown() error {
 // matchingSupplierProfilesForEvent returns ordered list of matching resources which are active by the time of the call
 func (spS *SupplierService) matchingSupplierProfilesForEvent(ev *utils.CGREvent) (sPrfls SupplierProfiles, err error) {
 	matchingLPs := make(map[string]*SupplierProfile)
-	sPrflIDs, err := matchingItemIDsForEvent(ev.Event, spS.stringIndexedFields, spS.prefixIndexedFields,
+	sPrflIDs, err := matchingItemIDsForEvent(ev.Event, spS.stringIndexedFields, spS.prefixIndexedFields, spS.suffixIndexedFields,
 		spS.dm, utils.CacheSupplierFilterIndexes, ev.Tenant)
 	if err != nil {
 		return nil, err
@@ -132,8 +142,9 @@ func (spS *SupplierService) matchingSupplierProfilesForEvent(ev *utils.CGREvent)
 			}
 			return nil, err
 		}
-		if splPrfl.ActivationInterval != nil && ev.Time != nil &&
-			!splPrfl.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
+		if splPrfl.Disabled ||
+			(splPrfl.ActivationInterval != nil && ev.Time != nil &&
+				!splPrfl.ActivationInterval.IsActiveAtTime(*ev.Time)) { // not active
 			continue
 		}
 		if pass, err := spS.filterS.Pass(ev.Tenant, splPrfl.FilterIDs,
@@ -250,13 +261,83 @@ func (spS *SupplierService) costForEvent(ev *utils.CGREvent,
 }
 
 // statMetrics will query a list of statIDs and return composed metric values
-// first metric found is always returned
-func (spS *SupplierService) statMetrics(statIDs []string, metricIDs []string) (sms map[string]StatMetric, err error) {
+// first metric found is always returned, STATS_NA for the metrics not available
+func (spS *SupplierService) statMetrics(tenant string, statIDs []string,
+	metricIDs []string) (sms map[string]float64, err error) {
+	if spS.statS == nil {
+		return nil, utils.NewErrNotConnected(utils.StatService)
+	}
+	sms = make(map[string]float64, len(metricIDs))
+	for _, metricID := range metricIDs {
+		sms[metricID] = STATS_NA
+	}
+	for _, statID := range statIDs {
+		var metrics map[string]float64
+		if err = spS.statS.Call(utils.StatSv1GetQueueFloatMetrics,
+			&utils.TenantID{Tenant: tenant, ID: statID}, &metrics); err != nil {
+			if err.Error() != utils.ErrNotFound.Error() {
+				return nil, err
+			}
+			err = nil
+			continue
+		}
+		for _, metricID := range metricIDs {
+			if sms[metricID] != STATS_NA {
+				continue // first metric found wins
+			}
+			if val, has := metrics[metricID]; has {
+				sms[metricID] = val
+			}
+		}
+	}
+	return
+}
+
+// resourceSummaries queries ResourceS for the usage of each resource in list
+// resources not found are ignored
+func (spS *SupplierService) resourceSummaries(tenant string,
+	resIDs []string) (rSums []*ResourceSummary, err error) {
+	if spS.resourceS == nil {
+		return nil, utils.NewErrNotConnected(utils.ResourceS)
+	}
+	for _, resID := range resIDs {
+		var rSum ResourceSummary
+		if err = spS.resourceS.Call(utils.ResourceSv1GetResourceSummary,
+			&utils.TenantID{Tenant: tenant, ID: resID}, &rSum); err != nil {
+			if err.Error() != utils.ErrNotFound.Error() {
+				return nil, err
+			}
+			err = nil
+			continue
+		}
+		rSums = append(rSums, &rSum)
+	}
 	return
 }
 
 // resourceUsage returns sum of all resource usages out of list
-func (spS *SupplierService) resourceUsage(resIDs []string) (tUsage float64, err error) {
+func (spS *SupplierService) resourceUsage(tenant string, resIDs []string) (tUsage float64, err error) {
+	var rSums []*ResourceSummary
+	if rSums, err = spS.resourceSummaries(tenant, resIDs); err != nil {
+		return
+	}
+	for _, rSum := range rSums {
+		tUsage += rSum.TotalUsage
+	}
+	return
+}
+
+// resourceAvailability returns sum of the units still available out of list
+func (spS *SupplierService) resourceAvailability(tenant string, resIDs []string) (tAvail float64, err error) {
+	var rSums []*ResourceSummary
+	if rSums, err = spS.resourceSummaries(tenant, resIDs); err != nil {
+		return
+	}
+	for _, rSum := range rSums {
+		if rSum.Limit > rSum.TotalUsage {
+			tAvail += rSum.Limit - rSum.TotalUsage
+		}
+	}
 	return
 }
 
@@ -289,21 +370,23 @@ func (spS *SupplierService) sortedSuppliersForEvent(args *ArgsGetSuppliers) (sor
 	if err != nil {
 		return nil, err
 	}
+	extraOpts.sortingParameters = splPrfl.SortingParameters
 	sortedSuppliers, err := spS.sorter.SortSuppliers(splPrfl.ID, splPrfl.Sorting,
 		spls, &args.CGREvent, extraOpts)
 	if err != nil {
 		return nil, err
 	}
-	if args.Paginator.Offset != nil {
-		if *args.Paginator.Offset <= len(sortedSuppliers.SortedSuppliers) {
-			sortedSuppliers.SortedSuppliers = sortedSuppliers.SortedSuppliers[*args.Paginator.Offset:]
-		}
+	// limits are applied on the sorted list so the order is not influenced by them
+	if err = sortedSuppliers.FilterErrors(extraOpts.ignoreErrors); err != nil {
+		return nil, err
 	}
-	if args.Paginator.Limit != nil {
-		if *args.Paginator.Limit <= len(sortedSuppliers.SortedSuppliers) {
-			sortedSuppliers.SortedSuppliers = sortedSuppliers.SortedSuppliers[:*args.Paginator.Limit]
-		}
+	if extraOpts.maxCost != nil {
+		sortedSuppliers.FilterMaxCost(*extraOpts.maxCost)
+	}
+	if len(sortedSuppliers.SortedSuppliers) == 0 {
+		return nil, utils.ErrNotFound
 	}
+	sortedSuppliers.Paginate(args.Paginator)
 	return sortedSuppliers, nil
 }
 
@@ -328,20 +411,22 @@ func (args *ArgsGetSuppliers) asOptsGetSuppliers() (opts *optsGetSuppliers, err
 		if cc, err := cd.GetCost(); err != nil {
 			return nil, err
 		} else {
-			opts.maxCost = cc.Cost
+			opts.maxCost = utils.Float64Pointer(cc.Cost)
 		}
 	} else if args.MaxCost != "" {
-		if opts.maxCost, err = strconv.ParseFloat(args.MaxCost,
-			64); err != nil {
+		maxCost, err := strconv.ParseFloat(args.MaxCost, 64)
+		if err != nil {
 			return nil, err
 		}
+		opts.maxCost = utils.Float64Pointer(maxCost)
 	}
 	return
 }
 
 type optsGetSuppliers struct {
-	ignoreErrors bool
-	maxCost      float64
+	ignoreErrors      bool
+	maxCost           *float64 // nil for no limit
+	sortingParameters []string // out of SupplierProfile, used by some strategies
 }
 
 // V1GetSuppliersForEvent returns the list of valid supplier IDs
@@ -675,7 +675,7 @@ func TestSuppliersAsOptsGetSuppliers(t *testing.T) {
 	}
 	spl := &optsGetSuppliers{
 		ignoreErrors: true,
-		maxCost:      10.0,
+		maxCost:      utils.Float64Pointer(10.0),
 	}
 	sprf, err := s.asOptsGetSuppliers()
 	if err != nil {
@@ -707,7 +707,7 @@ func TestSuppliersAsOptsGetSuppliersMaxCost(t *testing.T) {
 		MaxCost: "10.0",
 	}
 	spl := &optsGetSuppliers{
-		maxCost: 10.0,
+		maxCost: utils.Float64Pointer(10.0),
 	}
 	sprf, err := s.asOptsGetSuppliers()
 	if err != nil {
@@ -717,3 +717,19 @@ func TestSuppliersAsOptsGetSuppliersMaxCost(t *testing.T) {
 		t.Errorf("Expecting: %+v,received: %+v", spl, sprf)
 	}
 }
+
+func TestSuppliersAsOptsGetSuppliersZeroMaxCost(t *testing.T) {
+	s := &ArgsGetSuppliers{
+		MaxCost: "0",
+	}
+	spl := &optsGetSuppliers{
+		maxCost: utils.Float64Pointer(0),
+	}
+	sprf, err := s.asOptsGetSuppliers()
+	if err != nil {
+		t.Errorf("Error: %+v", err)
+	}
+	if !reflect.DeepEqual(spl, sprf) {
+		t.Errorf("Expecting: %+v,received: %+v", utils.ToJSON(spl), utils.ToJSON(sprf))
+	}
+}
@@ -42,6 +42,8 @@ type ThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	ResetInterval      time.Duration // reset the hits after this interval from the first hit, 0 to disable
+	RecoveryFilterIDs  []string      // once executed, wait for an event passing these filters before arming again
 }
 
 func (tp *ThresholdProfile) TenantID() string {
@@ -50,10 +52,12 @@ func (tp *ThresholdProfile) TenantID() string {
 
 // Threshold is the unit matched by filters
 type Threshold struct {
-	Tenant string
-	ID     string
-	Hits   int       // number of hits for this threshold
-	Snooze time.Time // prevent threshold to run too early
+	Tenant   string
+	ID       string
+	Hits     int       // number of hits for this threshold
+	Snooze   time.Time // prevent threshold to run too early
+	FirstHit time.Time // time of the first hit, used by ResetInterval
+	Disarmed bool      // actions executed, waiting for an event passing RecoveryFilterIDs
 
 	tPrfl *ThresholdProfile
 	dirty *bool // needs save
@@ -63,9 +67,26 @@ func (t *Threshold) TenantID() string {
 	return utils.ConcatenatedKey(t.Tenant, t.ID)
 }
 
+// reset clears the hits and the state of the threshold
+func (t *Threshold) reset() {
+	t.Hits = 0
+	t.Snooze = time.Time{}
+	t.FirstHit = time.Time{}
+	t.Disarmed = false
+}
+
+// resetIntervalPassed returns true if ResetInterval elapsed since the first hit
+func (t *Threshold) resetIntervalPassed() bool {
+	return t.tPrfl.ResetInterval > 0 && !t.FirstHit.IsZero() &&
+		time.Since(t.FirstHit) >= t.tPrfl.ResetInterval
+}
+
 // ProcessEvent processes an ThresholdEvent
 // concurrentActions limits the number of simultaneous action sets executed
 func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err error) {
+	if t.Disarmed { // waiting for recovery, not executing actions
+		return
+	}
 	if t.Snooze.After(time.Now()) { // snoozed, not executing actions
 		return
 	}
@@ -80,10 +101,13 @@ func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err e
 	if acnt != "" {
 		acntID = utils.ConcatenatedKey(args.Tenant, acnt)
 	}
+	thHit := &ThresholdHit{Tenant: t.Tenant, ThresholdID: t.ID,
+		Hits: t.Hits, Event: &args.CGREvent}
 	for _, actionSetID := range t.tPrfl.ActionIDs {
 		at := &ActionTiming{
 			Uuid:      utils.GenUUID(),
 			ActionsID: actionSetID,
+			extraData: thHit,
 		}
 		if acntID != "" {
 			at.accountIDs = utils.NewStringMap(acntID)
@@ -103,9 +127,20 @@ func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err e
 			}
 		}
 	}
+	if len(t.tPrfl.RecoveryFilterIDs) != 0 {
+		t.Disarmed = true
+	}
 	return
 }
 
+// ThresholdHit is passed to the actions executed by a Threshold, ie: posted by *http_post
+type ThresholdHit struct {
+	Tenant      string
+	ThresholdID string
+	Hits        int
+	Event       *utils.CGREvent // event triggering the threshold
+}
+
 // Thresholds is a sortable slice of Threshold
 type Thresholds []*Threshold
 
@@ -114,11 +149,12 @@ func (ts Thresholds) Sort() {
 	sort.Slice(ts, func(i, j int) bool { return ts[i].tPrfl.Weight > ts[j].tPrfl.Weight })
 }
 
-func NewThresholdService(dm *DataManager, stringIndexedFields, prefixIndexedFields *[]string, storeInterval time.Duration,
+func NewThresholdService(dm *DataManager, stringIndexedFields, prefixIndexedFields, suffixIndexedFields *[]string, storeInterval time.Duration,
 	filterS *FilterS) (tS *ThresholdService, err error) {
 	return &ThresholdService{dm: dm,
 		stringIndexedFields: stringIndexedFields,
 		prefixIndexedFields: prefixIndexedFields,
+		suffixIndexedFields: suffixIndexedFields,
 		storeInterval:       storeInterval,
 		filterS:             filterS,
 		stopBackup:          make(chan struct{}),
@@ -130,6 +166,7 @@ type ThresholdService struct {
 	dm                  *DataManager
 	stringIndexedFields *[]string // fields considered when searching for matching thresholds
 	prefixIndexedFields *[]string
+	suffixIndexedFields *[]string
 	storeInterval       time.Duration
 	filterS             *FilterS
 	stopBackup          chan struct{}
@@ -200,6 +237,21 @@ func (tS *ThresholdService) storeThresholds() {
 	}
 }
 
+// storeThreshold saves the threshold right away or schedules it for the next backup, based on storeInterval
+func (tS *ThresholdService) storeThreshold(t *Threshold) {
+	if t.dirty == nil {
+		t.dirty = utils.BoolPointer(false)
+	}
+	*t.dirty = true // mark it to be saved
+	if tS.storeInterval == -1 {
+		tS.StoreThreshold(t)
+		return
+	}
+	tS.stMux.Lock()
+	tS.storedTdIDs[t.TenantID()] = true
+	tS.stMux.Unlock()
+}
+
 // StoreThreshold stores the threshold in DB and corrects dirty flag
 func (tS *ThresholdService) StoreThreshold(t *Threshold) (err error) {
 	if t.dirty == nil || !*t.dirty {
@@ -217,14 +269,15 @@ func (tS *ThresholdService) StoreThreshold(t *Threshold) (err error) {
 }
 
 // matchingThresholdsForEvent returns ordered list of matching thresholds which are active for an Event
-func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (ts Thresholds, err error) {
+// withRecovery will arm again the disarmed thresholds not matching the event but passing their RecoveryFilterIDs
+func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent, withRecovery bool) (ts Thresholds, err error) {
 	matchingTs := make(map[string]*Threshold)
 	var tIDs []string
 	if len(args.ThresholdIDs) != 0 {
 		tIDs = args.ThresholdIDs
 	} else {
 		tIDsMap, err := matchingItemIDsForEvent(args.Event, tS.stringIndexedFields,
-			tS.prefixIndexedFields, tS.dm, utils.CacheThresholdFilterIndexes, args.Tenant)
+			tS.prefixIndexedFields, tS.suffixIndexedFields, tS.dm, utils.CacheThresholdFilterIndexes, args.Tenant)
 		if err != nil {
 			return nil, err
 		}
@@ -249,6 +302,11 @@ func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (
 			NavigableMap(args.Event)); err != nil {
 			return nil, err
 		} else if !pass {
+			if withRecovery && len(tPrfl.RecoveryFilterIDs) != 0 {
+				if err := tS.recoverThreshold(tPrfl, args); err != nil {
+					return nil, err
+				}
+			}
 			continue
 		}
 		t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, false, "")
@@ -278,6 +336,29 @@ func (tS *ThresholdService) matchingThresholdsForEvent(args *ArgsProcessEvent) (
 	return
 }
 
+// recoverThreshold arms again a disarmed threshold if the event passes the RecoveryFilterIDs of its profile
+func (tS *ThresholdService) recoverThreshold(tPrfl *ThresholdProfile, args *ArgsProcessEvent) (err error) {
+	var pass bool
+	if pass, err = tS.filterS.Pass(args.Tenant, tPrfl.RecoveryFilterIDs,
+		NavigableMap(args.Event)); err != nil || !pass {
+		return
+	}
+	t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, false, "")
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if !t.Disarmed {
+		return
+	}
+	t.Disarmed = false
+	t.tPrfl = tPrfl
+	tS.storeThreshold(t)
+	return
+}
+
 type ArgsProcessEvent struct {
 	ThresholdIDs []string
 	utils.CGREvent
@@ -285,7 +366,7 @@ type ArgsProcessEvent struct {
 
 // processEvent processes a new event, dispatching to matching thresholds
 func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs []string, err error) {
-	matchTs, err := tS.matchingThresholdsForEvent(args)
+	matchTs, err := tS.matchingThresholdsForEvent(args, true)
 	if err != nil {
 		return nil, err
 	}
@@ -293,6 +374,16 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 	var tIDs []string
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
+		if t.resetIntervalPassed() { // reset timer lost, ie: on restart
+			t.reset()
+		}
+		if t.Disarmed { // waiting for recovery, hits are not counted
+			continue
+		}
+		if t.Hits == 0 {
+			t.FirstHit = time.Now()
+			tS.scheduleReset(t)
+		}
 		t.Hits += 1
 		err = t.ProcessEvent(args, tS.dm)
 		if err != nil {
@@ -302,7 +393,8 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 			withErrors = true
 			continue
 		}
-		if t.dirty == nil || t.Hits == t.tPrfl.MaxHits { // one time threshold
+		if t.tPrfl.ResetInterval == 0 &&
+			(t.dirty == nil || t.Hits == t.tPrfl.MaxHits) { // one time threshold
 			if err = tS.dm.RemoveThreshold(t.Tenant, t.ID, utils.NonTransactional); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<ThresholdService> failed removing non-recurrent threshold: %s, error: %s",
@@ -313,15 +405,7 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 			continue
 		}
 		t.Snooze = time.Now().Add(t.tPrfl.MinSleep)
-		// recurrent threshold
-		if tS.storeInterval == -1 {
-			tS.StoreThreshold(t)
-		} else {
-			*t.dirty = true // mark it to be saved
-			tS.stMux.Lock()
-			tS.storedTdIDs[t.TenantID()] = true
-			tS.stMux.Unlock()
-		}
+		tS.storeThreshold(t) // recurrent threshold
 	}
 	if len(tIDs) != 0 {
 		thresholdsIDs = append(thresholdsIDs, tIDs...)
@@ -334,6 +418,39 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 	return
 }
 
+// scheduleReset resets the threshold once ResetInterval passes since its first hit,
+// without waiting for another matching event
+func (tS *ThresholdService) scheduleReset(t *Threshold) {
+	if t.tPrfl.ResetInterval <= 0 {
+		return
+	}
+	tnt, tID, firstHit := t.Tenant, t.ID, t.FirstHit
+	time.AfterFunc(t.tPrfl.ResetInterval, func() {
+		tS.resetExpiredThreshold(tnt, tID, firstHit)
+	})
+}
+
+// resetExpiredThreshold resets the threshold unless it was reset meanwhile
+func (tS *ThresholdService) resetExpiredThreshold(tnt, tID string, firstHit time.Time) {
+	lockID := utils.ThresholdFilterIndexes + tID
+	guardian.Guardian.GuardIDs(config.CgrConfig().LockingTimeout, lockID)
+	defer guardian.Guardian.UnguardIDs(lockID)
+	t, err := tS.dm.GetThreshold(tnt, tID, false, "")
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ThresholdS> failed resetting Threshold with tenant: %s and ID: %s, error: %s",
+					tnt, tID, err.Error()))
+		}
+		return
+	}
+	if !t.FirstHit.Equal(firstHit) { // reset or restarted since scheduled
+		return
+	}
+	t.reset()
+	tS.storeThreshold(t)
+}
+
 // V1ProcessEvent implements ThresholdService method for processing an Event
 func (tS *ThresholdService) V1ProcessEvent(args *ArgsProcessEvent, reply *[]string) (err error) {
 	if missing := utils.MissingStructFields(args, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
@@ -357,7 +474,7 @@ func (tS *ThresholdService) V1GetThresholdsForEvent(args *ArgsProcessEvent, repl
 		return utils.NewErrMandatoryIeMissing("Event")
 	}
 	var ts Thresholds
-	if ts, err = tS.matchingThresholdsForEvent(args); err == nil {
+	if ts, err = tS.matchingThresholdsForEvent(args, false); err == nil {
 		*reply = ts
 	}
 	return
@@ -387,3 +504,34 @@ func (tS *ThresholdService) V1GetThreshold(tntID *utils.TenantID, t *Threshold)
 	}
 	return
 }
+
+// V1ResetThreshold clears the hits and the state of a Threshold,
+// creating it again if it was removed after reaching MaxHits
+func (tS *ThresholdService) V1ResetThreshold(tntID *utils.TenantID, reply *string) (err error) {
+	if missing := utils.MissingStructFields(tntID, []string{"Tenant", "ID"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	lockID := utils.ThresholdFilterIndexes + tntID.ID
+	guardian.Guardian.GuardIDs(config.CgrConfig().LockingTimeout, lockID)
+	defer guardian.Guardian.UnguardIDs(lockID)
+	t, err := tS.dm.GetThreshold(tntID.Tenant, tntID.ID, false, "")
+	if err != nil {
+		if err != utils.ErrNotFound {
+			return err
+		}
+		if _, err = tS.dm.GetThresholdProfile(tntID.Tenant, tntID.ID,
+			false, utils.NonTransactional); err != nil {
+			return err
+		}
+		t = &Threshold{Tenant: tntID.Tenant, ID: tntID.ID}
+	}
+	t.reset()
+	if err = tS.dm.SetThreshold(t); err != nil {
+		return
+	}
+	if t.dirty != nil {
+		*t.dirty = false
+	}
+	*reply = utils.OK
+	return
+}
@@ -219,3 +219,177 @@ func TestThresholdsPopulateThresholdService(t *testing.T) {
 // func TestThresholdsprocessEvent(t *testing.T) {
 
 // }
+
+func TestThresholdsResetAndRecovery(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmRst := NewDataManager(data)
+	tS := &ThresholdService{
+		dm:            dmRst,
+		filterS:       &FilterS{dm: dmRst},
+		storeInterval: -1,
+		storedTdIDs:   make(utils.StringMap),
+	}
+	tPrfls := []*ThresholdProfile{
+		&ThresholdProfile{
+			Tenant:            "cgrates.org",
+			ID:                "TH_HYSTERESIS",
+			FilterIDs:         []string{"*gte:ACD:10"},
+			RecoveryFilterIDs: []string{"*lt:ACD:5"},
+			MaxHits:           -1,
+			Weight:            20,
+		},
+		&ThresholdProfile{
+			Tenant:        "cgrates.org",
+			ID:            "TH_RESET",
+			FilterIDs:     []string{"*gte:ACD:10"},
+			MaxHits:       1,
+			ResetInterval: time.Minute,
+			Weight:        10,
+		},
+	}
+	for _, tPrfl := range tPrfls {
+		if err := dmRst.SetThresholdProfile(tPrfl, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := dmRst.SetThreshold(&Threshold{Tenant: tPrfl.Tenant, ID: tPrfl.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := &ArgsProcessEvent{
+		ThresholdIDs: []string{"TH_HYSTERESIS", "TH_RESET"},
+		CGREvent: utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "ACD_ALERT",
+			Event:  map[string]interface{}{"ACD": 12.0},
+		},
+	}
+	if ids, err := tS.processEvent(args); err != nil {
+		t.Error(err)
+	} else if len(ids) != 2 {
+		t.Errorf("received: %+v", ids)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 1 || !th.Disarmed || th.FirstHit.IsZero() {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	// disarmed, hits are not counted
+	if _, err := tS.processEvent(&ArgsProcessEvent{
+		ThresholdIDs: []string{"TH_HYSTERESIS"},
+		CGREvent:     args.CGREvent}); err != nil {
+		t.Error(err)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 1 || !th.Disarmed {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	// above the recovery value, threshold stays disarmed
+	args.Event["ACD"] = 7.0
+	if ids, err := tS.processEvent(args); err != nil {
+		t.Error(err)
+	} else if len(ids) != 0 {
+		t.Errorf("received: %+v", ids)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", true, ""); err != nil {
+		t.Fatal(err)
+	} else if !th.Disarmed {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	args.Event["ACD"] = 3.0
+	if _, err := tS.processEvent(args); err != nil {
+		t.Error(err)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Disarmed {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	var reply string
+	if err := tS.V1ResetThreshold(&utils.TenantID{Tenant: "cgrates.org", ID: "TH_HYSTERESIS"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("received: %s", reply)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 0 || !th.FirstHit.IsZero() {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	// MaxHits reached, kept until ResetInterval passes
+	th, err := dmRst.GetThreshold("cgrates.org", "TH_RESET", false, "")
+	if err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 1 {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	th.FirstHit = time.Now().Add(-time.Hour)
+	args.Event["ACD"] = 15.0
+	if _, err := tS.processEvent(args); err != nil {
+		t.Error(err)
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_RESET", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 1 || time.Since(th.FirstHit) > time.Minute {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	// removed thresholds are created again by reset
+	if err := dmRst.RemoveThreshold("cgrates.org", "TH_HYSTERESIS", utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := tS.V1ResetThreshold(&utils.TenantID{Tenant: "cgrates.org", ID: "TH_HYSTERESIS"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if _, err := dmRst.GetThreshold("cgrates.org", "TH_HYSTERESIS", false, ""); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestThresholdsScheduledReset(t *testing.T) {
+	data, _ := NewMapStorage()
+	dmRst := NewDataManager(data)
+	tS := &ThresholdService{
+		dm:            dmRst,
+		filterS:       &FilterS{dm: dmRst},
+		storeInterval: -1,
+		storedTdIDs:   make(utils.StringMap),
+	}
+	tPrfl := &ThresholdProfile{
+		Tenant:        "cgrates.org",
+		ID:            "TH_TIMER",
+		MaxHits:       -1,
+		MinHits:       5,
+		ResetInterval: 10 * time.Millisecond,
+	}
+	if err := dmRst.SetThresholdProfile(tPrfl, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmRst.SetThreshold(&Threshold{Tenant: tPrfl.Tenant, ID: tPrfl.ID}); err != nil {
+		t.Fatal(err)
+	}
+	args := &ArgsProcessEvent{
+		ThresholdIDs: []string{"TH_TIMER"},
+		CGREvent: utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV1",
+			Event:  map[string]interface{}{utils.Account: "1001"},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := tS.processEvent(args); err != nil {
+			t.Error(err)
+		}
+	}
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_TIMER", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 2 {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+	// reset without waiting for a new event
+	time.Sleep(50 * time.Millisecond)
+	if th, err := dmRst.GetThreshold("cgrates.org", "TH_TIMER", true, ""); err != nil {
+		t.Fatal(err)
+	} else if th.Hits != 0 || !th.FirstHit.IsZero() {
+		t.Errorf("received: %s", utils.ToJSON(th))
+	}
+}
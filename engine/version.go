@@ -34,6 +34,7 @@ var (
 		utils.ActionPlans:    "cgr-migrator -migrate=*action_plans",
 		utils.SharedGroups:   "cgr-migrator -migrate=*shared_groups",
 		utils.Thresholds:     "cgr-migrator -migrate=*thresholds",
+		utils.StatS:          "cgr-migrator -migrate=*stats",
 	}
 	storDBVers = map[string]string{
 		utils.CostDetails:   "cgr-migrator -migrate=*cost_details",
@@ -119,7 +120,7 @@ func (vers Versions) Compare(curent Versions, storType string) string {
 
 func CurrentDataDBVersions() Versions {
 	return Versions{
-		utils.StatS:               2,
+		utils.StatS:               3,
 		utils.Accounts:            3,
 		utils.Actions:             2,
 		utils.ActionTriggers:      2,
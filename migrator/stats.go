@@ -155,6 +155,190 @@ func (m *Migrator) migrateV1CDRSTATS() (err error) {
 	return
 }
 
+// v2StatASR is the ASR metric as stored by v2, with the values kept per event
+type v2StatASR struct {
+	Answered float64
+	Count    float64
+	Events   map[string]bool // map[EventTenantID]Answered
+	MinItems int
+}
+
+// v2StatDuration is the ACD, TCD or PDD metric as stored by v2
+type v2StatDuration struct {
+	Sum      time.Duration
+	Count    int64
+	Events   map[string]time.Duration // map[EventTenantID]Duration
+	MinItems int
+}
+
+// v2StatFloat64 is the ACC, TCC, *sum or *average metric as stored by v2
+type v2StatFloat64 struct {
+	Sum       float64
+	Count     float64
+	Events    map[string]float64 // map[EventTenantID]Value
+	MinItems  int
+	FieldName string
+}
+
+// v2StatDDC is the DDC metric as stored by v2
+type v2StatDDC struct {
+	Destinations map[string]utils.StringMap
+	Events       map[string]string // map[EventTenantID]Destination
+	MinItems     int
+}
+
+// v2StatMetricAsStatMetric converts the v2 values kept per event into the per item aggregates,
+// each event becoming one item so the queue keeps expiring them individually
+func v2StatMetricAsStatMetric(metricID string, minItems int,
+	marshaled []byte, ms engine.Marshaler) (sm engine.StatMetric, err error) {
+	if sm, err = engine.NewStatMetric(metricID, minItems, ""); err != nil {
+		return
+	}
+	switch metric := sm.(type) {
+	case *engine.StatASR:
+		v2Metric := new(v2StatASR)
+		if err = ms.Unmarshal(marshaled, v2Metric); err != nil {
+			return
+		}
+		for evID, answered := range v2Metric.Events {
+			itm := &engine.StatWithCount{Count: 1}
+			if answered {
+				itm.Stat = 1
+				metric.Answered += 1
+			}
+			metric.Events[evID] = itm
+			metric.Count += 1
+		}
+	case *engine.StatACD, *engine.StatTCD, *engine.StatPDD:
+		v2Metric := new(v2StatDuration)
+		if err = ms.Unmarshal(marshaled, v2Metric); err != nil {
+			return
+		}
+		var sum time.Duration
+		events := make(map[string]*engine.DurationWithCount, len(v2Metric.Events))
+		for evID, dur := range v2Metric.Events {
+			events[evID] = &engine.DurationWithCount{Duration: dur, Count: 1}
+			sum += dur
+		}
+		switch metric := sm.(type) {
+		case *engine.StatACD:
+			metric.Sum, metric.Count, metric.Events = sum, int64(len(events)), events
+		case *engine.StatTCD:
+			metric.Sum, metric.Count, metric.Events = sum, int64(len(events)), events
+		case *engine.StatPDD:
+			metric.Sum, metric.Count, metric.Events = sum, int64(len(events)), events
+		}
+	case *engine.StatACC, *engine.StatTCC, *engine.StatSum, *engine.StatAverage:
+		v2Metric := new(v2StatFloat64)
+		if err = ms.Unmarshal(marshaled, v2Metric); err != nil {
+			return
+		}
+		var sum float64
+		events := make(map[string]*engine.StatWithCount, len(v2Metric.Events))
+		for evID, val := range v2Metric.Events {
+			events[evID] = &engine.StatWithCount{Stat: val, Count: 1}
+			sum += val
+		}
+		switch metric := sm.(type) {
+		case *engine.StatACC:
+			metric.Sum, metric.Count, metric.Events = sum, float64(len(events)), events
+		case *engine.StatTCC:
+			metric.Sum, metric.Count, metric.Events = sum, float64(len(events)), events
+		case *engine.StatSum:
+			metric.Sum, metric.Count, metric.Events = sum, int64(len(events)), events
+			metric.FieldName = v2Metric.FieldName
+		case *engine.StatAverage:
+			metric.Sum, metric.Count, metric.Events = sum, float64(len(events)), events
+			metric.FieldName = v2Metric.FieldName
+		}
+	case *engine.StatDDC:
+		v2Metric := new(v2StatDDC)
+		if err = ms.Unmarshal(marshaled, v2Metric); err != nil {
+			return
+		}
+		for evID, dest := range v2Metric.Events {
+			if _, has := metric.Destinations[dest]; !has {
+				metric.Destinations[dest] = make(utils.StringMap)
+			}
+			metric.Destinations[dest][evID] = true
+			metric.Events[evID] = map[string]int64{dest: 1}
+			metric.Count += 1
+		}
+	default: // metrics introduced after v2 are already stored per item
+		err = sm.LoadMarshaled(ms, marshaled)
+	}
+	return
+}
+
+// migrateV2Stats converts the metrics of the stored StatQueues to the per item format
+func (m *Migrator) migrateV2Stats() (err error) {
+	ms := m.dmIN.DataManager().DataDB().Marshaler()
+	ids, err := m.dmIN.DataManager().DataDB().GetKeysForPrefix(utils.StatQueuePrefix)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		tntID := utils.SplitConcatenatedKey(strings.TrimPrefix(id, utils.StatQueuePrefix))
+		if len(tntID) < 2 {
+			return fmt.Errorf("invalid key <%s> when migrating stat queues", id)
+		}
+		tenant, sqID := tntID[0], utils.ConcatenatedKey(tntID[1:]...)
+		ssq, err := m.dmIN.DataManager().DataDB().GetStoredStatQueueDrv(tenant, sqID)
+		if err != nil {
+			return err
+		}
+		sq := &engine.StatQueue{Tenant: ssq.Tenant, ID: ssq.ID,
+			SQItems: ssq.SQItems, MinItems: ssq.MinItems,
+			SQMetrics: make(map[string]engine.StatMetric, len(ssq.SQMetrics))}
+		for metricID, marshaled := range ssq.SQMetrics {
+			if sq.SQMetrics[metricID], err = v2StatMetricAsStatMetric(metricID,
+				ssq.MinItems, marshaled, ms); err != nil {
+				return err
+			}
+		}
+		if m.dryRun {
+			continue
+		}
+		if err := m.dmOut.DataManager().SetStatQueue(sq); err != nil {
+			return err
+		}
+		m.stats[utils.StatS] += 1
+	}
+	if !m.sameDataDB {
+		if ids, err = m.dmIN.DataManager().DataDB().GetKeysForPrefix(utils.StatQueueProfilePrefix); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			tntID := utils.SplitConcatenatedKey(strings.TrimPrefix(id, utils.StatQueueProfilePrefix))
+			if len(tntID) < 2 {
+				return fmt.Errorf("invalid key <%s> when migrating stat queue profiles", id)
+			}
+			sqp, err := m.dmIN.DataManager().GetStatQueueProfile(tntID[0],
+				utils.ConcatenatedKey(tntID[1:]...), true, utils.NonTransactional)
+			if err != nil {
+				return err
+			}
+			if m.dryRun {
+				continue
+			}
+			if err := m.dmOut.DataManager().SetStatQueueProfile(sqp, true); err != nil {
+				return err
+			}
+		}
+	}
+	if m.dryRun != true {
+		// All done, update version wtih current one
+		vrs := engine.Versions{utils.StatS: engine.CurrentDataDBVersions()[utils.StatS]}
+		if err = m.dmOut.DataManager().DataDB().SetVersions(vrs, false); err != nil {
+			return utils.NewCGRError(utils.Migrator,
+				utils.ServerErrorCaps,
+				err.Error(),
+				fmt.Sprintf("error: <%s> when updating Stats version into dataDB", err.Error()))
+		}
+	}
+	return
+}
+
 func (m *Migrator) migrateStats() (err error) {
 	var vrs engine.Versions
 	current := engine.CurrentDataDBVersions()
@@ -175,6 +359,10 @@ func (m *Migrator) migrateStats() (err error) {
 		if err := m.migrateV1CDRSTATS(); err != nil {
 			return err
 		}
+	case 2:
+		if err := m.migrateV2Stats(); err != nil {
+			return err
+		}
 	case current[utils.StatS]:
 		if m.sameDataDB {
 			return
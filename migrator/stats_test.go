@@ -141,3 +141,73 @@ func TestV1StatsAsStats(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", filter, fltr)
 	}
 }
+
+func TestV2StatMetricAsStatMetric(t *testing.T) {
+	ms := engine.NewCodecMsgpackMarshaler()
+	v2ASR := &v2StatASR{Answered: 1, Count: 2, MinItems: 2,
+		Events: map[string]bool{"cgrates.org:EV1": true, "cgrates.org:EV2": false}}
+	marshaled, err := ms.Marshal(v2ASR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asr, err := v2StatMetricAsStatMetric(utils.MetaASR, 2, marshaled, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := asr.GetFloat64Value(); v != 50.0 {
+		t.Errorf("wrong asr value: %v", v)
+	}
+	if err := asr.RemEvent("cgrates.org:EV2"); err != nil {
+		t.Error(err)
+	}
+	asr.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "EV3",
+		Event: map[string]interface{}{utils.AnswerTime: "2018-01-07T17:00:10Z"}})
+	if v := asr.GetFloat64Value(); v != 100.0 {
+		t.Errorf("wrong asr value: %v", v)
+	}
+	v2Sum := &v2StatFloat64{Sum: 15, FieldName: "Cost",
+		Events: map[string]float64{"cgrates.org:EV1": 10, "cgrates.org:EV2": 5}}
+	if marshaled, err = ms.Marshal(v2Sum); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := v2StatMetricAsStatMetric(utils.MetaSum, 0, marshaled, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := sum.GetFloat64Value(); v != 15.0 {
+		t.Errorf("wrong sum value: %v", v)
+	}
+	sum.RemEvent("cgrates.org:EV1")
+	if v := sum.GetFloat64Value(); v != 5.0 {
+		t.Errorf("wrong sum value: %v", v)
+	}
+	v2ACD := &v2StatDuration{Sum: time.Minute, Count: 2,
+		Events: map[string]time.Duration{"cgrates.org:EV1": 20 * time.Second,
+			"cgrates.org:EV2": 40 * time.Second}}
+	if marshaled, err = ms.Marshal(v2ACD); err != nil {
+		t.Fatal(err)
+	}
+	acd, err := v2StatMetricAsStatMetric(utils.MetaACD, 0, marshaled, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := acd.GetValue(); v != 30*time.Second {
+		t.Errorf("wrong acd value: %v", v)
+	}
+	v2DDC := &v2StatDDC{Events: map[string]string{"cgrates.org:EV1": "1002",
+		"cgrates.org:EV2": "1002", "cgrates.org:EV3": "1003"}}
+	if marshaled, err = ms.Marshal(v2DDC); err != nil {
+		t.Fatal(err)
+	}
+	ddc, err := v2StatMetricAsStatMetric(utils.MetaDDC, 0, marshaled, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ddc.RemEvent("cgrates.org:EV1")
+	if v := ddc.GetFloat64Value(); v != 2 {
+		t.Errorf("wrong ddc value: %v", v)
+	}
+	if _, err := v2StatMetricAsStatMetric("*unsupported", 0, marshaled, ms); err == nil {
+		t.Error("expecting error on unsupported metric")
+	}
+}
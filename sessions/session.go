@@ -38,6 +38,7 @@ type SMGSession struct {
 	rals        rpcclient.RpcClientConnection // Connector to rals service
 	cdrsrv      rpcclient.RpcClientConnection // Connector to CDRS service
 	clientProto float64
+	recovered   bool // recovered out of DataDB after restart, clntConn to be restored out of the agent updates or sync
 
 	CGRID      string // Unique identifier for this session
 	RunID      string // Keep a reference for the derived run
@@ -50,7 +51,7 @@ type SMGSession struct {
 	LastUsage     time.Duration // last requested Duration
 	LastDebit     time.Duration // last real debited duration
 	TotalUsage    time.Duration // sum of lastUsage
-
+	LastUpdate    time.Time     // time of the last update or debit, used to expire the recovered sessions
 }
 
 // Called in case of automatic debits
@@ -89,6 +90,7 @@ func (self *SMGSession) debitLoop(debitInterval time.Duration) {
 func (self *SMGSession) debit(dur time.Duration, lastUsed *time.Duration) (time.Duration, error) {
 	self.mux.Lock()
 	defer self.mux.Unlock()
+	self.LastUpdate = time.Now()
 	requestedDuration := dur
 	if lastUsed != nil {
 		self.ExtraDuration = self.LastDebit - *lastUsed
@@ -154,7 +156,8 @@ func (self *SMGSession) debit(dur time.Duration, lastUsed *time.Duration) (time.
 // Send disconnect order to remote connection
 func (self *SMGSession) disconnectSession(reason string) error {
 	self.EventStart[utils.Usage] = self.TotalUsage.Nanoseconds() // Set the usage to total one debitted
-	if self.clntConn == nil || reflect.ValueOf(self.clntConn).IsNil() {
+	clntConn := self.getClntConn()
+	if clntConn == nil {
 		return errors.New("Calling SMGClientV1.DisconnectSession requires bidirectional JSON connection")
 	}
 	var reply string
@@ -162,7 +165,7 @@ func (self *SMGSession) disconnectSession(reason string) error {
 	if self.clientProto == 0 { // competibility with OpenSIPS
 		servMethod = "SMGClientV1.DisconnectSession"
 	}
-	if err := self.clntConn.Call(servMethod,
+	if err := clntConn.Call(servMethod,
 		utils.AttrDisconnectSession{EventStart: self.EventStart, Reason: reason},
 		&reply); err != nil {
 		return err
@@ -267,6 +270,55 @@ func (self *SMGSession) storeSMCost() error {
 	return nil
 }
 
+// getClntConn returns the connection towards the agent owning the session, nil if not known
+func (self *SMGSession) getClntConn() rpcclient.RpcClientConnection {
+	self.mux.RLock()
+	defer self.mux.RUnlock()
+	if self.clntConn == nil || reflect.ValueOf(self.clntConn).IsNil() {
+		return nil
+	}
+	return self.clntConn
+}
+
+// setClntConn sets the connection towards the agent owning the session if not already known
+func (self *SMGSession) setClntConn(clntConn rpcclient.RpcClientConnection) {
+	if clntConn == nil || reflect.ValueOf(clntConn).IsNil() {
+		return
+	}
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	if self.clntConn == nil || reflect.ValueOf(self.clntConn).IsNil() {
+		self.clntConn = clntConn
+	}
+}
+
+// asStoredSession returns a snapshot of the session which can be saved in DataDB
+func (self *SMGSession) asStoredSession() *engine.StoredSession {
+	self.mux.RLock()
+	defer self.mux.RUnlock()
+	evStart := make(map[string]interface{}, len(self.EventStart))
+	for fldName, fldVal := range self.EventStart {
+		evStart[fldName] = fldVal
+	}
+	stS := &engine.StoredSession{
+		CGRID:         self.CGRID,
+		RunID:         self.RunID,
+		EventStart:    evStart,
+		ExtraDuration: self.ExtraDuration,
+		LastUsage:     self.LastUsage,
+		LastDebit:     self.LastDebit,
+		TotalUsage:    self.TotalUsage,
+		LastUpdate:    self.LastUpdate,
+	}
+	if self.CD != nil {
+		stS.CD = self.CD.Clone()
+	}
+	if self.EventCost != nil {
+		stS.EventCost = self.EventCost.Clone()
+	}
+	return stS
+}
+
 func (self *SMGSession) AsActiveSession(timezone string) *ActiveSession {
 	self.mux.RLock()
 	defer self.mux.RUnlock()
@@ -63,7 +63,7 @@ type SMGReplicationConn struct {
 	Synchronous bool
 }
 
-func NewSMGeneric(cgrCfg *config.CGRConfig, rals, resS, thdS,
+func NewSMGeneric(cgrCfg *config.CGRConfig, dm *engine.DataManager, rals, resS, thdS,
 	statS, splS, attrS, cdrsrv rpcclient.RpcClientConnection,
 	smgReplConns []*SMGReplicationConn, timezone string) *SMGeneric {
 	ssIdxCfg := cgrCfg.SessionSCfg().SessionIndexes
@@ -90,6 +90,7 @@ func NewSMGeneric(cgrCfg *config.CGRConfig, rals, resS, thdS,
 		cdrsrv = nil
 	}
 	return &SMGeneric{cgrCfg: cgrCfg,
+		dm:                 dm,
 		rals:               rals,
 		resS:               resS,
 		thdS:               thdS,
@@ -107,11 +108,18 @@ func NewSMGeneric(cgrCfg *config.CGRConfig, rals, resS, thdS,
 		pSessionsIndex:     make(map[string]map[string]map[string]utils.StringMap),
 		pSessionsRIndex:    make(map[string][]*riFieldNameVal),
 		sessionTerminators: make(map[string]*smgSessionTerminator),
-		responseCache:      utils.NewResponseCache(cgrCfg.ResponseCacheTTL)}
+		responseCache:      utils.NewResponseCache(cgrCfg.ResponseCacheTTL),
+		storedSessions:     make(utils.StringMap),
+		recoveredSessions:  make(utils.StringMap),
+		clntConns:          make(map[string]rpcclient.RpcClientConnection),
+		noSyncClnts:        make(map[rpcclient.RpcClientConnection]bool),
+		stopBackup:         make(chan struct{}),
+		stopSync:           make(chan struct{})}
 }
 
 type SMGeneric struct {
 	cgrCfg             *config.CGRConfig             // Separate from smCfg since there can be multiple
+	dm                 *engine.DataManager           // snapshot active sessions here so we can recover them after restart
 	rals               rpcclient.RpcClientConnection // RALs connections
 	resS               rpcclient.RpcClientConnection // ResourceS connections
 	thdS               rpcclient.RpcClientConnection // ThresholdS connections
@@ -135,6 +143,15 @@ type SMGeneric struct {
 	sessionTerminators map[string]*smgSessionTerminator                 // terminate and cleanup the session if timer expires
 	sTsMux             sync.RWMutex                                     // protects sessionTerminators
 	responseCache      *utils.ResponseCache                             // cache replies here
+	storedSessions     utils.StringMap                                  // CGRIDs of the sessions snapshotted in DataDB
+	stSMux             sync.Mutex                                       // protects storedSessions and serializes DataDB snapshots
+	recoveredSessions  utils.StringMap                                  // CGRIDs of the recovered sessions not yet confirmed by their agents
+	rSMux              sync.RWMutex                                     // protects recoveredSessions
+	clntConns          map[string]rpcclient.RpcClientConnection         // agent connections per OriginHost, used to sync the recovered sessions
+	noSyncClnts        map[rpcclient.RpcClientConnection]bool           // agent connections not supporting the sync, logged once
+	clntCMux           sync.RWMutex                                     // protects clntConns and noSyncClnts
+	stopBackup         chan struct{}                                    // stops the snapshotting loop
+	stopSync           chan struct{}                                    // stops the loop syncing sessions with the agents
 }
 
 // riFieldNameVal is a reverse index entry
@@ -217,11 +234,23 @@ func (smg *SMGeneric) ttlTerminate(s *SMGSession, tmtr *smgSessionTerminator) {
 		s.debit(debitUsage, tmtr.ttlLastUsed)
 	}
 	smg.sessionEnd(s.CGRID, s.TotalUsage)
+	smg.processSessionCDR(s, s.TotalUsage)
+	smg.replicateSessionsWithID(s.CGRID, false, smg.smgReplConns)
+}
+
+// processSessionCDR sends to CDRs the CDR of a session terminated on our side
+func (smg *SMGeneric) processSessionCDR(s *SMGSession, usage time.Duration) {
+	if smg.cdrsrv == nil {
+		return
+	}
 	cdr := s.EventStart.AsCDR(smg.cgrCfg, smg.Timezone)
-	cdr.Usage = s.TotalUsage
+	cdr.Usage = usage
 	var reply string
-	smg.cdrsrv.Call("CdrsV1.ProcessCDR", cdr, &reply)
-	smg.replicateSessionsWithID(s.CGRID, false, smg.smgReplConns)
+	if err := smg.cdrsrv.Call(utils.CdrsV1ProcessCDR, cdr, &reply); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed processing CDR for session: %s, error: %s",
+				utils.SessionS, cdr.CGRID, err.Error()))
+	}
 }
 
 func (smg *SMGeneric) recordASession(s *SMGSession) {
@@ -396,6 +425,7 @@ func (smg *SMGeneric) getSessionIDsForPrefix(prefix string, passiveSessions bool
 func (smg *SMGeneric) sessionStart(evStart SMGenericEvent,
 	clntConn rpcclient.RpcClientConnection) (err error) {
 	cgrID := evStart.GetCGRID(utils.META_DEFAULT)
+	smg.recordClntConn(evStart.GetOriginatorIP(utils.META_DEFAULT), clntConn)
 	_, err = guardian.Guardian.Guard(func() (interface{}, error) { // Lock it on CGRID level
 		if pSS := smg.passiveToActive(cgrID); len(pSS) != 0 {
 			return nil, nil // ToDo: handle here also debits
@@ -408,7 +438,7 @@ func (smg *SMGeneric) sessionStart(evStart SMGenericEvent,
 			s := &SMGSession{CGRID: cgrID, EventStart: evStart,
 				RunID: utils.META_NONE, Timezone: smg.Timezone,
 				rals: smg.rals, cdrsrv: smg.cdrsrv,
-				clntConn: clntConn}
+				clntConn: clntConn, LastUpdate: time.Now()}
 			smg.recordASession(s)
 			return nil, nil
 		}
@@ -418,7 +448,8 @@ func (smg *SMGeneric) sessionStart(evStart SMGenericEvent,
 				RunID: sessionRun.DerivedCharger.RunID, Timezone: smg.Timezone,
 				rals: smg.rals, cdrsrv: smg.cdrsrv,
 				CD: sessionRun.CallDescriptor, clntConn: clntConn,
-				clientProto: smg.cgrCfg.SessionSCfg().ClientProtocol}
+				clientProto: smg.cgrCfg.SessionSCfg().ClientProtocol,
+				LastUpdate:  time.Now()}
 			smg.recordASession(s)
 			//utils.Logger.Info(fmt.Sprintf("<%s> Starting session: %s, runId: %s",utils.SessionS, sessionId, s.runId))
 			if smg.cgrCfg.SessionSCfg().DebitInterval != 0 {
@@ -443,6 +474,10 @@ func (smg *SMGeneric) sessionEnd(cgrID string, usage time.Duration) error {
 		if !smg.unrecordASession(cgrID) { // Unreference it early so we avoid concurrency
 			return nil, nil // Did not find the session so no need to close it anymore
 		}
+		smg.removeStoredSessions(cgrID) // ended sessions should not be recovered on restart
+		smg.rSMux.Lock()
+		delete(smg.recoveredSessions, cgrID)
+		smg.rSMux.Unlock()
 		for idx, s := range ss[cgrID] {
 			if s.RunID == utils.META_NONE {
 				continue
@@ -547,6 +582,333 @@ func (smg *SMGeneric) replicateSessionsWithID(cgrID string, passiveSessions bool
 	return
 }
 
+// storingSessions returns true if active sessions are snapshotted in DataDB
+func (smg *SMGeneric) storingSessions() bool {
+	return smg.dm != nil && smg.cgrCfg.SessionSCfg().StoreInterval > 0
+}
+
+// storeSessions snapshots the active sessions into DataDB and removes the snapshots of the ended ones
+func (smg *SMGeneric) storeSessions() {
+	aSessions := smg.getSessions("", false)
+	smg.stSMux.Lock()
+	defer smg.stSMux.Unlock()
+	for cgrID, ss := range aSessions {
+		if len(smg.getSessions(cgrID, false)) == 0 { // ended meanwhile
+			delete(aSessions, cgrID)
+			continue
+		}
+		stSs := make([]*engine.StoredSession, len(ss))
+		for i, s := range ss {
+			stSs[i] = s.asStoredSession()
+		}
+		if err := smg.dm.SetSessions(cgrID, stSs); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed storing session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+			continue
+		}
+		smg.storedSessions[cgrID] = true
+	}
+	for cgrID := range smg.storedSessions {
+		if _, isActive := aSessions[cgrID]; isActive {
+			continue
+		}
+		if err := smg.dm.RemoveSessions(cgrID); err != nil && err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed removing stored session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+			continue
+		}
+		delete(smg.storedSessions, cgrID)
+	}
+}
+
+// removeStoredSessions removes the snapshot of a session out of DataDB
+func (smg *SMGeneric) removeStoredSessions(cgrID string) {
+	if !smg.storingSessions() {
+		return
+	}
+	smg.stSMux.Lock()
+	defer smg.stSMux.Unlock()
+	if !smg.storedSessions.HasKey(cgrID) {
+		return
+	}
+	if err := smg.dm.RemoveSessions(cgrID); err != nil && err != utils.ErrNotFound {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed removing stored session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+		return
+	}
+	delete(smg.storedSessions, cgrID)
+}
+
+// runBackup snapshots regularly the active sessions
+func (smg *SMGeneric) runBackup() {
+	for {
+		select {
+		case <-smg.stopBackup:
+			return
+		case <-time.After(smg.cgrCfg.SessionSCfg().StoreInterval):
+		}
+		smg.storeSessions()
+	}
+}
+
+// recoverSessions restores the sessions snapshotted in DataDB before a restart
+func (smg *SMGeneric) recoverSessions() (err error) {
+	keys, err := smg.dm.DataDB().GetKeysForPrefix(utils.SessionsPrefix)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		cgrID := key[len(utils.SessionsPrefix):]
+		stSs, err := smg.dm.GetSessions(cgrID)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed retrieving stored session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+			continue
+		}
+		smg.stSMux.Lock()
+		smg.storedSessions[cgrID] = true // so we remove the snapshot when the session ends
+		smg.stSMux.Unlock()
+		smg.recoverSession(cgrID, stSs)
+	}
+	return
+}
+
+// recoverSession makes a stored session active again, resuming its debits.
+// Sessions which would have timed-out while we were down are terminated,
+// with the usage computed out of their TTL, the same way ttlTerminate does.
+// Sessions without TTL wait for their agent to confirm them on sync, being
+// terminated with the usage debited so far otherwise
+func (smg *SMGeneric) recoverSession(cgrID string, stSs []*engine.StoredSession) {
+	if len(stSs) == 0 {
+		smg.removeStoredSessions(cgrID)
+		return
+	}
+	var lastUpdate time.Time
+	ss := make([]*SMGSession, len(stSs))
+	for i, stS := range stSs {
+		ss[i] = &SMGSession{CGRID: cgrID, RunID: stS.RunID, Timezone: smg.Timezone,
+			EventStart: SMGenericEvent(stS.EventStart), CD: stS.CD, EventCost: stS.EventCost,
+			ExtraDuration: stS.ExtraDuration, LastUsage: stS.LastUsage,
+			LastDebit: stS.LastDebit, TotalUsage: stS.TotalUsage, LastUpdate: stS.LastUpdate,
+			rals: smg.rals, cdrsrv: smg.cdrsrv,
+			clientProto: smg.cgrCfg.SessionSCfg().ClientProtocol, recovered: true}
+		if stS.LastUpdate.After(lastUpdate) {
+			lastUpdate = stS.LastUpdate
+		}
+	}
+	ttl := ss[0].EventStart.GetSessionTTL(smg.cgrCfg.SessionSCfg().SessionTTL,
+		smg.cgrCfg.SessionSCfg().SessionTTLMaxDelay)
+	if ttl != 0 && time.Now().Sub(lastUpdate) <= ttl { // still in progress, resume it
+		for _, s := range ss {
+			smg.recordASession(s)
+		}
+		smg.startDebitLoops(ss)
+		utils.Logger.Info(fmt.Sprintf("<%s> recovered session: %s", utils.SessionS, cgrID))
+		return
+	}
+	usage := ss[0].TotalUsage // no TTL, end it with the usage debited so far
+	if ttl != 0 {
+		usage += ttl
+		if ttlUsage := ss[0].EventStart.GetSessionTTLUsage(); ttlUsage != nil {
+			usage = ss[0].TotalUsage + *ttlUsage
+		}
+	} else if smg.cgrCfg.SessionSCfg().ChannelSyncInterval > 0 { // the agent can still confirm it on sync
+		smg.rSMux.Lock()
+		smg.recoveredSessions[cgrID] = true
+		smg.rSMux.Unlock()
+		for _, s := range ss {
+			smg.recordASession(s)
+		}
+		utils.Logger.Info(fmt.Sprintf("<%s> recovered session: %s, waiting for agent confirmation",
+			utils.SessionS, cgrID))
+		return
+	}
+	for _, s := range ss {
+		smg.recordASession(s)
+	}
+	if err := smg.sessionEnd(cgrID, usage); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed terminating recovered session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> terminated recovered session: %s, usage: %s",
+		utils.SessionS, cgrID, usage))
+	smg.processSessionCDR(ss[0], usage)
+}
+
+// startDebitLoops starts the automatic debits for the runs of a session
+func (smg *SMGeneric) startDebitLoops(ss []*SMGSession) {
+	if smg.cgrCfg.SessionSCfg().DebitInterval == 0 {
+		return
+	}
+	stopDebitChan := make(chan struct{})
+	for _, s := range ss {
+		if s.RunID == utils.META_NONE || s.CD == nil {
+			continue
+		}
+		s.stopDebit = stopDebitChan
+		go s.debitLoop(smg.cgrCfg.SessionSCfg().DebitInterval)
+	}
+}
+
+// isRecoveredSession returns true for a recovered session not yet confirmed by its agent
+func (smg *SMGeneric) isRecoveredSession(cgrID string) bool {
+	smg.rSMux.RLock()
+	defer smg.rSMux.RUnlock()
+	return smg.recoveredSessions.HasKey(cgrID)
+}
+
+// confirmSession resumes a recovered session once its agent confirms it as active
+func (smg *SMGeneric) confirmSession(cgrID string, clntConn rpcclient.RpcClientConnection) {
+	if !smg.isRecoveredSession(cgrID) {
+		return
+	}
+	guardian.Guardian.Guard(func() (interface{}, error) { // Lock it on CGRID level
+		smg.rSMux.Lock()
+		if !smg.recoveredSessions.HasKey(cgrID) { // ended or confirmed meanwhile
+			smg.rSMux.Unlock()
+			return nil, nil
+		}
+		delete(smg.recoveredSessions, cgrID)
+		smg.rSMux.Unlock()
+		ss := smg.getSessions(cgrID, false)[cgrID]
+		if len(ss) == 0 {
+			return nil, nil
+		}
+		for _, s := range ss {
+			s.setClntConn(clntConn)
+		}
+		smg.startDebitLoops(ss)
+		utils.Logger.Info(fmt.Sprintf("<%s> recovered session: %s confirmed by agent", utils.SessionS, cgrID))
+		return nil, nil
+	}, smg.cgrCfg.LockingTimeout, cgrID)
+}
+
+// recordClntConn remembers the connection of the agent at originHost,
+// so we can sync with it the sessions recovered without one
+func (smg *SMGeneric) recordClntConn(originHost string, clntConn rpcclient.RpcClientConnection) {
+	if originHost == "" || clntConn == nil || reflect.ValueOf(clntConn).IsNil() {
+		return
+	}
+	smg.clntCMux.Lock()
+	smg.clntConns[originHost] = clntConn
+	smg.clntCMux.Unlock()
+}
+
+// getClntConn returns the last connection of the agent at originHost, nil if not known
+func (smg *SMGeneric) getClntConn(originHost string) rpcclient.RpcClientConnection {
+	smg.clntCMux.RLock()
+	defer smg.clntCMux.RUnlock()
+	return smg.clntConns[originHost]
+}
+
+// runSync regularly syncs the active sessions with the agents
+func (smg *SMGeneric) runSync() {
+	for {
+		select {
+		case <-smg.stopSync:
+			return
+		case <-time.After(smg.cgrCfg.SessionSCfg().ChannelSyncInterval):
+		}
+		smg.syncSessions()
+	}
+}
+
+// syncSessions asks the agents connected over BiRPC for their active sessions
+// and terminates the sessions unknown to them, ie: calls lost after an agent crash.
+// The recovered sessions are synced with the agent at their OriginHost,
+// the ones no agent can confirm are terminated.
+// Agents not implementing SessionSv1.GetActiveSessionIDs (ie: OpenSIPS)
+// are logged once, their sessions are left to the TTL and the recovered ones terminated
+func (smg *SMGeneric) syncSessions() {
+	clntSessions := make(map[rpcclient.RpcClientConnection][]*SMGSession)
+	for cgrID, ss := range smg.getSessions("", false) { // query agents after, so sessions started meanwhile are known to them
+		if len(ss) == 0 {
+			continue
+		}
+		clntConn := ss[0].getClntConn()
+		if clntConn == nil && ss[0].recovered {
+			if clntConn = smg.getClntConn(ss[0].EventStart.GetOriginatorIP(utils.META_DEFAULT)); clntConn == nil {
+				if smg.isRecoveredSession(cgrID) {
+					smg.forceTerminateSession(ss[0]) // no agent to confirm it
+				}
+				continue
+			}
+			for _, s := range ss { // so we can disconnect it
+				s.setClntConn(clntConn)
+			}
+		}
+		if clntConn == nil {
+			continue
+		}
+		clntSessions[clntConn] = append(clntSessions[clntConn], ss[0])
+	}
+	for clnt, ss := range clntSessions {
+		var originIDs []string
+		if err := clnt.Call(utils.SessionSv1GetActiveSessionIDs, "", &originIDs); err != nil {
+			if err.Error() != rpcclient.ErrUnsupporteServiceMethod.Error() {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> failed querying agent for active sessions, error: %s",
+						utils.SessionS, err.Error()))
+				continue
+			}
+			smg.clntCMux.Lock()
+			if !smg.noSyncClnts[clnt] {
+				smg.noSyncClnts[clnt] = true
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> agent of session: %s does not support %s, its sessions will not be synced",
+						utils.SessionS, ss[0].CGRID, utils.SessionSv1GetActiveSessionIDs))
+			}
+			smg.clntCMux.Unlock()
+			for _, s := range ss { // the agent cannot confirm them
+				if smg.isRecoveredSession(s.CGRID) {
+					smg.forceTerminateSession(s)
+				}
+			}
+			continue
+		}
+		aOriginIDs := utils.NewStringMap(originIDs...)
+		for _, s := range ss {
+			if aOriginIDs.HasKey(s.EventStart.GetOriginID(utils.META_DEFAULT)) {
+				smg.confirmSession(s.CGRID, clnt)
+				continue
+			}
+			smg.forceTerminateSession(s)
+		}
+	}
+}
+
+// forceTerminateSession ends a session on our side only, with the usage debited so far
+func (smg *SMGeneric) forceTerminateSession(s *SMGSession) {
+	s.mux.RLock()
+	cgrID := s.CGRID
+	usage := s.TotalUsage
+	s.mux.RUnlock()
+	if err := smg.sessionEnd(cgrID, usage); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed terminating session: %s, error: %s", utils.SessionS, cgrID, err.Error()))
+		return
+	}
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> terminated session: %s not confirmed by agent, usage: %s", utils.SessionS, cgrID, usage))
+	originID := s.EventStart.GetOriginID(utils.META_DEFAULT)
+	if smg.resS != nil {
+		var reply string
+		if err := smg.resS.Call(utils.ResourceSv1ReleaseResources,
+			utils.ArgRSv1ResourceUsage{
+				CGREvent: utils.CGREvent{Tenant: s.EventStart.GetTenant(utils.META_DEFAULT),
+					ID: utils.GenUUID(), Event: s.EventStart},
+				UsageID: originID,
+				Units:   1,
+			}, &reply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed releasing resources for session: %s, error: %s",
+					utils.SessionS, cgrID, err.Error()))
+		}
+	}
+	smg.processSessionCDR(s, usage)
+}
+
 // getSessions is used to return in a thread-safe manner active or passive sessions
 func (smg *SMGeneric) getSessions(cgrID string, passiveSessions bool) (aSS map[string][]*SMGSession) {
 	ssMux := &smg.aSessionsMux
@@ -799,8 +1161,14 @@ func (smg *SMGeneric) UpdateSession(gev SMGenericEvent,
 	}
 	defer smg.replicateSessionsWithID(gev.GetCGRID(utils.META_DEFAULT),
 		false, smg.smgReplConns)
+	smg.recordClntConn(gev.GetOriginatorIP(utils.META_DEFAULT), clnt)
+	smg.confirmSession(cgrID, clnt) // updates from the agent confirm the recovered sessions
 	for _, s := range aSessions[cgrID] {
+		s.setClntConn(clnt) // recovered sessions have no connection
 		if s.RunID == utils.META_NONE {
+			s.mux.Lock()
+			s.LastUpdate = time.Now()
+			s.mux.Unlock()
 			maxUsage = time.Duration(-1)
 			continue
 		}
@@ -1008,12 +1376,29 @@ func (smg *SMGeneric) ProcessCDR(gev SMGenericEvent) (err error) {
 	return
 }
 
+// Connect recovers the sessions stored before restart and starts the snapshotting and syncing loops
 func (smg *SMGeneric) Connect() error {
+	if smg.cgrCfg.SessionSCfg().ChannelSyncInterval > 0 {
+		go smg.runSync()
+	}
+	if !smg.storingSessions() {
+		return nil
+	}
+	if err := smg.recoverSessions(); err != nil {
+		return err
+	}
+	go smg.runBackup()
 	return nil
 }
 
 // System shutdown
 func (smg *SMGeneric) Shutdown() error {
+	close(smg.stopSync)
+	if smg.storingSessions() { // keep the sessions so we can recover them on start
+		close(smg.stopBackup)
+		smg.storeSessions()
+		return nil
+	}
 	for ssId := range smg.getSessions("", false) { // Force sessions shutdown
 		smg.sessionEnd(ssId, time.Duration(smg.cgrCfg.MaxCallDuration))
 	}
@@ -1188,6 +1573,15 @@ func (smg *SMGeneric) BiRPCV1ProcessCDR(clnt rpcclient.RpcClientConnection,
 	return nil
 }
 
+// BiRPCv1SyncSessions syncs the active sessions with the agents right away,
+// terminating the ones the agents do not know about anymore
+func (smg *SMGeneric) BiRPCv1SyncSessions(clnt rpcclient.RpcClientConnection,
+	ignParam string, reply *string) error {
+	smg.syncSessions()
+	*reply = utils.OK
+	return nil
+}
+
 func (smg *SMGeneric) BiRPCV1GetActiveSessions(clnt rpcclient.RpcClientConnection,
 	fltr map[string]string, reply *[]*ActiveSession) error {
 	for fldName, fldVal := range fltr {
@@ -1220,6 +1614,24 @@ func (smg *SMGeneric) BiRPCV1GetActiveSessionsCount(clnt rpcclient.RpcClientConn
 	return nil
 }
 
+// BiRPCV1GetActiveOriginIDs returns the OriginIDs out of the ones received which have active sessions
+func (smg *SMGeneric) BiRPCV1GetActiveOriginIDs(clnt rpcclient.RpcClientConnection,
+	originIDs []string, reply *[]string) error {
+	queriedIDs := utils.NewStringMap(originIDs...)
+	activeIDs := make([]string, 0)
+	for _, ss := range smg.getSessions("", false) {
+		for _, s := range ss {
+			originID := s.EventStart.GetOriginID(utils.META_DEFAULT)
+			if queriedIDs[originID] {
+				activeIDs = append(activeIDs, originID)
+				delete(queriedIDs, originID) // once per OriginID
+			}
+		}
+	}
+	*reply = activeIDs
+	return nil
+}
+
 func (smg *SMGeneric) BiRPCV1GetPassiveSessions(clnt rpcclient.RpcClientConnection,
 	fltr map[string]string, reply *[]*ActiveSession) error {
 	for fldName, fldVal := range fltr {
@@ -1389,7 +1801,7 @@ func (smg *SMGeneric) BiRPCv1AuthorizeEvent(clnt rpcclient.RpcClientConnection,
 		}
 		var rplyEv engine.AttrSProcessEventReply
 		if err := smg.attrS.Call(utils.AttributeSv1ProcessEvent,
-			&args.CGREvent, &rplyEv); err == nil {
+			&engine.AttrArgsProcessEvent{CGREvent: args.CGREvent}, &rplyEv); err == nil {
 			args.CGREvent = *rplyEv.CGREvent
 			authReply.Attributes = &rplyEv
 		} else if err.Error() != utils.ErrNotFound.Error() {
@@ -1600,7 +2012,7 @@ func (smg *SMGeneric) BiRPCv1InitiateSession(clnt rpcclient.RpcClientConnection,
 		}
 		var rplyEv engine.AttrSProcessEventReply
 		if err := smg.attrS.Call(utils.AttributeSv1ProcessEvent,
-			&args.CGREvent, &rplyEv); err == nil {
+			&engine.AttrArgsProcessEvent{CGREvent: args.CGREvent}, &rplyEv); err == nil {
 			args.CGREvent = *rplyEv.CGREvent
 			rply.Attributes = &rplyEv
 		} else if err.Error() != utils.ErrNotFound.Error() {
@@ -1771,7 +2183,7 @@ func (smg *SMGeneric) BiRPCv1UpdateSession(clnt rpcclient.RpcClientConnection,
 		}
 		var rplyEv engine.AttrSProcessEventReply
 		if err := smg.attrS.Call(utils.AttributeSv1ProcessEvent,
-			&args.CGREvent, &rplyEv); err == nil {
+			&engine.AttrArgsProcessEvent{CGREvent: args.CGREvent}, &rplyEv); err == nil {
 			args.CGREvent = *rplyEv.CGREvent
 			rply.Attributes = &rplyEv
 		} else if err.Error() != utils.ErrNotFound.Error() {
@@ -1968,7 +2380,7 @@ func (smg *SMGeneric) BiRPCv1ProcessEvent(clnt rpcclient.RpcClientConnection,
 		}
 		var rplyEv engine.AttrSProcessEventReply
 		if err = smg.attrS.Call(utils.AttributeSv1ProcessEvent,
-			args.CGREvent, &rplyEv); err != nil {
+			&engine.AttrArgsProcessEvent{CGREvent: args.CGREvent}, &rplyEv); err != nil {
 			return utils.NewErrAttributeS(err)
 		}
 		rply.Attributes = &rplyEv
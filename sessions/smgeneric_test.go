@@ -20,9 +20,12 @@ package sessions
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 var smgCfg *config.CGRConfig
@@ -34,7 +37,7 @@ func init() {
 }
 
 func TestSMGSessionIndexing(t *testing.T) {
-	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
 	smGev := SMGenericEvent{
 		utils.EVENT_NAME:       "TEST_EVENT",
 		utils.ToR:              "*voice",
@@ -389,7 +392,7 @@ func TestSMGSessionIndexing(t *testing.T) {
 }
 
 func TestSMGActiveSessions(t *testing.T) {
-	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
 	smGev1 := SMGenericEvent{
 		utils.EVENT_NAME:       "TEST_EVENT",
 		utils.ToR:              "*voice",
@@ -463,7 +466,7 @@ func TestSMGActiveSessions(t *testing.T) {
 }
 
 func TestGetPassiveSessions(t *testing.T) {
-	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
 	if pSS := smg.getSessions("", true); len(pSS) != 0 {
 		t.Errorf("PassiveSessions: %+v", pSS)
 	}
@@ -532,3 +535,310 @@ func TestGetPassiveSessions(t *testing.T) {
 		t.Errorf("PassiveSessions: %+v", pSS)
 	}
 }
+
+func TestSMGStoreAndRecoverSessions(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().StoreInterval = time.Hour
+	data, _ := engine.NewMapStorage()
+	dm := engine.NewDataManager(data)
+	smg := NewSMGeneric(cfg, dm, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	smGev := SMGenericEvent{
+		utils.EVENT_NAME:  "TEST_EVENT",
+		utils.ToR:         "*voice",
+		utils.OriginID:    "12345",
+		utils.Account:     "1001",
+		utils.Destination: "1002",
+		utils.Category:    "call",
+		utils.Tenant:      "cgrates.org",
+		utils.RequestType: "*prepaid",
+		utils.SetupTime:   "2015-11-09 14:21:24",
+		utils.AnswerTime:  "2015-11-09 14:22:02",
+		utils.OriginHost:  "127.0.0.1",
+	}
+	cgrID := smGev.GetCGRID(utils.META_DEFAULT)
+	smg.recordASession(&SMGSession{CGRID: cgrID, RunID: utils.META_DEFAULT,
+		Timezone: "UTC", EventStart: smGev,
+		CD: &engine.CallDescriptor{Tenant: "cgrates.org", Account: "1001",
+			LoopIndex: 2, DurationIndex: time.Minute},
+		LastDebit: 30 * time.Second, TotalUsage: time.Minute})
+	smg.storeSessions()
+	if stSs, err := dm.GetSessions(cgrID); err != nil {
+		t.Fatal(err)
+	} else if len(stSs) != 1 || stSs[0].TotalUsage != time.Minute ||
+		stSs[0].CD == nil || stSs[0].CD.LoopIndex != 2 {
+		t.Errorf("received: %s", utils.ToJSON(stSs))
+	}
+	// new instance after restart, the session without TTL waits for the agent to confirm it
+	cfg.SessionSCfg().ChannelSyncInterval = time.Hour
+	smgRcv := NewSMGeneric(cfg, dm, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	if err := smgRcv.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer close(smgRcv.stopBackup)
+	defer close(smgRcv.stopSync)
+	if aSs := smgRcv.getSessions(cgrID, false); len(aSs[cgrID]) != 1 {
+		t.Errorf("received: %+v", aSs)
+	} else if s := aSs[cgrID][0]; s.TotalUsage != time.Minute ||
+		s.LastDebit != 30*time.Second || s.CD.DurationIndex != time.Minute {
+		t.Errorf("received: %s", utils.ToJSON(s))
+	}
+	if !smgRcv.isRecoveredSession(cgrID) {
+		t.Error("session not waiting for confirmation")
+	}
+	if err := smgRcv.sessionEnd(cgrID, time.Minute); err != nil {
+		t.Error(err)
+	}
+	if smgRcv.isRecoveredSession(cgrID) {
+		t.Error("ended session still waiting for confirmation")
+	}
+	if _, err := dm.GetSessions(cgrID); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// session timing-out while we were down is terminated on recovery
+	evTTL := make(map[string]interface{})
+	for fldName, fldVal := range smGev {
+		evTTL[fldName] = fldVal
+	}
+	evTTL[utils.SessionTTL] = "10s"
+	if err := dm.SetSessions(cgrID, []*engine.StoredSession{
+		&engine.StoredSession{CGRID: cgrID, RunID: utils.META_DEFAULT,
+			EventStart: evTTL, TotalUsage: time.Minute,
+			LastUpdate: time.Now().Add(-time.Hour)}}); err != nil {
+		t.Fatal(err)
+	}
+	smgTTL := NewSMGeneric(cfg, dm, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	if err := smgTTL.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer close(smgTTL.stopBackup)
+	defer close(smgTTL.stopSync)
+	if aSs := smgTTL.getSessions(cgrID, false); len(aSs) != 0 {
+		t.Errorf("received: %+v", aSs)
+	}
+	if _, err := dm.GetSessions(cgrID); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// without TTL and sync no agent can confirm the session, terminated on recovery
+	cfg.SessionSCfg().ChannelSyncInterval = 0
+	if err := dm.SetSessions(cgrID, []*engine.StoredSession{
+		&engine.StoredSession{CGRID: cgrID, RunID: utils.META_DEFAULT,
+			EventStart: smGev, TotalUsage: time.Minute,
+			LastUpdate: time.Now()}}); err != nil {
+		t.Fatal(err)
+	}
+	smgNoSync := NewSMGeneric(cfg, dm, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	if err := smgNoSync.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer close(smgNoSync.stopBackup)
+	if aSs := smgNoSync.getSessions(cgrID, false); len(aSs) != 0 {
+		t.Errorf("received: %+v", aSs)
+	}
+	if _, err := dm.GetSessions(cgrID); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+// testAgent mocks an agent knowing only the sessions with the given OriginIDs
+type testAgent struct {
+	originIDs []string
+}
+
+func (ta *testAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.SessionSv1GetActiveSessionIDs {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	*reply.(*[]string) = ta.originIDs
+	return nil
+}
+
+func TestSMGSyncSessions(t *testing.T) {
+	cdrs := new(testCDRs)
+	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, cdrs, nil, "UTC")
+	agent := &testAgent{originIDs: []string{"111"}}
+	for originID, clntConn := range map[string]rpcclient.RpcClientConnection{
+		"111": agent, "222": agent, "333": nil} {
+		smGev := SMGenericEvent{
+			utils.EVENT_NAME:  "TEST_EVENT",
+			utils.ToR:         "*voice",
+			utils.OriginID:    originID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.Tenant:      "cgrates.org",
+			utils.RequestType: "*prepaid",
+			utils.AnswerTime:  "2015-11-09 14:22:02",
+			utils.OriginHost:  "127.0.0.1",
+		}
+		smg.recordASession(&SMGSession{CGRID: smGev.GetCGRID(utils.META_DEFAULT),
+			RunID: utils.META_DEFAULT, Timezone: "UTC", EventStart: smGev,
+			clntConn: clntConn, TotalUsage: time.Minute})
+	}
+	var reply string
+	if err := smg.BiRPCv1SyncSessions(nil, "", &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("received reply: %s", reply)
+	}
+	// 111 is live on the agent, 222 is unknown to it and 333 has no agent connection to sync with
+	aSs := smg.getSessions("", false)
+	if len(aSs) != 2 {
+		t.Errorf("received: %+v", aSs)
+	}
+	kept := make(utils.StringMap)
+	for _, ss := range aSs {
+		kept[ss[0].EventStart.GetOriginID(utils.META_DEFAULT)] = true
+	}
+	if !kept["111"] || !kept["333"] {
+		t.Errorf("live sessions not kept, received: %+v", kept)
+	}
+	if len(cdrs.cdrs) != 1 {
+		t.Fatalf("received: %s", utils.ToJSON(cdrs.cdrs))
+	} else if cdrs.cdrs[0].OriginID != "222" || cdrs.cdrs[0].Usage != time.Minute {
+		t.Errorf("stale session not terminated, received: %s", utils.ToJSON(cdrs.cdrs[0]))
+	}
+}
+
+func TestSMGSyncRecoveredSessions(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().ChannelSyncInterval = time.Hour
+	smg := NewSMGeneric(cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	agent := &testAgent{originIDs: []string{"111"}}
+	smg.recordClntConn("127.0.0.1", agent)
+	cgrIDs := make(map[string]string)
+	for originID, originHost := range map[string]string{
+		"111": "127.0.0.1", "222": "127.0.0.1", "333": "10.0.0.1"} {
+		ev := map[string]interface{}{
+			utils.EVENT_NAME:  "TEST_EVENT",
+			utils.ToR:         "*voice",
+			utils.OriginID:    originID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.Tenant:      "cgrates.org",
+			utils.RequestType: "*prepaid",
+			utils.AnswerTime:  "2015-11-09 14:22:02",
+			utils.OriginHost:  originHost,
+		}
+		cgrID := SMGenericEvent(ev).GetCGRID(utils.META_DEFAULT)
+		cgrIDs[originID] = cgrID
+		smg.recoverSession(cgrID, []*engine.StoredSession{
+			&engine.StoredSession{CGRID: cgrID, RunID: utils.META_DEFAULT,
+				EventStart: ev, TotalUsage: time.Minute,
+				LastUpdate: time.Now().Add(-time.Hour)}})
+	}
+	if aSs := smg.getSessions("", false); len(aSs) != 3 {
+		t.Fatalf("received: %+v", aSs)
+	}
+	smg.syncSessions()
+	// 111 is confirmed by the agent, 222 is unknown to it and 333 has no agent to confirm it
+	aSs := smg.getSessions("", false)
+	if ss, has := aSs[cgrIDs["111"]]; !has || len(aSs) != 1 {
+		t.Fatalf("received: %+v", aSs)
+	} else if ss[0].getClntConn() != agent {
+		t.Errorf("agent connection not restored on session: %+v", ss[0])
+	}
+	if smg.isRecoveredSession(cgrIDs["111"]) {
+		t.Error("session still waiting for confirmation")
+	}
+}
+
+// testCDRs mocks CDRs, recording the processed CDRs
+type testCDRs struct {
+	cdrs []*engine.CDR
+}
+
+func (tc *testCDRs) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.CdrsV1ProcessCDR {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	tc.cdrs = append(tc.cdrs, args.(*engine.CDR))
+	*reply.(*string) = utils.OK
+	return nil
+}
+
+func TestSMGSyncSessionsUnsupportedAgent(t *testing.T) {
+	cdrs := new(testCDRs)
+	smg := NewSMGeneric(smgCfg, nil, nil, nil, nil, nil, nil, nil, cdrs, nil, "UTC")
+	agent := &testAgent{}      // does not know the sessions
+	noSyncAgent := &testCDRs{} // GetActiveSessionIDs unsupported
+	for originID, clntConn := range map[string]rpcclient.RpcClientConnection{
+		"111": agent, "222": noSyncAgent} {
+		smGev := SMGenericEvent{
+			utils.EVENT_NAME:  "TEST_EVENT",
+			utils.ToR:         "*voice",
+			utils.OriginID:    originID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.Tenant:      "cgrates.org",
+			utils.RequestType: "*prepaid",
+			utils.AnswerTime:  "2015-11-09 14:22:02",
+			utils.OriginHost:  "127.0.0.1",
+		}
+		smg.recordASession(&SMGSession{CGRID: smGev.GetCGRID(utils.META_DEFAULT),
+			RunID: utils.META_DEFAULT, Timezone: "UTC", EventStart: smGev,
+			clntConn: clntConn, TotalUsage: time.Minute})
+	}
+	smg.syncSessions()
+	smg.syncSessions()
+	if aSs := smg.getSessions("", false); len(aSs) != 1 {
+		t.Errorf("received: %+v", aSs)
+	}
+	if len(smg.noSyncClnts) != 1 || !smg.noSyncClnts[noSyncAgent] {
+		t.Errorf("received: %+v", smg.noSyncClnts)
+	}
+	if len(cdrs.cdrs) != 1 {
+		t.Fatalf("received: %s", utils.ToJSON(cdrs.cdrs))
+	} else if cdrs.cdrs[0].OriginID != "111" || cdrs.cdrs[0].Usage != time.Minute {
+		t.Errorf("received: %s", utils.ToJSON(cdrs.cdrs[0]))
+	}
+}
+
+func TestSMGRecoveredSessionClntConn(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	smg := NewSMGeneric(cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, "UTC")
+	agent := &testAgent{originIDs: []string{"111"}}
+	smg.recordClntConn("127.0.0.1", agent)
+	updtAgent := &testAgent{}
+	cgrIDs := make(map[string]string)
+	for originID, runID := range map[string]string{
+		"111": utils.META_DEFAULT, "222": utils.META_NONE} {
+		ev := map[string]interface{}{
+			utils.EVENT_NAME:  "TEST_EVENT",
+			utils.ToR:         "*voice",
+			utils.OriginID:    originID,
+			utils.Account:     "1001",
+			utils.Destination: "1002",
+			utils.Tenant:      "cgrates.org",
+			utils.RequestType: "*prepaid",
+			utils.AnswerTime:  "2015-11-09 14:22:02",
+			utils.OriginHost:  "127.0.0.1",
+			utils.SessionTTL:  "1h",
+		}
+		cgrID := SMGenericEvent(ev).GetCGRID(utils.META_DEFAULT)
+		cgrIDs[originID] = cgrID
+		smg.recoverSession(cgrID, []*engine.StoredSession{
+			&engine.StoredSession{CGRID: cgrID, RunID: runID,
+				EventStart: ev, TotalUsage: time.Minute,
+				LastUpdate: time.Now()}})
+	}
+	// resumed within TTL, the agent connection is restored on update
+	updtEv := SMGenericEvent{}
+	for fldName, fldVal := range smg.getSessions(cgrIDs["222"], false)[cgrIDs["222"]][0].EventStart {
+		updtEv[fldName] = fldVal
+	}
+	updtEv[utils.Usage] = "1m"
+	if _, err := smg.UpdateSession(updtEv, updtAgent); err != nil {
+		t.Error(err)
+	}
+	if aSs := smg.getSessions(cgrIDs["222"], false); len(aSs) != 1 {
+		t.Fatalf("received: %+v", aSs)
+	} else if clntConn := aSs[cgrIDs["222"]][0].getClntConn(); clntConn != updtAgent {
+		t.Errorf("received connection: %+v", clntConn)
+	}
+	// and on sync with the agent at its OriginHost
+	smg.syncSessions()
+	if aSs := smg.getSessions(cgrIDs["111"], false); len(aSs) != 1 {
+		t.Fatalf("received: %+v", aSs)
+	} else if clntConn := aSs[cgrIDs["111"]][0].getClntConn(); clntConn != agent {
+		t.Errorf("received connection: %+v", clntConn)
+	}
+}
@@ -677,6 +677,7 @@ type ArgsCache struct {
 	FilterIDs             *[]string
 	SupplierProfileIDs    *[]string
 	AttributeProfileIDs   *[]string
+	DispatcherProfileIDs  *[]string
 }
 
 // Data used to do remote cache reloads via api
@@ -720,6 +721,7 @@ type CacheStats struct {
 	Filters             int
 	SupplierProfiles    int
 	AttributeProfiles   int
+	DispatcherProfiles  int
 }
 
 type AttrExpFileCdrs struct {
@@ -1269,6 +1271,11 @@ type TPResource struct {
 	Stored             bool
 	Weight             float64  // Weight to sort the ResourceLimits
 	ThresholdIDs       []string // Thresholds to check after changing Limit
+	AllocationStrategy string
+	MaxUsageUnits      string // maximum units for one usage
+	QueueTimeout       string
+	QueueLength        int
+	RateInterval       string // sliding window for rate limiting resources
 }
 
 // TPActivationInterval represents an activation interval for an item
@@ -1345,6 +1352,7 @@ type TPStats struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	BucketInterval     string // aggregate the events into time buckets of this size
 }
 
 type MetricWithParams struct {
@@ -1365,6 +1373,8 @@ type TPThreshold struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	ResetInterval      string
+	RecoveryFilterIDs  []string
 }
 
 type TPFilterProfile struct {
@@ -1408,6 +1418,7 @@ type TPSupplierProfile struct {
 type TPAttribute struct {
 	FieldName  string
 	Initial    string
+	Type       string // <""|*constant|*composed|*sum|*usage_difference>
 	Substitute string
 	Append     bool
 }
@@ -111,6 +111,9 @@ func (ev *CGREvent) FieldAsFloat64(fldName string) (f float64, err error) {
 	if val, canCast := iface.(float64); canCast {
 		return val, nil
 	}
+	if dur, canCast := iface.(time.Duration); canCast { // durations are considered in nanoseconds
+		return float64(dur.Nanoseconds()), nil
+	}
 	csStr, canCast := iface.(string)
 	if !canCast {
 		err = fmt.Errorf("cannot cast %s to string", fldName)
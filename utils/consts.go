@@ -41,56 +41,61 @@ var (
 		MetaFileFWV:     FWVSuffix,
 	}
 	CacheInstanceToPrefix = map[string]string{
-		CacheDestinations:              DESTINATION_PREFIX,
-		CacheReverseDestinations:       REVERSE_DESTINATION_PREFIX,
-		CacheRatingPlans:               RATING_PLAN_PREFIX,
-		CacheRatingProfiles:            RATING_PROFILE_PREFIX,
-		CacheLCRRules:                  LCR_PREFIX,
-		CacheCDRStatS:                  CDR_STATS_PREFIX,
-		CacheActions:                   ACTION_PREFIX,
-		CacheActionPlans:               ACTION_PLAN_PREFIX,
-		CacheAccountActionPlans:        AccountActionPlansPrefix,
-		CacheActionTriggers:            ACTION_TRIGGER_PREFIX,
-		CacheSharedGroups:              SHARED_GROUP_PREFIX,
-		CacheAliases:                   ALIASES_PREFIX,
-		CacheReverseAliases:            REVERSE_ALIASES_PREFIX,
-		CacheDerivedChargers:           DERIVEDCHARGERS_PREFIX,
-		CacheResourceProfiles:          ResourceProfilesPrefix,
-		CacheResources:                 ResourcesPrefix,
-		CacheEventResources:            EventResourcesPrefix,
-		CacheTimings:                   TimingsPrefix,
-		CacheStatQueueProfiles:         StatQueueProfilePrefix,
-		CacheStatQueues:                StatQueuePrefix,
-		CacheThresholdProfiles:         ThresholdProfilePrefix,
-		CacheThresholds:                ThresholdPrefix,
-		CacheFilters:                   FilterPrefix,
-		CacheSupplierProfiles:          SupplierProfilePrefix,
-		CacheAttributeProfiles:         AttributeProfilePrefix,
-		CacheResourceFilterIndexes:     ResourceFilterIndexes,
-		CacheResourceFilterRevIndexes:  ResourceFilterRevIndexes,
-		CacheStatFilterIndexes:         StatFilterIndexes,
-		CacheStatFilterRevIndexes:      StatFilterRevIndexes,
-		CacheThresholdFilterIndexes:    ThresholdFilterIndexes,
-		CacheThresholdFilterRevIndexes: ThresholdFilterRevIndexes,
-		CacheSupplierFilterIndexes:     SupplierFilterIndexes,
-		CacheSupplierFilterRevIndexes:  SupplierFilterRevIndexes,
-		CacheAttributeFilterIndexes:    AttributeFilterIndexes,
-		CacheAttributeFilterRevIndexes: AttributeFilterRevIndexes,
+		CacheDestinations:               DESTINATION_PREFIX,
+		CacheReverseDestinations:        REVERSE_DESTINATION_PREFIX,
+		CacheRatingPlans:                RATING_PLAN_PREFIX,
+		CacheRatingProfiles:             RATING_PROFILE_PREFIX,
+		CacheLCRRules:                   LCR_PREFIX,
+		CacheCDRStatS:                   CDR_STATS_PREFIX,
+		CacheActions:                    ACTION_PREFIX,
+		CacheActionPlans:                ACTION_PLAN_PREFIX,
+		CacheAccountActionPlans:         AccountActionPlansPrefix,
+		CacheActionTriggers:             ACTION_TRIGGER_PREFIX,
+		CacheSharedGroups:               SHARED_GROUP_PREFIX,
+		CacheAliases:                    ALIASES_PREFIX,
+		CacheReverseAliases:             REVERSE_ALIASES_PREFIX,
+		CacheDerivedChargers:            DERIVEDCHARGERS_PREFIX,
+		CacheResourceProfiles:           ResourceProfilesPrefix,
+		CacheResources:                  ResourcesPrefix,
+		CacheEventResources:             EventResourcesPrefix,
+		CacheTimings:                    TimingsPrefix,
+		CacheStatQueueProfiles:          StatQueueProfilePrefix,
+		CacheStatQueues:                 StatQueuePrefix,
+		CacheThresholdProfiles:          ThresholdProfilePrefix,
+		CacheThresholds:                 ThresholdPrefix,
+		CacheFilters:                    FilterPrefix,
+		CacheSupplierProfiles:           SupplierProfilePrefix,
+		CacheAttributeProfiles:          AttributeProfilePrefix,
+		CacheDispatcherProfiles:         DispatcherProfilePrefix,
+		CacheResourceFilterIndexes:      ResourceFilterIndexes,
+		CacheResourceFilterRevIndexes:   ResourceFilterRevIndexes,
+		CacheStatFilterIndexes:          StatFilterIndexes,
+		CacheStatFilterRevIndexes:       StatFilterRevIndexes,
+		CacheThresholdFilterIndexes:     ThresholdFilterIndexes,
+		CacheThresholdFilterRevIndexes:  ThresholdFilterRevIndexes,
+		CacheSupplierFilterIndexes:      SupplierFilterIndexes,
+		CacheSupplierFilterRevIndexes:   SupplierFilterRevIndexes,
+		CacheAttributeFilterIndexes:     AttributeFilterIndexes,
+		CacheAttributeFilterRevIndexes:  AttributeFilterRevIndexes,
+		CacheDispatcherFilterIndexes:    DispatcherFilterIndexes,
+		CacheDispatcherFilterRevIndexes: DispatcherFilterRevIndexes,
 	}
 	CachePrefixToInstance map[string]string // will be built on init
 	PrefixToIndexCache    = map[string]string{
-		ThresholdProfilePrefix: CacheThresholdFilterIndexes,
-		ResourceProfilesPrefix: CacheResourceFilterIndexes,
-		StatQueueProfilePrefix: CacheStatFilterIndexes,
-		SupplierProfilePrefix:  CacheSupplierFilterIndexes,
-		AttributeProfilePrefix: CacheAttributeFilterIndexes,
+		ThresholdProfilePrefix:  CacheThresholdFilterIndexes,
+		ResourceProfilesPrefix:  CacheResourceFilterIndexes,
+		StatQueueProfilePrefix:  CacheStatFilterIndexes,
+		SupplierProfilePrefix:   CacheSupplierFilterIndexes,
+		AttributeProfilePrefix:  CacheAttributeFilterIndexes,
+		DispatcherProfilePrefix: CacheDispatcherFilterIndexes,
 	}
 	PrefixToRevIndexCache = map[string]string{
-		ThresholdProfilePrefix: CacheThresholdFilterRevIndexes,
-		ResourceProfilesPrefix: CacheResourceFilterRevIndexes,
-		StatQueueProfilePrefix: CacheStatFilterRevIndexes,
-		SupplierProfilePrefix:  CacheSupplierFilterRevIndexes,
-		AttributeProfilePrefix: CacheAttributeFilterRevIndexes,
+		ThresholdProfilePrefix:  CacheThresholdFilterRevIndexes,
+		ResourceProfilesPrefix:  CacheResourceFilterRevIndexes,
+		StatQueueProfilePrefix:  CacheStatFilterRevIndexes,
+		SupplierProfilePrefix:   CacheSupplierFilterRevIndexes,
+		AttributeProfilePrefix:  CacheAttributeFilterRevIndexes,
+		DispatcherProfilePrefix: CacheDispatcherFilterRevIndexes,
 	}
 )
 
@@ -252,6 +257,8 @@ const (
 	StatQueueProfilePrefix        = "sqp_"
 	SupplierProfilePrefix         = "spp_"
 	AttributeProfilePrefix        = "alp_"
+	DispatcherProfilePrefix       = "dpp_"
+	SessionsPrefix                = "ses_"
 	ThresholdProfilePrefix        = "thp_"
 	StatQueuePrefix               = "stq_"
 	LOADINST_KEY                  = "load_history"
@@ -330,6 +337,7 @@ const (
 	TRIGGER_BALANCE_EXPIRED      = "*balance_expired"
 	HIERARCHY_SEP                = ">"
 	META_COMPOSED                = "*composed"
+	MetaUsageDifference          = "*usage_difference"
 	MetaString                   = "*string"
 	NegativePrefix               = "!"
 	MatchStartPrefix             = "^"
@@ -513,9 +521,19 @@ const (
 	MetaDataDB                   = "*datadb"
 	MetaWeight                   = "*weight"
 	MetaLeastCost                = "*least_cost"
+	MetaQOS                      = "*qos"
+	MetaHighestCost              = "*highest_cost"
+	MetaLoadDistribution         = "*load_distribution"
+	MetaReas                     = "*reas"
+	MetaAsc                      = "*asc"
+	MetaDesc                     = "*desc"
 	Weight                       = "Weight"
 	Cost                         = "Cost"
 	RatingPlanID                 = "RatingPlanID"
+	ResourceUsage                = "ResourceUsage"
+	ResourceAvailability         = "ResourceAvailability"
+	Ratio                        = "Ratio"
+	Load                         = "Load"
 	MetaSessionS                 = "*sessions"
 	MetaDefault                  = "*default"
 	Error                        = "Error"
@@ -525,6 +543,7 @@ const (
 	FilterIDs                    = "FilterIDs"
 	FieldName                    = "FieldName"
 	Initial                      = "Initial"
+	Type                         = "Type"
 	Substitute                   = "Substitute"
 	Append                       = "Append"
 	MetaRound                    = "*round"
@@ -552,15 +571,23 @@ const (
 
 // MetaMetrics
 const (
-	MetaASR     = "*asr"
-	MetaACD     = "*acd"
-	MetaTCD     = "*tcd"
-	MetaACC     = "*acc"
-	MetaTCC     = "*tcc"
-	MetaPDD     = "*pdd"
-	MetaDDC     = "*ddc"
-	MetaSum     = "*sum"
-	MetaAverage = "*average"
+	MetaASR       = "*asr"
+	MetaACD       = "*acd"
+	MetaTCD       = "*tcd"
+	MetaACC       = "*acc"
+	MetaTCC       = "*tcc"
+	MetaPDD       = "*pdd"
+	MetaDDC       = "*ddc"
+	MetaSum       = "*sum"
+	MetaAverage   = "*average"
+	MetaP50       = "*p50"
+	MetaP95       = "*p95"
+	MetaP99       = "*p99"
+	MetaHistogram = "*histogram"
+	MetaStdDev    = "*stddev"
+	MetaMin       = "*min"
+	MetaMax       = "*max"
+	MetaDistinct  = "*distinct"
 )
 
 // Services
@@ -626,21 +653,38 @@ const (
 	CapStatQueues           = "StatQueues"
 )
 
+// ResourceS allocation strategies
+const (
+	MetaAll      = "*all"
+	MetaFirstFit = "*first_fit"
+)
+
 // Dispatcher Const
 const (
-	MetaFirst     = "*first"
-	MetaRandom    = "*random"
-	MetaBroadcast = "*broadcast"
-	MetaNext      = "*next"
-	ThresholdSv1  = "ThresholdSv1"
-	StatSv1       = "StatSv1"
-	ResourceSv1   = "ResourceSv1"
-	SupplierSv1   = "SupplierSv1"
-	AttributeSv1  = "AttributeSv1"
-	SessionSv1    = "SessionSv1"
-	MetaAuth      = "*auth"
-	APIKey        = "APIKey"
-	APIMethods    = "APIMethods"
+	MetaFirst      = "*first"
+	MetaRandom     = "*random"
+	MetaBroadcast  = "*broadcast"
+	MetaNext       = "*next"
+	MetaRoundRobin = "*round_robin"
+	MetaHash       = "*hash"
+	MetaLoad       = "*load"
+	MetaHashField  = "*hash_field"
+	MetaRALs       = "*rals"
+	MetaCaches     = "*caches"
+	MetaApier      = "*apier"
+	ThresholdSv1   = "ThresholdSv1"
+	StatSv1        = "StatSv1"
+	ResourceSv1    = "ResourceSv1"
+	SupplierSv1    = "SupplierSv1"
+	AttributeSv1   = "AttributeSv1"
+	FilterSv1      = "FilterSv1"
+	SessionSv1     = "SessionSv1"
+	Responder      = "Responder"
+	CdrsV1         = "CdrsV1"
+	ApierV1        = "ApierV1"
+	MetaAuth       = "*auth"
+	APIKey         = "APIKey"
+	APIMethods     = "APIMethods"
 )
 
 // MetaFilterIndexesAPIs
@@ -649,6 +693,24 @@ const (
 	ApierV1ReloadCache          = "ApierV1.ReloadCache"
 	ApierV1ReloadScheduler      = "ApierV1.ReloadScheduler"
 	ApierV1Ping                 = "ApierV1.Ping"
+	ApierV1GetScheduledActions  = "ApierV1.GetScheduledActions"
+	ApierV1GetAccount           = "ApierV1.GetAccount"
+	ApierV1GetAccounts          = "ApierV1.GetAccounts"
+	ApierV1GetMaxUsage          = "ApierV1.GetMaxUsage"
+)
+
+// Responder APIs
+const (
+	ResponderGetCost           = "Responder.GetCost"
+	ResponderDebit             = "Responder.Debit"
+	ResponderMaxDebit          = "Responder.MaxDebit"
+	ResponderGetMaxSessionTime = "Responder.GetMaxSessionTime"
+)
+
+// CDRs APIs
+const (
+	CdrsV1ProcessCDR = "CdrsV1.ProcessCDR"
+	CdrsV1RateCDRs   = "CdrsV1.RateCDRs"
 )
 
 const (
@@ -673,6 +735,12 @@ const (
 	AttributeSv1Ping                 = "AttributeSv1.Ping"
 )
 
+// FilterS APIs
+const (
+	FilterSv1ExplainFilters = "FilterSv1.ExplainFilters"
+	FilterSv1Ping           = "FilterSv1.Ping"
+)
+
 // ThresholdS APIs
 const (
 	ThresholdSv1ProcessEvent          = "ThresholdSv1.ProcessEvent"
@@ -680,6 +748,7 @@ const (
 	ThresholdSv1GetThresholdIDs       = "ThresholdSv1.GetThresholdIDs"
 	ThresholdSv1Ping                  = "ThresholdSv1.Ping"
 	ThresholdSv1GetThresholdsForEvent = "ThresholdSv1.GetThresholdsForEvent"
+	ThresholdSv1ResetThreshold        = "ThresholdSv1.ResetThreshold"
 )
 
 // StatS APIs
@@ -687,17 +756,22 @@ const (
 	StatSv1ProcessEvent          = "StatSv1.ProcessEvent"
 	StatSv1GetQueueIDs           = "StatSv1.GetQueueIDs"
 	StatSv1GetQueueStringMetrics = "StatSv1.GetQueueStringMetrics"
+	StatSv1GetQueueFloatMetrics  = "StatSv1.GetQueueFloatMetrics"
 	StatSv1Ping                  = "StatSv1.Ping"
 	StatSv1GetStatQueuesForEvent = "StatSv1.GetStatQueuesForEvent"
 )
 
 // ResourceS APIs
 const (
-	ResourceSv1AuthorizeResources   = "ResourceSv1.AuthorizeResources"
-	ResourceSv1GetResourcesForEvent = "ResourceSv1.GetResourcesForEvent"
-	ResourceSv1AllocateResources    = "ResourceSv1.AllocateResources"
-	ResourceSv1ReleaseResources     = "ResourceSv1.ReleaseResources"
-	ResourceSv1Ping                 = "ResourceSv1.Ping"
+	ResourceSv1AuthorizeResources    = "ResourceSv1.AuthorizeResources"
+	ResourceSv1GetResourcesForEvent  = "ResourceSv1.GetResourcesForEvent"
+	ResourceSv1AllocateResources     = "ResourceSv1.AllocateResources"
+	ResourceSv1ReleaseResources      = "ResourceSv1.ReleaseResources"
+	ResourceSv1Ping                  = "ResourceSv1.Ping"
+	ResourceSv1GetResourceIDs        = "ResourceSv1.GetResourceIDs"
+	ResourceSv1GetResourceSummary    = "ResourceSv1.GetResourceSummary"
+	ResourceSv1GetResourceUsages     = "ResourceSv1.GetResourceUsages"
+	ResourceSv1ReleaseResourceUsages = "ResourceSv1.ReleaseResourceUsages"
 )
 
 // SessionS APIs
@@ -711,17 +785,22 @@ const (
 	SessionSv1ProcessCDR                = "SessionSv1.ProcessCDR"
 	SessionSv1ProcessEvent              = "SessionSv1.ProcessEvent"
 	SessionSv1DisconnectSession         = "SessionSv1.DisconnectSession"
+	SessionSv1GetActiveSessionIDs       = "SessionSv1.GetActiveSessionIDs"
+	SessionSv1SyncSessions              = "SessionSv1.SyncSessions"
 	SessionSv1GetActiveSessions         = "SessionSv1.GetActiveSessions"
 	SessionSv1GetPassiveSessions        = "SessionSv1.GetPassiveSessions"
 	SMGenericV1InitiateSession          = "SMGenericV1.InitiateSession"
 	SMGenericV2InitiateSession          = "SMGenericV2.InitiateSession"
 	SMGenericV2UpdateSession            = "SMGenericV2.UpdateSession"
+	SMGenericV1GetActiveSessionsCount   = "SMGenericV1.GetActiveSessionsCount"
+	SMGenericV1GetActiveOriginIDs       = "SMGenericV1.GetActiveOriginIDs"
 	SessionSv1Ping                      = "SessionSv1.Ping"
 )
 
 // DispatcherS APIs
 const (
-	DispatcherSv1Ping = "DispatcherSv1.Ping"
+	DispatcherSv1Ping          = "DispatcherSv1.Ping"
+	DispatcherSv1GetHostStatus = "DispatcherSv1.GetHostStatus"
 )
 
 // LoaderS APIs
@@ -742,6 +821,7 @@ const (
 	CacheSv1GetGroupItemIDs   = "CacheSv1.GetGroupItemIDs"
 	CacheSv1RemoveGroup       = "CacheSv1.RemoveGroup"
 	CacheSv1Clear             = "CacheSv1.Clear"
+	CacheSv1Ping              = "CacheSv1.Ping"
 )
 
 // Scheduler
@@ -812,57 +892,62 @@ const (
 
 // Cache Name
 const (
-	CacheDestinations              = "destinations"
-	CacheReverseDestinations       = "reverse_destinations"
-	CacheRatingPlans               = "rating_plans"
-	CacheRatingProfiles            = "rating_profiles"
-	CacheLCRRules                  = "lcr_rules"
-	CacheCDRStatS                  = "cdr_stats"
-	CacheActions                   = "actions"
-	CacheActionPlans               = "action_plans"
-	CacheAccountActionPlans        = "account_action_plans"
-	CacheActionTriggers            = "action_triggers"
-	CacheSharedGroups              = "shared_groups"
-	CacheAliases                   = "aliases"
-	CacheReverseAliases            = "reverse_aliases"
-	CacheDerivedChargers           = "derived_chargers"
-	CacheResources                 = "resources"
-	CacheResourceProfiles          = "resource_profiles"
-	CacheTimings                   = "timings"
-	CacheEventResources            = "event_resources"
-	CacheStatQueueProfiles         = "statqueue_profiles"
-	CacheStatQueues                = "statqueues"
-	CacheThresholdProfiles         = "threshold_profiles"
-	CacheThresholds                = "thresholds"
-	CacheFilters                   = "filters"
-	CacheSupplierProfiles          = "supplier_profiles"
-	CacheAttributeProfiles         = "attribute_profiles"
-	CacheResourceFilterIndexes     = "resource_filter_indexes"
-	CacheResourceFilterRevIndexes  = "resource_filter_revindexes"
-	CacheStatFilterIndexes         = "stat_filter_indexes"
-	CacheStatFilterRevIndexes      = "stat_filter_revindexes"
-	CacheThresholdFilterIndexes    = "threshold_filter_indexes"
-	CacheThresholdFilterRevIndexes = "threshold_filter_revindexes"
-	CacheSupplierFilterIndexes     = "supplier_filter_indexes"
-	CacheSupplierFilterRevIndexes  = "supplier_filter_revindexes"
-	CacheAttributeFilterIndexes    = "attribute_filter_indexes"
-	CacheAttributeFilterRevIndexes = "attribute_filter_revindexes"
-	MetaPrecaching                 = "*precaching"
-	MetaReady                      = "*ready"
+	CacheDestinations               = "destinations"
+	CacheReverseDestinations        = "reverse_destinations"
+	CacheRatingPlans                = "rating_plans"
+	CacheRatingProfiles             = "rating_profiles"
+	CacheLCRRules                   = "lcr_rules"
+	CacheCDRStatS                   = "cdr_stats"
+	CacheActions                    = "actions"
+	CacheActionPlans                = "action_plans"
+	CacheAccountActionPlans         = "account_action_plans"
+	CacheActionTriggers             = "action_triggers"
+	CacheSharedGroups               = "shared_groups"
+	CacheAliases                    = "aliases"
+	CacheReverseAliases             = "reverse_aliases"
+	CacheDerivedChargers            = "derived_chargers"
+	CacheResources                  = "resources"
+	CacheResourceProfiles           = "resource_profiles"
+	CacheTimings                    = "timings"
+	CacheEventResources             = "event_resources"
+	CacheStatQueueProfiles          = "statqueue_profiles"
+	CacheStatQueues                 = "statqueues"
+	CacheThresholdProfiles          = "threshold_profiles"
+	CacheThresholds                 = "thresholds"
+	CacheFilters                    = "filters"
+	CacheSupplierProfiles           = "supplier_profiles"
+	CacheAttributeProfiles          = "attribute_profiles"
+	CacheResourceFilterIndexes      = "resource_filter_indexes"
+	CacheResourceFilterRevIndexes   = "resource_filter_revindexes"
+	CacheStatFilterIndexes          = "stat_filter_indexes"
+	CacheStatFilterRevIndexes       = "stat_filter_revindexes"
+	CacheThresholdFilterIndexes     = "threshold_filter_indexes"
+	CacheThresholdFilterRevIndexes  = "threshold_filter_revindexes"
+	CacheSupplierFilterIndexes      = "supplier_filter_indexes"
+	CacheSupplierFilterRevIndexes   = "supplier_filter_revindexes"
+	CacheAttributeFilterIndexes     = "attribute_filter_indexes"
+	CacheAttributeFilterRevIndexes  = "attribute_filter_revindexes"
+	CacheDispatcherProfiles         = "dispatcher_profiles"
+	CacheDispatcherFilterIndexes    = "dispatcher_filter_indexes"
+	CacheDispatcherFilterRevIndexes = "dispatcher_filter_revindexes"
+	MetaPrecaching                  = "*precaching"
+	MetaReady                       = "*ready"
 )
 
 // Prefix for indexing
 const (
-	ResourceFilterIndexes     = "rfi_"
-	ResourceFilterRevIndexes  = "rfr_"
-	StatFilterIndexes         = "sfi_"
-	StatFilterRevIndexes      = "sfr_"
-	ThresholdFilterIndexes    = "tfi_"
-	ThresholdFilterRevIndexes = "tfr_"
-	SupplierFilterIndexes     = "spi_"
-	SupplierFilterRevIndexes  = "spr_"
-	AttributeFilterIndexes    = "afi_"
-	AttributeFilterRevIndexes = "afr_"
+	ResourceFilterIndexes      = "rfi_"
+	ResourceFilterRevIndexes   = "rfr_"
+	StatFilterIndexes          = "sfi_"
+	StatFilterRevIndexes       = "sfr_"
+	ThresholdFilterIndexes     = "tfi_"
+	ThresholdFilterRevIndexes  = "tfr_"
+	SupplierFilterIndexes      = "spi_"
+	SupplierFilterRevIndexes   = "spr_"
+	AttributeFilterIndexes     = "afi_"
+	AttributeFilterRevIndexes  = "afr_"
+	DispatcherFilterIndexes    = "dfi_"
+	DispatcherFilterRevIndexes = "dfr_"
 )
 
 // Agents
@@ -873,6 +958,7 @@ const (
 	FreeSWITCHAgent = "FreeSWITCHAgent"
 	AsteriskAgent   = "AsteriskAgent"
 	HTTPAgent       = "HTTPAgent"
+	PrometheusAgent = "PrometheusAgent"
 )
 
 func buildCacheInstRevPrefixes() {
@@ -278,6 +278,15 @@ func SplitPrefix(prefix string, minLength int) []string {
 	return subs
 }
 
+// SplitSuffix returns all the suffixes of the string, longest first
+func SplitSuffix(suffix string) []string {
+	subs := make([]string, len(suffix))
+	for i := range subs {
+		subs[i] = suffix[i:]
+	}
+	return subs
+}
+
 func CopyHour(src, dest time.Time) time.Time {
 	if src.Hour() == 0 && src.Minute() == 0 && src.Second() == 0 {
 		return src
@@ -349,6 +358,11 @@ func ConcatenatedKey(keyVals ...string) string {
 	return strings.Join(keyVals, CONCATENATED_KEY_SEP)
 }
 
+// SplitConcatenatedKey is the reverse of ConcatenatedKey
+func SplitConcatenatedKey(key string) []string {
+	return strings.Split(key, CONCATENATED_KEY_SEP)
+}
+
 func LCRKey(direction, tenant, category, account, subject string) string {
 	return ConcatenatedKey(direction, tenant, category, account, subject)
 
@@ -381,6 +381,15 @@ func TestSplitPrefixEmpty(t *testing.T) {
 	}
 }
 
+func TestSplitSuffix(t *testing.T) {
+	if a := SplitSuffix("0123"); !reflect.DeepEqual([]string{"0123", "123", "23", "3"}, a) {
+		t.Error("Error splitting suffix: ", a)
+	}
+	if a := SplitSuffix(""); len(a) != 0 {
+		t.Error("Error splitting suffix: ", a)
+	}
+}
+
 func TestParseDurationWithSecs(t *testing.T) {
 	durStr := "2"
 	durExpected := time.Duration(2) * time.Second
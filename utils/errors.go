@@ -55,6 +55,7 @@ var (
 	ErrNotConvertibleNoCaps     = errors.New("not convertible")
 	ErrMandatoryIeMissingNoCaps = errors.New("mandatory information missing")
 	ErrUnauthorizedApi          = errors.New("UNAUTHORIZED_API")
+	ErrFilterCycle              = errors.New("FILTER_CYCLE")
 	RalsErrorPrfx               = "RALS_ERROR"
 )
 
@@ -126,6 +127,11 @@ func NewErrAttributeS(err error) error {
 	return fmt.Errorf("ATTRIBUTES_ERROR:%s", err)
 }
 
+// NewErrFilterCycle returns the error for filters referencing themselves, with the path of filter IDs
+func NewErrFilterCycle(path []string) error {
+	return fmt.Errorf("%s: %s", ErrFilterCycle, strings.Join(path, "->"))
+}
+
 // Centralized returns for APIs
 func APIErrorHandler(errIn error) (err error) {
 	cgrErr, ok := errIn.(*CGRError)